@@ -0,0 +1,367 @@
+package collections
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// arrayBlockingQueue is a blocking queue backed by a ring buffer.
+//
+// Waiting goroutines don't use a sync.Cond, as that can't be combined with timeouts or contexts. Instead, every
+// change to the queue closes the changed channel and replaces it, waking every goroutine waiting on it so they can
+// check whether they can now proceed.
+type arrayBlockingQueue[O objects.Object] struct {
+	lock     sync.Mutex
+	deque    *arrayDeque[O]
+	capacity int
+	closed   bool
+	changed  chan struct{}
+}
+
+// NewArrayBlockingQueue creates a new blocking queue backed by a ring buffer, that holds at most capacity elements.
+func NewArrayBlockingQueue[O objects.Object](capacity int) BlockingQueue[O] {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+	return &arrayBlockingQueue[O]{
+		deque:    &arrayDeque[O]{},
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (q *arrayBlockingQueue[O]) Equals(other any) bool {
+	return queueEquals[O](q, other)
+}
+
+// HashCode implements objects.Object.
+func (q *arrayBlockingQueue[O]) HashCode() uint64 {
+	return queueHashCode[O](q)
+}
+
+// String implements objects.Object.
+func (q *arrayBlockingQueue[O]) String() string {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (q *arrayBlockingQueue[O]) MarshalJSON() ([]byte, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object.
+func (q *arrayBlockingQueue[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(values) > q.capacity {
+		return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "out of bounds"), "capacity", q.capacity)
+	}
+	q.deque.Clear()
+	for _, value := range values {
+		_ = q.deque.OfferLast(value)
+	}
+	q.signal()
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot taken when it is created, so the queue
+// isn't locked while the caller iterates.
+func (q *arrayBlockingQueue[O]) Iterator() objects.Iterator[O] {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return objects.NewSliceIterator(q.deque.slice())
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (q *arrayBlockingQueue[O]) Elems() iter.Seq[O] {
+	return objects.SequenceFrom[O](q)
+}
+
+// Add implements Collection.
+func (q *arrayBlockingQueue[O]) Add(value O) error {
+	return q.Offer(value)
+}
+
+// AddAll implements Collection.
+func (q *arrayBlockingQueue[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](q, values)
+}
+
+// Remove implements Collection.
+func (q *arrayBlockingQueue[O]) Remove(value O) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if err := q.deque.Remove(value); err != nil {
+		return err
+	}
+	q.signal()
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (q *arrayBlockingQueue[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](q, values)
+}
+
+// Contains implements Collection.
+func (q *arrayBlockingQueue[O]) Contains(value O) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (q *arrayBlockingQueue[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](q, values)
+}
+
+// RemoveIf implements Collection. The condition is called with the queue locked, so it must not use the queue.
+func (q *arrayBlockingQueue[O]) RemoveIf(condition func(value O) bool) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	removed := q.deque.RemoveIf(condition)
+	if removed > 0 {
+		q.signal()
+	}
+	return removed
+}
+
+// RetainAll implements Collection. The given values are copied first, so the queue isn't locked while reading them.
+func (q *arrayBlockingQueue[O]) RetainAll(values Collection[O]) int {
+	if Collection[O](q) == values {
+		return 0
+	}
+	return collectionRetainAll[O](q, setSnapshot(values))
+}
+
+// Copy implements Collection. The copy has the same capacity as the original, and is open even if the original has
+// been closed.
+func (q *arrayBlockingQueue[O]) Copy() Collection[O] {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return &arrayBlockingQueue[O]{
+		deque:    q.deque.Copy().(*arrayDeque[O]),
+		capacity: q.capacity,
+		changed:  make(chan struct{}),
+	}
+}
+
+// Size implements Collection.
+func (q *arrayBlockingQueue[O]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Size()
+}
+
+// IsEmpty implements Collection.
+func (q *arrayBlockingQueue[O]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Clear implements Collection.
+func (q *arrayBlockingQueue[O]) Clear() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.deque.Clear()
+	q.signal()
+}
+
+// Queue implementation
+
+// Offer implements Queue.
+func (q *arrayBlockingQueue[O]) Offer(value O) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return errors.New(nil, ErrorCodeClosed, "closed")
+	}
+	if q.deque.Size() >= q.capacity {
+		return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "out of bounds"), "capacity", q.capacity)
+	}
+	_ = q.deque.OfferLast(value)
+	q.signal()
+	return nil
+}
+
+// Peep implements Queue.
+func (q *arrayBlockingQueue[O]) Peep() (O, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.PeepFirst()
+}
+
+// Pop implements Queue.
+func (q *arrayBlockingQueue[O]) Pop() (O, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	value, err := q.deque.PopFirst()
+	if err != nil {
+		return value, err
+	}
+	q.signal()
+	return value, nil
+}
+
+// BlockingQueue implementation
+
+// Put implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) Put(value O) error {
+	return q.put(context.Background(), nil, value)
+}
+
+// PutCtx implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) PutCtx(ctx context.Context, value O) error {
+	return q.put(ctx, nil, value)
+}
+
+// OfferTimeout implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) OfferTimeout(value O, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	return q.put(context.Background(), timer.C, value)
+}
+
+// Take implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) Take() (O, error) {
+	return q.take(context.Background(), nil)
+}
+
+// TakeCtx implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) TakeCtx(ctx context.Context) (O, error) {
+	return q.take(ctx, nil)
+}
+
+// PollTimeout implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) PollTimeout(timeout time.Duration) (O, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	return q.take(context.Background(), timer.C)
+}
+
+// Capacity implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) Capacity() int {
+	return q.capacity
+}
+
+// RemainingCapacity implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) RemainingCapacity() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.capacity - q.deque.Size()
+}
+
+// Close implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.signal()
+}
+
+// IsClosed implements BlockingQueue.
+func (q *arrayBlockingQueue[O]) IsClosed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.closed
+}
+
+// array blocking queue
+
+// put adds the value to the queue, waiting until there is space, the queue is closed, the context is done or the
+// timeout fires. A nil timeout waits forever.
+func (q *arrayBlockingQueue[O]) put(ctx context.Context, timeout <-chan time.Time, value O) error {
+	q.lock.Lock()
+	for {
+		if q.closed {
+			q.lock.Unlock()
+			return errors.New(nil, ErrorCodeClosed, "closed")
+		}
+		if q.deque.Size() < q.capacity {
+			_ = q.deque.OfferLast(value)
+			q.signal()
+			q.lock.Unlock()
+			return nil
+		}
+		if err := q.wait(ctx, timeout); err != nil {
+			return err
+		}
+	}
+}
+
+// take removes the first value from the queue, waiting until there is one, the queue is closed, the context is done
+// or the timeout fires. A nil timeout waits forever.
+func (q *arrayBlockingQueue[O]) take(ctx context.Context, timeout <-chan time.Time) (O, error) {
+	q.lock.Lock()
+	for {
+		if q.deque.Size() > 0 {
+			value, _ := q.deque.PopFirst()
+			q.signal()
+			q.lock.Unlock()
+			return value, nil
+		}
+		if q.closed {
+			q.lock.Unlock()
+			var null O
+			return null, errors.New(nil, ErrorCodeClosed, "closed")
+		}
+		if err := q.wait(ctx, timeout); err != nil {
+			var null O
+			return null, err
+		}
+	}
+}
+
+// wait releases the lock, which must be held, and waits for the queue to change. If the queue changes, wait returns
+// with the lock held again. If the context is done or the timeout fires first, wait returns an error without the
+// lock.
+func (q *arrayBlockingQueue[O]) wait(ctx context.Context, timeout <-chan time.Time) error {
+	changed := q.changed
+	q.lock.Unlock()
+
+	select {
+	case <-changed:
+		q.lock.Lock()
+		return nil
+	case <-timeout:
+		return errors.New(nil, ErrorCodeTimeout, "timed out")
+	case <-ctx.Done():
+		return errors.New(ctx.Err(), ErrorCodeCancelled, "cancelled")
+	}
+}
+
+// signal wakes every goroutine waiting for the queue to change. The lock must be held.
+func (q *arrayBlockingQueue[O]) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
@@ -0,0 +1,130 @@
+package collections
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestArrayBlockingQueue_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewArrayBlockingQueue[*objects.String](16)
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayBlockingQueue_Capacity(t *testing.T) {
+	queue := NewArrayBlockingQueue[*objects.Int](2)
+
+	tests.ExecuteE(queue.Offer(objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(queue.Offer(objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(queue.Offer(objects.WrapInt(3))).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.ExecuteE(queue.OfferTimeout(objects.WrapInt(3), time.Millisecond)).ErrorCode(t, ErrorCodeTimeout)
+	tests.Execute(queue.RemainingCapacity()).Equal(t, 0)
+
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapInt(2))
+	tests.Execute2E(queue.Pop()).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute2E(queue.PollTimeout(time.Millisecond)).ErrorCode(t, ErrorCodeTimeout)
+	tests.Execute(queue.RemainingCapacity()).Equal(t, 2)
+}
+
+func TestArrayBlockingQueue_Blocking(t *testing.T) {
+	queue := NewArrayBlockingQueue[*objects.Int](1)
+	tests.ExecuteE(queue.Put(objects.WrapInt(1))).NoError(t)
+
+	// The second put must wait until the consumer makes space.
+	done := make(chan error)
+	go func() {
+		done <- queue.Put(objects.WrapInt(2))
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("put should block while the queue is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	tests.Execute2E(queue.Take()).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.ExecuteE(<-done).NoError(t)
+	tests.Execute2E(queue.Take()).NoError(t).Equal(t, objects.WrapInt(2))
+}
+
+func TestArrayBlockingQueue_Context(t *testing.T) {
+	queue := NewArrayBlockingQueue[*objects.Int](1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+	tests.Execute2E(queue.TakeCtx(ctx)).ErrorCode(t, ErrorCodeCancelled)
+
+	tests.ExecuteE(queue.Put(objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(queue.PutCtx(ctx, objects.WrapInt(2))).ErrorCode(t, ErrorCodeCancelled)
+}
+
+func TestArrayBlockingQueue_Close(t *testing.T) {
+	queue := NewArrayBlockingQueue[*objects.Int](1)
+
+	done := make(chan error)
+	go func() {
+		_, err := queue.Take()
+		done <- err
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	queue.Close()
+	tests.ExecuteE(<-done).ErrorCode(t, ErrorCodeClosed)
+	tests.Execute(queue.IsClosed()).Equal(t, true)
+	tests.ExecuteE(queue.Put(objects.WrapInt(1))).ErrorCode(t, ErrorCodeClosed)
+
+	// Elements already in a closed queue can still be drained.
+	queue = NewArrayBlockingQueue[*objects.Int](1)
+	tests.ExecuteE(queue.Put(objects.WrapInt(1))).NoError(t)
+	queue.Close()
+	tests.Execute2E(queue.Take()).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(queue.Take()).ErrorCode(t, ErrorCodeClosed)
+}
+
+func TestArrayBlockingQueue_ProducerConsumer(t *testing.T) {
+	queue := NewArrayBlockingQueue[*objects.Int](4)
+
+	const producers = 8
+	const values = 100
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for ix := 0; ix < values; ix++ {
+				if err := queue.Put(objects.WrapInt(p*values + ix)); err != nil {
+					t.Error(err)
+				}
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		queue.Close()
+	}()
+
+	seen := make(map[int]bool)
+	for {
+		value, err := queue.Take()
+		if err != nil {
+			tests.ExecuteE(err).ErrorCode(t, ErrorCodeClosed)
+			break
+		}
+		seen[value.Unwrap()] = true
+	}
+	tests.Execute(len(seen)).Equal(t, producers*values)
+}
@@ -0,0 +1,352 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+const arrayDequeMinCapacity = 8
+
+// arrayDeque is a deque backed by a ring buffer. The buffer doubles in size whenever it fills up, so adding to either
+// end is amortised constant time and doesn't allocate per element.
+type arrayDeque[O objects.Object] struct {
+	values []O
+
+	// head is the index in values of the first element.
+	head int
+	size int
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
+}
+
+// NewArrayDeque creates a new deque backed by a ring buffer, with the given elements.
+func NewArrayDeque[O objects.Object](elems ...O) Deque[O] {
+	deque := &arrayDeque[O]{}
+	for _, elem := range elems {
+		_ = deque.OfferLast(elem)
+	}
+	return deque
+}
+
+// NewArrayDequeWith creates an empty deque backed by a ring buffer, with the buffer configured by the given options.
+func NewArrayDequeWith[O objects.Object](opts ...Option) Deque[O] {
+	options := newOptions(opts)
+	deque := &arrayDeque[O]{}
+	deque.EnsureCapacity(options.capacity)
+	return deque
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (deque *arrayDeque[O]) Equals(other any) bool {
+	return dequeEquals[O](deque, other)
+}
+
+// HashCode implements objects.Object.
+func (deque *arrayDeque[O]) HashCode() uint64 {
+	return dequeHashCode[O](deque)
+}
+
+// String implements objects.Object.
+func (deque *arrayDeque[O]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+	for ix := 0; ix < deque.size; ix++ {
+		if ix == 0 {
+			buffer.WriteString(deque.get(ix).String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", deque.get(ix)))
+		}
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (deque *arrayDeque[O]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deque.slice())
+}
+
+// UnmarshalJSON implements objects.Object.
+func (deque *arrayDeque[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	deque.values = values
+	deque.head = 0
+	deque.size = len(values)
+	deque.modCount = deque.modCount + 1
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (deque *arrayDeque[O]) Iterator() objects.Iterator[O] {
+	return &arrayDequeIterator[O]{
+		deque:    deque,
+		modCount: deque.modCount,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (deque *arrayDeque[O]) Elems() iter.Seq[O] {
+	return objects.SequenceFrom[O](deque)
+}
+
+// Add implements Collection.
+func (deque *arrayDeque[O]) Add(value O) error {
+	return deque.OfferLast(value)
+}
+
+// AddAll implements Collection.
+func (deque *arrayDeque[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](deque, values)
+}
+
+// Remove implements Collection.
+func (deque *arrayDeque[O]) Remove(value O) error {
+	ix := deque.indexOf(value)
+	if ix < 0 {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+	deque.removeAt(ix)
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (deque *arrayDeque[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](deque, values)
+}
+
+// Contains implements Collection.
+func (deque *arrayDeque[O]) Contains(value O) bool {
+	return deque.indexOf(value) >= 0
+}
+
+// ContainsAll implements Collection.
+func (deque *arrayDeque[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](deque, values)
+}
+
+// RemoveIf implements Collection. The remaining elements are compacted in place.
+func (deque *arrayDeque[O]) RemoveIf(condition func(value O) bool) int {
+	kept := 0
+	for ix := 0; ix < deque.size; ix++ {
+		value := deque.get(ix)
+		if !condition(value) {
+			deque.values[deque.index(kept)] = value
+			kept = kept + 1
+		}
+	}
+
+	removed := deque.size - kept
+	if removed > 0 {
+		var null O
+		for ix := kept; ix < deque.size; ix++ {
+			deque.values[deque.index(ix)] = null
+		}
+		deque.size = kept
+		deque.modCount = deque.modCount + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (deque *arrayDeque[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](deque, values)
+}
+
+// Copy implements Collection.
+func (deque *arrayDeque[O]) Copy() Collection[O] {
+	return &arrayDeque[O]{
+		values: deque.slice(),
+		size:   deque.size,
+	}
+}
+
+// Size implements Collection.
+func (deque *arrayDeque[O]) Size() int {
+	return deque.size
+}
+
+// IsEmpty implements Collection.
+func (deque *arrayDeque[O]) IsEmpty() bool {
+	return deque.size == 0
+}
+
+// Clear implements Collection.
+func (deque *arrayDeque[O]) Clear() {
+	deque.values = nil
+	deque.head = 0
+	deque.size = 0
+	deque.modCount = deque.modCount + 1
+}
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (deque *arrayDeque[O]) EnsureCapacity(capacity int) {
+	if capacity <= len(deque.values) {
+		return
+	}
+	deque.resize(capacity)
+}
+
+// TrimToSize implements Resizable.
+func (deque *arrayDeque[O]) TrimToSize() {
+	if deque.size == 0 {
+		deque.values = nil
+		deque.head = 0
+		return
+	}
+	deque.resize(deque.size)
+}
+
+// Deque implementation
+
+// OfferFirst implements Deque.
+func (deque *arrayDeque[O]) OfferFirst(value O) error {
+	deque.grow()
+	deque.head = deque.index(-1)
+	deque.values[deque.head] = value
+	deque.size = deque.size + 1
+	deque.modCount = deque.modCount + 1
+	return nil
+}
+
+// OfferLast implements Deque.
+func (deque *arrayDeque[O]) OfferLast(value O) error {
+	deque.grow()
+	deque.values[deque.index(deque.size)] = value
+	deque.size = deque.size + 1
+	deque.modCount = deque.modCount + 1
+	return nil
+}
+
+// PeepFirst implements Deque.
+func (deque *arrayDeque[O]) PeepFirst() (O, error) {
+	if deque.size == 0 {
+		var null O
+		return null, errors.New(nil, ErrorCodeOutOfBounds, "out of bounds")
+	}
+	return deque.get(0), nil
+}
+
+// PeepLast implements Deque.
+func (deque *arrayDeque[O]) PeepLast() (O, error) {
+	if deque.size == 0 {
+		var null O
+		return null, errors.New(nil, ErrorCodeOutOfBounds, "out of bounds")
+	}
+	return deque.get(deque.size - 1), nil
+}
+
+// PopFirst implements Deque.
+func (deque *arrayDeque[O]) PopFirst() (O, error) {
+	var null O
+	if deque.size == 0 {
+		return null, errors.New(nil, ErrorCodeOutOfBounds, "out of bounds")
+	}
+
+	value := deque.values[deque.head]
+	// Clear the slot so the buffer doesn't keep the value alive.
+	deque.values[deque.head] = null
+	deque.head = deque.index(1)
+	deque.size = deque.size - 1
+	deque.modCount = deque.modCount + 1
+	return value, nil
+}
+
+// PopLast implements Deque.
+func (deque *arrayDeque[O]) PopLast() (O, error) {
+	var null O
+	if deque.size == 0 {
+		return null, errors.New(nil, ErrorCodeOutOfBounds, "out of bounds")
+	}
+
+	ix := deque.index(deque.size - 1)
+	value := deque.values[ix]
+	deque.values[ix] = null
+	deque.size = deque.size - 1
+	deque.modCount = deque.modCount + 1
+	return value, nil
+}
+
+// array deque
+
+// index converts a position relative to the head into an index into values.
+func (deque *arrayDeque[O]) index(position int) int {
+	ix := (deque.head + position) % len(deque.values)
+	if ix < 0 {
+		ix = ix + len(deque.values)
+	}
+	return ix
+}
+
+// get returns the element at the given position relative to the head.
+func (deque *arrayDeque[O]) get(position int) O {
+	return deque.values[deque.index(position)]
+}
+
+func (deque *arrayDeque[O]) indexOf(value O) int {
+	for ix := 0; ix < deque.size; ix++ {
+		if deque.get(ix).Equals(value) {
+			return ix
+		}
+	}
+	return -1
+}
+
+// removeAt removes the element at the given position relative to the head, shifting the later elements down.
+func (deque *arrayDeque[O]) removeAt(position int) {
+	for ix := position; ix < deque.size-1; ix++ {
+		deque.values[deque.index(ix)] = deque.get(ix + 1)
+	}
+
+	var null O
+	deque.values[deque.index(deque.size-1)] = null
+	deque.size = deque.size - 1
+	deque.modCount = deque.modCount + 1
+}
+
+// slice returns the elements of the deque in order, in a newly allocated slice.
+func (deque *arrayDeque[O]) slice() []O {
+	values := make([]O, deque.size)
+	if deque.size == 0 {
+		return values
+	}
+
+	// The elements occupy at most two contiguous runs of the buffer.
+	n := copy(values, deque.values[deque.head:min(deque.head+deque.size, len(deque.values))])
+	copy(values[n:], deque.values[:deque.size-n])
+	return values
+}
+
+// grow makes sure there is room for at least one more element.
+func (deque *arrayDeque[O]) grow() {
+	if deque.size < len(deque.values) {
+		return
+	}
+
+	deque.resize(max(len(deque.values)*2, arrayDequeMinCapacity))
+}
+
+// resize moves the elements into a new buffer with the given capacity, starting from the beginning of the buffer.
+func (deque *arrayDeque[O]) resize(capacity int) {
+	values := make([]O, capacity)
+	copy(values, deque.slice())
+	deque.values = values
+	deque.head = 0
+}
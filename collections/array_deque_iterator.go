@@ -0,0 +1,28 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// arrayDequeIterator is an iterator for arrayDeque, running from the front to the end of the deque.
+type arrayDequeIterator[O objects.Object] struct {
+	deque   *arrayDeque[O]
+	current int
+
+	// modCount is the modification count of the deque when the iterator was created.
+	modCount int
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *arrayDequeIterator[O]) HasNext() bool {
+	return iterator.current < iterator.deque.size
+}
+
+// Next implements objects.Iterator.
+func (iterator *arrayDequeIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.deque.modCount)
+	if iterator.current >= iterator.deque.size {
+		panic("out of bounds")
+	}
+	value := iterator.deque.get(iterator.current)
+	iterator.current = iterator.current + 1
+	return value
+}
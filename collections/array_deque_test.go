@@ -0,0 +1,125 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestArrayDeque_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewArrayDeque[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayDeque_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewArrayDeque[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayDeque(t *testing.T) {
+	deque := NewArrayDeque[*objects.String]()
+
+	tests.Execute2E(deque.PeepFirst()).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute2E(deque.PopLast()).ErrorCode(t, ErrorCodeOutOfBounds)
+
+	tests.ExecuteE(deque.OfferLast(objects.WrapString("b"))).NoError(t)
+	tests.ExecuteE(deque.OfferFirst(objects.WrapString("a"))).NoError(t)
+	tests.ExecuteE(deque.OfferLast(objects.WrapString("c"))).NoError(t)
+	tests.Execute(deque.String()).Equal(t, "[a,b,c]")
+
+	tests.Execute2E(deque.PeepFirst()).NoError(t).Equal(t, objects.WrapString("a"))
+	tests.Execute2E(deque.PeepLast()).NoError(t).Equal(t, objects.WrapString("c"))
+	tests.Execute2E(deque.PopFirst()).NoError(t).Equal(t, objects.WrapString("a"))
+	tests.Execute2E(deque.PopLast()).NoError(t).Equal(t, objects.WrapString("c"))
+	tests.Execute2E(deque.PopLast()).NoError(t).Equal(t, objects.WrapString("b"))
+	tests.Execute(deque.IsEmpty()).Equal(t, true)
+}
+
+func TestArrayDeque_Growth(t *testing.T) {
+	deque := NewArrayDeque[*objects.Int]()
+
+	// Push from both ends so the contents wrap around the buffer as it grows.
+	for ix := 0; ix < 100; ix++ {
+		if ix%2 == 0 {
+			tests.ExecuteE(deque.OfferFirst(objects.WrapInt(-ix))).NoError(t)
+		} else {
+			tests.ExecuteE(deque.OfferLast(objects.WrapInt(ix))).NoError(t)
+		}
+	}
+	tests.Execute(deque.Size()).Equal(t, 100)
+
+	previous := -1000
+	for value := range deque.Elems() {
+		if value.Unwrap() <= previous {
+			t.Fatalf("expected ascending values, got %d after %d", value.Unwrap(), previous)
+		}
+		previous = value.Unwrap()
+	}
+
+	tests.ExecuteE(deque.Remove(objects.WrapInt(0))).NoError(t)
+	tests.Execute(deque.Contains(objects.WrapInt(0))).Equal(t, false)
+	tests.Execute(deque.Size()).Equal(t, 99)
+
+	data, err := json.Marshal(deque)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+
+	other := NewArrayDeque[*objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.Equals(deque)).Equal(t, true)
+	tests.Execute(deque.Copy().Equals(deque)).Equal(t, true)
+}
+
+func TestArrayDeque_Capacity(t *testing.T) {
+	deque := NewArrayDequeWith[*objects.String](WithCapacity(20))
+	tests.Execute(len(deque.(*arrayDeque[*objects.String]).values)).Equal(t, 20)
+
+	// Wrap around the end of the buffer, so trimming has to unwrap it.
+	for ix := 0; ix < 15; ix++ {
+		tests.ExecuteE(deque.OfferLast(objects.WrapString("x"))).NoError(t)
+	}
+	for ix := 0; ix < 10; ix++ {
+		_, _ = deque.PopFirst()
+	}
+	tests.ExecuteE(deque.OfferLast(objects.WrapString("a"))).NoError(t)
+	for ix := 0; ix < 10; ix++ {
+		tests.ExecuteE(deque.OfferLast(objects.WrapString("b"))).NoError(t)
+	}
+
+	deque.(Resizable).TrimToSize()
+	tests.Execute(len(deque.(*arrayDeque[*objects.String]).values)).Equal(t, 16)
+	tests.Execute2E(deque.PeepFirst()).NoError(t).Equal(t, objects.WrapString("x"))
+	tests.Execute2E(deque.PeepLast()).NoError(t).Equal(t, objects.WrapString("b"))
+	tests.Execute(deque.(*arrayDeque[*objects.String]).indexOf(objects.WrapString("a"))).Equal(t, 5)
+}
+
+func TestArrayDeque_RemoveIf(t *testing.T) {
+	deque := NewArrayDeque[*objects.Int]()
+
+	// Push from both ends so the contents wrap around the buffer.
+	for ix := 1; ix <= 10; ix++ {
+		tests.ExecuteE(deque.OfferLast(objects.WrapInt(ix))).NoError(t)
+		tests.ExecuteE(deque.OfferFirst(objects.WrapInt(-ix))).NoError(t)
+	}
+
+	removed := deque.RemoveIf(func(value *objects.Int) bool {
+		return value.Unwrap()%2 == 0
+	})
+	tests.Execute(removed).Equal(t, 10)
+	tests.Execute(deque.String()).Equal(t, "[-9,-7,-5,-3,-1,1,3,5,7,9]")
+
+	tests.ExecuteE(deque.OfferFirst(objects.WrapInt(0))).NoError(t)
+	tests.ExecuteE(deque.OfferLast(objects.WrapInt(10))).NoError(t)
+	tests.Execute(deque.String()).Equal(t, "[0,-9,-7,-5,-3,-1,1,3,5,7,9,10]")
+}
@@ -11,6 +11,9 @@ import (
 
 type arrayList[O objects.Object] struct {
 	values []O
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
 }
 
 // NewArrayList creates a basic array list with the given elements.
@@ -20,6 +23,14 @@ func NewArrayList[O objects.Object](elems ...O) List[O] {
 	return list
 }
 
+// NewArrayListWith creates an empty array list, with its backing storage configured by the given options.
+func NewArrayListWith[O objects.Object](opts ...Option) List[O] {
+	options := newOptions(opts)
+	return &arrayList[O]{
+		values: make([]O, 0, options.capacity),
+	}
+}
+
 // Object implementation
 
 // Equals implements objects.Object.
@@ -44,6 +55,7 @@ func (list *arrayList[O]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements objects.Object.
 func (list *arrayList[O]) UnmarshalJSON(bytes []byte) error {
+	list.modCount = list.modCount + 1
 	return json.Unmarshal(bytes, &list.values)
 }
 
@@ -51,10 +63,7 @@ func (list *arrayList[O]) UnmarshalJSON(bytes []byte) error {
 
 // Iterator implements objects.Iterable.
 func (list *arrayList[O]) Iterator() objects.Iterator[O] {
-	return &arrayListIterator[O]{
-		current: 0,
-		list:    list,
-	}
+	return list.ListIterator()
 }
 
 // Collection implementation
@@ -74,6 +83,31 @@ func (list *arrayList[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](list, values)
 }
 
+// RemoveIf implements Collection. The remaining elements are compacted in place.
+func (list *arrayList[O]) RemoveIf(condition func(value O) bool) int {
+	kept := 0
+	for _, value := range list.values {
+		if !condition(value) {
+			list.values[kept] = value
+			kept = kept + 1
+		}
+	}
+
+	removed := len(list.values) - kept
+	if removed > 0 {
+		// Clear the tail so the backing array doesn't keep the values alive.
+		clear(list.values[kept:])
+		list.values = list.values[:kept]
+		list.modCount = list.modCount + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (list *arrayList[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](list, values)
+}
+
 // Add implements Collection.
 func (list *arrayList[O]) Add(value O) error {
 	// Adding to a list is the same as inserting at the end.
@@ -122,6 +156,19 @@ func (list *arrayList[O]) IsEmpty() bool {
 // Clear implements Collection.
 func (list *arrayList[O]) Clear() {
 	list.values = nil
+	list.modCount = list.modCount + 1
+}
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (list *arrayList[O]) EnsureCapacity(capacity int) {
+	list.values = growSlice(list.values, capacity)
+}
+
+// TrimToSize implements Resizable.
+func (list *arrayList[O]) TrimToSize() {
+	list.values = trimSlice(list.values)
 }
 
 // List implementation
@@ -145,6 +192,7 @@ func (list *arrayList[O]) Get(ix int) (O, error) {
 func (list *arrayList[O]) Insert(value O, ix int) error {
 	if ix == len(list.values) {
 		list.values = append(list.values, value)
+		list.modCount = list.modCount + 1
 		return nil
 	}
 
@@ -154,6 +202,7 @@ func (list *arrayList[O]) Insert(value O, ix int) error {
 
 	list.values = append(list.values[:ix+1], list.values[ix:]...)
 	list.values[ix] = value
+	list.modCount = list.modCount + 1
 	return nil
 }
 
@@ -178,12 +227,46 @@ func (list *arrayList[O]) RemoveAt(ix int) (O, error) {
 
 	obj := list.values[ix]
 	list.values = append(list.values[:ix], list.values[ix+1:]...)
+	list.modCount = list.modCount + 1
 	return obj, nil
 }
 
+// ReplaceAll implements List.
+func (list *arrayList[O]) ReplaceAll(fn func(value O) O) {
+	for ix, value := range list.values {
+		list.values[ix] = fn(value)
+	}
+}
+
+// ListIterator implements List.
+func (list *arrayList[O]) ListIterator() ListIterator[O] {
+	return &arrayListIterator[O]{
+		current:  0,
+		list:     list,
+		last:     -1,
+		modCount: list.modCount,
+	}
+}
+
+// ListIteratorAt implements List.
+func (list *arrayList[O]) ListIteratorAt(ix int) (ListIterator[O], error) {
+	if ix < 0 || ix > len(list.values) {
+		return nil, errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
+	}
+	return &arrayListIterator[O]{
+		current:  ix,
+		list:     list,
+		last:     -1,
+		modCount: list.modCount,
+	}, nil
+}
+
+// array list
+
 // sort sorts the list using the given comparator.
 func (list *arrayList[O]) sort(comparator objects.Comparator[O]) {
 	sort.Slice(list.values, func(i, j int) bool {
 		return comparator.Compare(list.values[i], list.values[j]) < 0
 	})
+	list.modCount = list.modCount + 1
 }
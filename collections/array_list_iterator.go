@@ -6,6 +6,12 @@ import "github.com/pasataleo/go-objects/objects"
 type arrayListIterator[O objects.Object] struct {
 	current int
 	list    *arrayList[O]
+
+	// last is the index of the element most recently returned by Next or Previous, or -1 if there isn't one.
+	last int
+
+	// modCount is the modification count of the list when the iterator was created, or last changed the list.
+	modCount int
 }
 
 // HasNext implements objects.Iterator.
@@ -15,10 +21,78 @@ func (iterator *arrayListIterator[O]) HasNext() bool {
 
 // Next implements objects.Iterator.
 func (iterator *arrayListIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
 	item, err := iterator.list.Get(iterator.current)
 	if err != nil {
 		panic(err)
 	}
+	iterator.last = iterator.current
 	iterator.current = iterator.current + 1
 	return item
 }
+
+// HasPrevious implements ListIterator.
+func (iterator *arrayListIterator[O]) HasPrevious() bool {
+	return iterator.current > 0
+}
+
+// Previous implements ListIterator.
+func (iterator *arrayListIterator[O]) Previous() O {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+	item, err := iterator.list.Get(iterator.current - 1)
+	if err != nil {
+		panic(err)
+	}
+	iterator.current = iterator.current - 1
+	iterator.last = iterator.current
+	return item
+}
+
+// NextIndex implements ListIterator.
+func (iterator *arrayListIterator[O]) NextIndex() int {
+	return iterator.current
+}
+
+// PreviousIndex implements ListIterator.
+func (iterator *arrayListIterator[O]) PreviousIndex() int {
+	return iterator.current - 1
+}
+
+// Remove implements ListIterator.
+func (iterator *arrayListIterator[O]) Remove() {
+	if iterator.last < 0 {
+		noCurrentElement()
+	}
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	if _, err := iterator.list.RemoveAt(iterator.last); err != nil {
+		panic(err)
+	}
+	iterator.current = iterator.last
+	iterator.last = -1
+	iterator.modCount = iterator.list.modCount
+}
+
+// Set implements ListIterator.
+func (iterator *arrayListIterator[O]) Set(value O) {
+	if iterator.last < 0 {
+		noCurrentElement()
+	}
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	if _, err := iterator.list.Replace(value, iterator.last); err != nil {
+		panic(err)
+	}
+}
+
+// Add implements ListIterator.
+func (iterator *arrayListIterator[O]) Add(value O) {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	if err := iterator.list.Insert(value, iterator.current); err != nil {
+		panic(err)
+	}
+	iterator.current = iterator.current + 1
+	iterator.last = -1
+	iterator.modCount = iterator.list.modCount
+}
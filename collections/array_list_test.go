@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
 )
 
 func TestArrayList_Collection(t *testing.T) {
@@ -16,6 +17,16 @@ func TestArrayList_Collection(t *testing.T) {
 	})
 }
 
+func TestArrayList_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewArrayList[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
 func TestArrayList_List(t *testing.T) {
 	runListTests(t, func() List[*objects.String] {
 		return NewArrayList[*objects.String]()
@@ -27,3 +38,16 @@ func TestArrayList_List(t *testing.T) {
 		"four":  objects.WrapString("four"),
 	})
 }
+
+func TestArrayList_Capacity(t *testing.T) {
+	list := NewArrayListWith[*objects.String](WithCapacity(10))
+	tests.Execute(cap(list.(*arrayList[*objects.String]).values)).Equal(t, 10)
+
+	tests.ExecuteE(list.Add(objects.WrapString("one"))).NoError(t)
+	list.(Resizable).EnsureCapacity(100)
+	tests.Execute(cap(list.(*arrayList[*objects.String]).values) >= 100).Equal(t, true)
+
+	list.(Resizable).TrimToSize()
+	tests.Execute(cap(list.(*arrayList[*objects.String]).values)).Equal(t, 1)
+	tests.Execute2E(list.Get(0)).NoError(t).Equal(t, objects.WrapString("one"))
+}
@@ -0,0 +1,157 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type arrayQueue[O objects.Object] struct {
+	deque *arrayDeque[O]
+}
+
+// NewArrayQueue creates a new queue backed by a ring buffer.
+func NewArrayQueue[O objects.Object]() Queue[O] {
+	return &arrayQueue[O]{
+		deque: &arrayDeque[O]{},
+	}
+}
+
+// NewArrayQueueWith creates a new queue backed by a ring buffer, with the buffer configured by the given options.
+func NewArrayQueueWith[O objects.Object](opts ...Option) Queue[O] {
+	return &arrayQueue[O]{
+		deque: NewArrayDequeWith[O](opts...).(*arrayDeque[O]),
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (q *arrayQueue[O]) Equals(other any) bool {
+	return queueEquals[O](q, other)
+}
+
+// HashCode implements objects.Object.
+func (q *arrayQueue[O]) HashCode() uint64 {
+	return queueHashCode[O](q)
+}
+
+// String implements objects.Object.
+func (q *arrayQueue[O]) String() string {
+	return q.deque.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (q *arrayQueue[O]) MarshalJSON() ([]byte, error) {
+	return q.deque.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object.
+func (q *arrayQueue[O]) UnmarshalJSON(bytes []byte) error {
+	return q.deque.UnmarshalJSON(bytes)
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (q *arrayQueue[O]) Iterator() objects.Iterator[O] {
+	return q.deque.Iterator()
+}
+
+// Collection implementation
+
+// Elems implements objects.Collection.
+func (q *arrayQueue[O]) Elems() iter.Seq[O] {
+	return objects.SequenceFrom[O](q)
+}
+
+// Add implements objects.Collection.
+func (q *arrayQueue[O]) Add(value O) error {
+	return q.Offer(value)
+}
+
+// AddAll implements objects.Collection.
+func (q *arrayQueue[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](q, values)
+}
+
+// Remove implements objects.Collection.
+func (q *arrayQueue[O]) Remove(value O) error {
+	return q.deque.Remove(value)
+}
+
+// RemoveAll implements objects.Collection.
+func (q *arrayQueue[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](q, values)
+}
+
+// Contains implements objects.Collection.
+func (q *arrayQueue[O]) Contains(value O) bool {
+	return q.deque.Contains(value)
+}
+
+// ContainsAll implements objects.Collection.
+func (q *arrayQueue[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](q, values)
+}
+
+// RemoveIf implements Collection.
+func (q *arrayQueue[O]) RemoveIf(condition func(value O) bool) int {
+	return q.deque.RemoveIf(condition)
+}
+
+// RetainAll implements Collection.
+func (q *arrayQueue[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](q, values)
+}
+
+// Copy implements objects.Collection.
+func (q *arrayQueue[O]) Copy() Collection[O] {
+	return &arrayQueue[O]{
+		deque: q.deque.Copy().(*arrayDeque[O]),
+	}
+}
+
+// Size implements objects.Collection.
+func (q *arrayQueue[O]) Size() int {
+	return q.deque.Size()
+}
+
+// IsEmpty implements objects.Collection.
+func (q *arrayQueue[O]) IsEmpty() bool {
+	return q.deque.Size() == 0
+}
+
+// Clear implements objects.Collection.
+func (q *arrayQueue[O]) Clear() {
+	q.deque.Clear()
+}
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (q *arrayQueue[O]) EnsureCapacity(capacity int) {
+	q.deque.EnsureCapacity(capacity)
+}
+
+// TrimToSize implements Resizable.
+func (q *arrayQueue[O]) TrimToSize() {
+	q.deque.TrimToSize()
+}
+
+// Queue implementation
+
+// Offer implements Queue.
+func (q *arrayQueue[O]) Offer(value O) error {
+	return q.deque.OfferLast(value)
+}
+
+// Peep implements Queue.
+func (q *arrayQueue[O]) Peep() (O, error) {
+	return q.deque.PeepFirst()
+}
+
+// Pop implements Queue.
+func (q *arrayQueue[O]) Pop() (O, error) {
+	return q.deque.PopFirst()
+}
@@ -0,0 +1,40 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestArrayQueue_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewArrayQueue[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayQueue_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewArrayQueue[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayQueue(t *testing.T) {
+	queue := NewArrayQueue[*objects.String]()
+
+	tests.ExecuteE(queue.Offer(objects.WrapString("a"))).NoError(t)
+	tests.ExecuteE(queue.Offer(objects.WrapString("b"))).NoError(t)
+
+	tests.Execute2E(queue.Peep()).NoError(t).Equal(t, objects.WrapString("a"))
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapString("a"))
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapString("b"))
+	tests.Execute2E(queue.Pop()).ErrorCode(t, ErrorCodeOutOfBounds)
+}
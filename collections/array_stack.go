@@ -0,0 +1,157 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type arrayStack[O objects.Object] struct {
+	deque *arrayDeque[O]
+}
+
+// NewArrayStack creates a new stack backed by a ring buffer.
+func NewArrayStack[O objects.Object]() Stack[O] {
+	return &arrayStack[O]{
+		deque: &arrayDeque[O]{},
+	}
+}
+
+// NewArrayStackWith creates a new stack backed by a ring buffer, with the buffer configured by the given options.
+func NewArrayStackWith[O objects.Object](opts ...Option) Stack[O] {
+	return &arrayStack[O]{
+		deque: NewArrayDequeWith[O](opts...).(*arrayDeque[O]),
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (s *arrayStack[O]) Equals(other any) bool {
+	return stackEquals[O](s, other)
+}
+
+// HashCode implements objects.Object.
+func (s *arrayStack[O]) HashCode() uint64 {
+	return stackHashCode[O](s)
+}
+
+// String implements objects.Object.
+func (s *arrayStack[O]) String() string {
+	return s.deque.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (s *arrayStack[O]) MarshalJSON() ([]byte, error) {
+	return s.deque.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object.
+func (s *arrayStack[O]) UnmarshalJSON(bytes []byte) error {
+	return s.deque.UnmarshalJSON(bytes)
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (s *arrayStack[O]) Iterator() objects.Iterator[O] {
+	return s.deque.Iterator()
+}
+
+// Collection implementation
+
+// Elems implements objects.Collection.
+func (s *arrayStack[O]) Elems() iter.Seq[O] {
+	return objects.SequenceFrom[O](s)
+}
+
+// Add implements objects.Collection.
+func (s *arrayStack[O]) Add(value O) error {
+	return s.Offer(value)
+}
+
+// AddAll implements objects.Collection.
+func (s *arrayStack[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](s, values)
+}
+
+// Remove implements objects.Collection.
+func (s *arrayStack[O]) Remove(value O) error {
+	return s.deque.Remove(value)
+}
+
+// RemoveAll implements objects.Collection.
+func (s *arrayStack[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](s, values)
+}
+
+// Contains implements objects.Collection.
+func (s *arrayStack[O]) Contains(value O) bool {
+	return s.deque.Contains(value)
+}
+
+// ContainsAll implements objects.Collection.
+func (s *arrayStack[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](s, values)
+}
+
+// RemoveIf implements Collection.
+func (s *arrayStack[O]) RemoveIf(condition func(value O) bool) int {
+	return s.deque.RemoveIf(condition)
+}
+
+// RetainAll implements Collection.
+func (s *arrayStack[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](s, values)
+}
+
+// Copy implements objects.Collection.
+func (s *arrayStack[O]) Copy() Collection[O] {
+	return &arrayStack[O]{
+		deque: s.deque.Copy().(*arrayDeque[O]),
+	}
+}
+
+// Size implements objects.Collection.
+func (s *arrayStack[O]) Size() int {
+	return s.deque.Size()
+}
+
+// IsEmpty implements objects.Collection.
+func (s *arrayStack[O]) IsEmpty() bool {
+	return s.deque.Size() == 0
+}
+
+// Clear implements objects.Collection.
+func (s *arrayStack[O]) Clear() {
+	s.deque.Clear()
+}
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (s *arrayStack[O]) EnsureCapacity(capacity int) {
+	s.deque.EnsureCapacity(capacity)
+}
+
+// TrimToSize implements Resizable.
+func (s *arrayStack[O]) TrimToSize() {
+	s.deque.TrimToSize()
+}
+
+// Stack implementation
+
+// Offer implements Stack.
+func (s *arrayStack[O]) Offer(value O) error {
+	return s.deque.OfferLast(value)
+}
+
+// Peep implements Stack.
+func (s *arrayStack[O]) Peep() (O, error) {
+	return s.deque.PeepLast()
+}
+
+// Pop implements Stack.
+func (s *arrayStack[O]) Pop() (O, error) {
+	return s.deque.PopLast()
+}
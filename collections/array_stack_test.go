@@ -0,0 +1,40 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestArrayStack_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewArrayStack[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayStack_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewArrayStack[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestArrayStack(t *testing.T) {
+	stack := NewArrayStack[*objects.String]()
+
+	tests.ExecuteE(stack.Offer(objects.WrapString("a"))).NoError(t)
+	tests.ExecuteE(stack.Offer(objects.WrapString("b"))).NoError(t)
+
+	tests.Execute2E(stack.Peep()).NoError(t).Equal(t, objects.WrapString("b"))
+	tests.Execute2E(stack.Pop()).NoError(t).Equal(t, objects.WrapString("b"))
+	tests.Execute2E(stack.Pop()).NoError(t).Equal(t, objects.WrapString("a"))
+	tests.Execute2E(stack.Pop()).ErrorCode(t, ErrorCodeOutOfBounds)
+}
@@ -0,0 +1,15 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// BiMap represents a map whose values are unique as well as its keys, so it can be looked up in either direction.
+//
+// Inserting a value that is already associated with a different key is refused: methods that return an error return
+// an ErrorCodeAlreadyExists error, and the rest panic with one.
+type BiMap[K, V objects.Object] interface {
+	Map[K, V]
+
+	// Inverse returns a view of the map with the keys and values swapped. The view reflects later changes to the map,
+	// and changes made through the view are made to the map.
+	Inverse() BiMap[V, K]
+}
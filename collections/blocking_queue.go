@@ -0,0 +1,54 @@
+package collections
+
+import (
+	"context"
+	"time"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// BlockingQueue is a queue with a fixed capacity, that can wait for space to become available when adding elements
+// and for elements to become available when removing them.
+//
+// The non-blocking methods from Queue never wait: Offer returns an ErrorCodeOutOfBounds error when the queue is full,
+// and Pop returns an ErrorCodeOutOfBounds error when it is empty.
+type BlockingQueue[O objects.Object] interface {
+	Queue[O]
+
+	// Put adds an element to the end of the queue, waiting for space to become available if the queue is full.
+	Put(value O) error
+
+	// PutCtx adds an element to the end of the queue, waiting for space to become available until the context is
+	// done.
+	PutCtx(ctx context.Context, value O) error
+
+	// OfferTimeout adds an element to the end of the queue, waiting up to the given timeout for space to become
+	// available.
+	OfferTimeout(value O, timeout time.Duration) error
+
+	// Take removes and returns the element at the front of the queue, waiting for an element to become available if
+	// the queue is empty.
+	Take() (O, error)
+
+	// TakeCtx removes and returns the element at the front of the queue, waiting for an element to become available
+	// until the context is done.
+	TakeCtx(ctx context.Context) (O, error)
+
+	// PollTimeout removes and returns the element at the front of the queue, waiting up to the given timeout for an
+	// element to become available.
+	PollTimeout(timeout time.Duration) (O, error)
+
+	// Capacity returns the maximum number of elements the queue can hold.
+	Capacity() int
+
+	// RemainingCapacity returns the number of elements that can be added before the queue is full.
+	RemainingCapacity() int
+
+	// Close closes the queue and wakes every waiting goroutine. Adding to a closed queue returns an ErrorCodeClosed
+	// error, while the elements already in the queue can still be removed. Once a closed queue is empty, the
+	// removing methods return an ErrorCodeClosed error instead of waiting.
+	Close()
+
+	// IsClosed returns true if the queue has been closed.
+	IsClosed() bool
+}
@@ -0,0 +1,495 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// Weigher returns the weight of an entry, which counts against the budget of a bounded map. It must return the same,
+// non-negative, weight each time it is called with the same entry.
+type Weigher[K, V objects.Object] func(key K, value V) int64
+
+// BoundedMap is a map whose entries share a weight budget. Whenever a write takes the total weight of the entries over
+// the budget, the map evicts entries in the order chosen by its EvictionPolicy until it is back within it. An entry
+// heavier than the whole budget is evicted as soon as it is written, leaving the other entries in place.
+//
+// Reading or replacing an entry, through methods such as Get, GetOrDefault, Replace and Compute, counts as an access
+// for the policy. Iteration follows the underlying hash map, not the policy.
+type BoundedMap[K, V objects.Object] interface {
+	Map[K, V]
+
+	// Weight returns the total weight of the entries in the map.
+	Weight() int64
+
+	// MaxWeight returns the weight budget of the map.
+	MaxWeight() int64
+
+	// OnEvict sets a function to call with each entry the map evicts, after it has been removed. Entries removed by
+	// Delete, Clear and the other explicit removals aren't passed to it.
+	OnEvict(fn func(key K, value V))
+}
+
+// boundedMap keeps its entries in a hashMap, and tells its policy about every
+// change so the policy can pick the victims. The weight of an entry is worked
+// out again when it is removed, rather than being stored alongside it.
+type boundedMap[K, V objects.Object] struct {
+	values *hashMap[K, V]
+	policy EvictionPolicy[K]
+
+	weigher   Weigher[K, V]
+	weight    int64
+	maxWeight int64
+
+	onEvict func(key K, value V)
+}
+
+// NewBoundedMap creates a new map that holds at most capacity entries, evicting entries in the order chosen by the
+// given policy.
+func NewBoundedMap[K, V objects.Object](policy EvictionPolicy[K], capacity int) BoundedMap[K, V] {
+	return NewWeightedBoundedMap[K, V](policy, int64(capacity), func(K, V) int64 {
+		return 1
+	})
+}
+
+// NewWeightedBoundedMap creates a new map whose entries, weighed by the given weigher, weigh at most maxWeight in
+// total. Entries are evicted in the order chosen by the given policy.
+func NewWeightedBoundedMap[K, V objects.Object](policy EvictionPolicy[K], maxWeight int64, weigher Weigher[K, V]) BoundedMap[K, V] {
+	if maxWeight <= 0 {
+		panic("max weight must be positive")
+	}
+
+	policy.Clear()
+	return &boundedMap[K, V]{
+		values:    NewHashMap[K, V]().(*hashMap[K, V]),
+		policy:    policy,
+		weigher:   weigher,
+		maxWeight: maxWeight,
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object. Comparing maps doesn't count as an access of their entries for either policy.
+func (m *boundedMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *boundedMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *boundedMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *boundedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.values.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object. Entries that don't fit within the budget are evicted as they are read.
+func (m *boundedMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator implements MutableIterator, so entries can be removed while
+// iterating.
+func (m *boundedMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &boundedMapIterator[K, V]{
+		m:        m,
+		iterator: m.values.Iterator().(MutableIterator[MapEntry[K, V]]),
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *boundedMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return m.values.Elems()
+}
+
+// Add implements Collection.
+func (m *boundedMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *boundedMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *boundedMap[K, V]) Remove(value MapEntry[K, V]) error {
+	if !m.values.Contains(value) {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+
+	m.delete(value.GetKey(), value.GetValue())
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (m *boundedMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *boundedMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	return m.values.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (m *boundedMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection.
+func (m *boundedMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	return m.values.RemoveIf(func(value MapEntry[K, V]) bool {
+		if !condition(value) {
+			return false
+		}
+		m.forget(value.GetKey(), value.GetValue())
+		return true
+	})
+}
+
+// RetainAll implements Collection.
+func (m *boundedMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	return collectionRetainAll[MapEntry[K, V]](m, values)
+}
+
+// Copy implements Collection. The copy has the same budget, entries and policy state, but no eviction function.
+func (m *boundedMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	return &boundedMap[K, V]{
+		values:    m.values.Copy().(*hashMap[K, V]),
+		policy:    m.policy.Copy(),
+		weigher:   m.weigher,
+		weight:    m.weight,
+		maxWeight: m.maxWeight,
+	}
+}
+
+// Size implements Collection.
+func (m *boundedMap[K, V]) Size() int {
+	return m.values.Size()
+}
+
+// IsEmpty implements Collection.
+func (m *boundedMap[K, V]) IsEmpty() bool {
+	return m.values.IsEmpty()
+}
+
+// Clear implements Collection.
+func (m *boundedMap[K, V]) Clear() {
+	m.values.Clear()
+	m.policy.Clear()
+	m.weight = 0
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *boundedMap[K, V]) Entries() iter.Seq2[K, V] {
+	return m.values.Entries()
+}
+
+// ContainsKey implements Map.
+func (m *boundedMap[K, V]) ContainsKey(key K) bool {
+	return m.values.ContainsKey(key)
+}
+
+// Put implements Map.
+func (m *boundedMap[K, V]) Put(key K, value V) error {
+	if m.values.ContainsKey(key) {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+
+	m.set(key, value)
+	return nil
+}
+
+// Replace implements Map.
+func (m *boundedMap[K, V]) Replace(key K, value V) (V, error) {
+	current, err := m.values.GetSafe(key)
+	if err != nil {
+		return current, err
+	}
+
+	m.set(key, value)
+	return current, nil
+}
+
+// PutOrReplace implements Map.
+func (m *boundedMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	return m.set(key, value)
+}
+
+// Delete implements Map.
+func (m *boundedMap[K, V]) Delete(key K) (V, error) {
+	value, err := m.values.GetSafe(key)
+	if err != nil {
+		return value, err
+	}
+
+	m.delete(key, value)
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *boundedMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	value, err := m.Delete(key)
+	return value, err == nil
+}
+
+// Get implements Map.
+func (m *boundedMap[K, V]) Get(key K) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements Map.
+func (m *boundedMap[K, V]) GetSafe(key K) (V, error) {
+	value, err := m.values.GetSafe(key)
+	if err == nil {
+		m.policy.Access(key)
+	}
+	return value, err
+}
+
+// GetOrDefault implements Map.
+func (m *boundedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// PutIfAbsent implements Map.
+func (m *boundedMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if current, err := m.GetSafe(key); err == nil {
+		return current, true
+	}
+
+	m.set(key, value)
+	return value, false
+}
+
+// ComputeIfAbsent implements Map.
+func (m *boundedMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	if current, err := m.GetSafe(key); err == nil {
+		return current
+	}
+
+	modCount := m.values.modCount
+	value := fn(key)
+	checkForComodification(modCount, m.values.modCount)
+
+	m.set(key, value)
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (m *boundedMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	current, err := m.values.GetSafe(key)
+	if err != nil {
+		var obj V
+		return obj, false
+	}
+	return m.apply(key, current, true, func() (V, bool) {
+		return fn(key, current)
+	})
+}
+
+// Compute implements Map.
+func (m *boundedMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	current, err := m.values.GetSafe(key)
+	return m.apply(key, current, err == nil, func() (V, bool) {
+		return fn(key, current, err == nil)
+	})
+}
+
+// Merge implements Map.
+func (m *boundedMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	current, err := m.values.GetSafe(key)
+	if err != nil {
+		m.set(key, value)
+		return value, true
+	}
+	return m.apply(key, current, true, func() (V, bool) {
+		return remapping(current, value)
+	})
+}
+
+// ReplaceAllValues implements Map. It doesn't count as an access, and any evictions happen once every value has been
+// replaced.
+func (m *boundedMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	m.values.ReplaceAllValues(func(key K, value V) V {
+		replaced := fn(key, value)
+		m.weight = m.weight - m.weigh(key, value) + m.weigh(key, replaced)
+		return replaced
+	})
+	m.trim()
+}
+
+// Keys implements Map.
+func (m *boundedMap[K, V]) Keys() Collection[K] {
+	return m.values.Keys()
+}
+
+// Values implements Map.
+func (m *boundedMap[K, V]) Values() Collection[V] {
+	return m.values.Values()
+}
+
+// BoundedMap implementation
+
+// Weight implements BoundedMap.
+func (m *boundedMap[K, V]) Weight() int64 {
+	return m.weight
+}
+
+// MaxWeight implements BoundedMap.
+func (m *boundedMap[K, V]) MaxWeight() int64 {
+	return m.maxWeight
+}
+
+// OnEvict implements BoundedMap.
+func (m *boundedMap[K, V]) OnEvict(fn func(key K, value V)) {
+	m.onEvict = fn
+}
+
+// bounded map
+
+// peek returns the value for the given key, without telling the policy about the access.
+func (m *boundedMap[K, V]) peek(key K) (V, bool) {
+	value, err := m.values.GetSafe(key)
+	return value, err == nil
+}
+
+// writesOnRead returns true, as lookups are passed to the policy.
+func (m *boundedMap[K, V]) writesOnRead() bool {
+	return true
+}
+
+// weigh returns the weight of the entry, and panics if it is negative.
+func (m *boundedMap[K, V]) weigh(key K, value V) int64 {
+	weight := m.weigher(key, value)
+	if weight < 0 {
+		panic(errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "weight out of range"), "weight", weight))
+	}
+	return weight
+}
+
+// set associates the key with the value, and then evicts entries until the map is back within its budget.
+func (m *boundedMap[K, V]) set(key K, value V) (V, bool) {
+	current, replaced := m.values.PutOrReplace(key, value)
+	if replaced {
+		m.weight = m.weight - m.weigh(key, current)
+		m.policy.Access(key)
+	} else {
+		m.policy.Insert(key)
+	}
+
+	weight := m.weigh(key, value)
+	m.weight = m.weight + weight
+	if weight > m.maxWeight {
+		m.evict(key, value)
+	}
+	m.trim()
+	return current, replaced
+}
+
+// delete removes the key and its value from the map.
+func (m *boundedMap[K, V]) delete(key K, value V) {
+	_, _ = m.values.DeleteIfPresent(key)
+	m.forget(key, value)
+}
+
+// forget updates the weight and the policy after the entry has been removed from the values.
+func (m *boundedMap[K, V]) forget(key K, value V) {
+	m.weight = m.weight - m.weigh(key, value)
+	m.policy.Remove(key)
+}
+
+// evict removes the entry, and passes it to the eviction function.
+func (m *boundedMap[K, V]) evict(key K, value V) {
+	m.delete(key, value)
+	if m.onEvict != nil {
+		m.onEvict(key, value)
+	}
+}
+
+// trim evicts the victims chosen by the policy until the map is back within its budget.
+func (m *boundedMap[K, V]) trim() {
+	for m.weight > m.maxWeight {
+		key, ok := m.policy.Victim()
+		if !ok {
+			return
+		}
+		m.evict(key, m.values.Get(key))
+	}
+}
+
+// apply calls fn to work out the new value for the key, then stores it, or removes the key if fn returns false.
+func (m *boundedMap[K, V]) apply(key K, current V, present bool, fn func() (V, bool)) (V, bool) {
+	modCount := m.values.modCount
+	value, keep := fn()
+	checkForComodification(modCount, m.values.modCount)
+
+	if !keep {
+		if present {
+			m.delete(key, current)
+		}
+		var obj V
+		return obj, false
+	}
+
+	m.set(key, value)
+	return value, true
+}
+
+type boundedMapIterator[K, V objects.Object] struct {
+	m        *boundedMap[K, V]
+	iterator MutableIterator[MapEntry[K, V]]
+	last     MapEntry[K, V]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *boundedMapIterator[K, V]) HasNext() bool {
+	return iterator.iterator.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *boundedMapIterator[K, V]) Next() MapEntry[K, V] {
+	iterator.last = iterator.iterator.Next()
+	return iterator.last
+}
+
+// Remove implements MutableIterator.
+func (iterator *boundedMapIterator[K, V]) Remove() {
+	iterator.iterator.Remove()
+	iterator.m.forget(iterator.last.GetKey(), iterator.last.GetValue())
+}
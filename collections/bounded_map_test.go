@@ -0,0 +1,195 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestBoundedMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewBoundedMap[*objects.String, *objects.String](NewLFUPolicy[*objects.String](), 16)
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestBoundedMap_FailFast(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runFailFastTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewBoundedMap[*objects.String, *objects.String](NewLRUPolicy[*objects.String](), 16)
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestBoundedMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewBoundedMap[*objects.String, *objects.String](NewFIFOPolicy[*objects.String](), 16)
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestBoundedMap_LFU(t *testing.T) {
+	m := NewBoundedMap[*objects.String, *objects.Int](NewLFUPolicy[*objects.String](), 3)
+
+	var evicted []string
+	m.OnEvict(func(key *objects.String, value *objects.Int) {
+		tests.Execute(m.ContainsKey(key)).Equal(t, false)
+		evicted = append(evicted, key.String())
+	})
+
+	for ix, key := range []string{"a", "b", "c"} {
+		tests.ExecuteE(m.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(0))
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(0))
+	tests.Execute2E(m.Replace(objects.WrapString("c"), objects.WrapInt(5))).NoError(t)
+
+	tests.ExecuteE(m.Put(objects.WrapString("d"), objects.WrapInt(3))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"b"})
+
+	// d is the least used now.
+	_, _ = m.PutOrReplace(objects.WrapString("e"), objects.WrapInt(4))
+	tests.Execute(evicted).Equal(t, []string{"b", "d"})
+
+	// Explicit removals aren't evictions.
+	tests.Execute2E(m.Delete(objects.WrapString("a"))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"b", "d"})
+	tests.Execute(m.Size()).Equal(t, 2)
+	tests.Execute(m.Weight()).Equal(t, int64(2))
+}
+
+func TestBoundedMap_Equals(t *testing.T) {
+	for name, policy := range map[string]func() EvictionPolicy[*objects.String]{
+		"lru": NewLRUPolicy[*objects.String],
+		"lfu": NewLFUPolicy[*objects.String],
+	} {
+		t.Run(name, func(t *testing.T) {
+			onePolicy := &countingPolicy[*objects.String]{EvictionPolicy: policy()}
+			twoPolicy := &countingPolicy[*objects.String]{EvictionPolicy: policy()}
+			one := NewBoundedMap[*objects.String, *objects.Int](onePolicy, 3)
+			two := NewBoundedMap[*objects.String, *objects.Int](twoPolicy, 3)
+			for ix, key := range []string{"a", "b", "c"} {
+				tests.ExecuteE(one.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+				tests.ExecuteE(two.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+			}
+
+			// Comparing isn't an access, so neither policy hears about it.
+			tests.Execute(one.Equals(two)).Equal(t, true)
+			tests.Execute(two.Equals(one)).Equal(t, true)
+			tests.Execute(one.HashCode()).Equal(t, two.HashCode())
+			tests.Execute(onePolicy.accesses).Equal(t, 0)
+			tests.Execute(twoPolicy.accesses).Equal(t, 0)
+
+			tests.ExecuteE(one.Put(objects.WrapString("d"), objects.WrapInt(3))).NoError(t)
+			tests.ExecuteE(two.Put(objects.WrapString("d"), objects.WrapInt(3))).NoError(t)
+			tests.Execute(one.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+			tests.Execute(two.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+		})
+	}
+}
+
+func TestBoundedMap_FIFO(t *testing.T) {
+	m := NewBoundedMap[*objects.String, *objects.Int](NewFIFOPolicy[*objects.String](), 2)
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(1))
+	tests.ExecuteE(m.Put(objects.WrapString("c"), objects.WrapInt(3))).NoError(t)
+
+	tests.Execute(m.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+	tests.Execute(m.ContainsKey(objects.WrapString("b"))).Equal(t, true)
+	tests.Execute(m.ContainsKey(objects.WrapString("c"))).Equal(t, true)
+}
+
+func TestBoundedMap_Weight(t *testing.T) {
+	m := NewWeightedBoundedMap[*objects.String, *objects.String](NewLRUPolicy[*objects.String](), 10, func(key *objects.String, value *objects.String) int64 {
+		return int64(len(value.String()))
+	})
+
+	var evicted []string
+	m.OnEvict(func(key *objects.String, value *objects.String) {
+		evicted = append(evicted, key.String())
+	})
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapString("aaaa"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapString("bbbb"))).NoError(t)
+	tests.Execute(m.Weight()).Equal(t, int64(8))
+
+	// Growing b takes the map over its budget, so a goes.
+	tests.Execute2E(m.Replace(objects.WrapString("b"), objects.WrapString("bbbbbbb"))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"a"})
+	tests.Execute(m.Weight()).Equal(t, int64(7))
+
+	// An entry heavier than the budget is evicted straight away, leaving b.
+	tests.ExecuteE(m.Put(objects.WrapString("c"), objects.WrapString("ccccccccccc"))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"a", "c"})
+	tests.Execute(m.String()).Equal(t, "{b:bbbbbbb}")
+
+	// Replacing every value trims the map once they have all been replaced.
+	tests.ExecuteE(m.Put(objects.WrapString("d"), objects.WrapString("ddd"))).NoError(t)
+	m.ReplaceAllValues(func(key *objects.String, value *objects.String) *objects.String {
+		return objects.WrapString(value.String() + value.String())
+	})
+	tests.Execute(evicted).Equal(t, []string{"a", "c", "b"})
+	tests.Execute(m.Weight()).Equal(t, int64(6))
+
+	iterator := m.Iterator().(MutableIterator[MapEntry[*objects.String, *objects.String]])
+	for iterator.HasNext() {
+		iterator.Next()
+		iterator.Remove()
+	}
+	tests.Execute(m.Weight()).Equal(t, int64(0))
+	tests.Execute(m.MaxWeight()).Equal(t, int64(10))
+}
+
+func TestBoundedMap_JSON(t *testing.T) {
+	m := NewBoundedMap[*objects.String, *objects.Int](NewLRUPolicy[*objects.String](), 2)
+	tests.ExecuteE(json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]`), m)).NoError(t)
+	tests.Execute(m.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+	tests.Execute(m.Size()).Equal(t, 2)
+
+	copied := m.Copy().(BoundedMap[*objects.String, *objects.Int])
+	tests.Execute(copied.Get(objects.WrapString("b"))).Equal(t, objects.WrapInt(2))
+	tests.ExecuteE(copied.Put(objects.WrapString("d"), objects.WrapInt(4))).NoError(t)
+	tests.Execute(copied.ContainsKey(objects.WrapString("c"))).Equal(t, false)
+	tests.Execute(m.ContainsKey(objects.WrapString("c"))).Equal(t, true)
+}
+
+// countingPolicy counts the accesses its map tells it about.
+type countingPolicy[K objects.Object] struct {
+	EvictionPolicy[K]
+
+	accesses int
+}
+
+func (policy *countingPolicy[K]) Access(key K) {
+	policy.accesses = policy.accesses + 1
+	policy.EvictionPolicy.Access(key)
+}
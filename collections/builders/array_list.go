@@ -10,9 +10,9 @@ type ArrayListBuilder[V objects.Object] struct {
 	collection collections.List[V]
 }
 
-func NewArrayListBuilder[V objects.Object]() *ArrayListBuilder[V] {
+func NewArrayListBuilder[V objects.Object](opts ...collections.Option) *ArrayListBuilder[V] {
 	return &ArrayListBuilder[V]{
-		collection: collections.NewArrayList[V](),
+		collection: collections.NewArrayListWith[V](opts...),
 	}
 }
 
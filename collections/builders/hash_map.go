@@ -10,9 +10,9 @@ type HashMapBuilder[K, V objects.Object] struct {
 	collection collections.Map[K, V]
 }
 
-func NewHashMapBuilder[K, V objects.Object]() *HashMapBuilder[K, V] {
+func NewHashMapBuilder[K, V objects.Object](opts ...collections.Option) *HashMapBuilder[K, V] {
 	return &HashMapBuilder[K, V]{
-		collection: collections.NewHashMap[K, V](),
+		collection: collections.NewHashMapWith[K, V](opts...),
 	}
 }
 
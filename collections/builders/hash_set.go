@@ -10,9 +10,9 @@ type HashSetBuilder[V objects.Object] struct {
 	collection collections.Set[V]
 }
 
-func NewHashSetBuilder[V objects.Object]() *HashSetBuilder[V] {
+func NewHashSetBuilder[V objects.Object](opts ...collections.Option) *HashSetBuilder[V] {
 	return &HashSetBuilder[V]{
-		collection: collections.NewHashSet[V](),
+		collection: collections.NewHashSetWith[V](opts...),
 	}
 }
 
@@ -0,0 +1,20 @@
+package collections
+
+// CacheStats counts how often a cache was able to answer lookups, and how many entries it has evicted. Caches that
+// load their own values, such as LoadingCache, also count the loads and how many of them failed.
+type CacheStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+
+	Loads        int
+	LoadFailures int
+}
+
+// HitRate returns the fraction of lookups that were hits, or zero if there haven't been any lookups.
+func (stats CacheStats) HitRate() float64 {
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		return float64(stats.Hits) / float64(lookups)
+	}
+	return 0
+}
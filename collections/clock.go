@@ -0,0 +1,69 @@
+package collections
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time for the collections that depend on it, such as ExpiringMap. Supplying a ManualClock lets
+// tests move time forward without waiting.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ManualClock is a Clock that only moves when told to. It is safe for concurrent use.
+type ManualClock interface {
+	Clock
+
+	// Advance moves the clock forward by the given duration.
+	Advance(duration time.Duration)
+
+	// Set moves the clock to the given time.
+	Set(now time.Time)
+}
+
+// SystemClock returns a clock that reads the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// NewManualClock returns a clock that starts at the given time, and only moves when it is advanced or set.
+func NewManualClock(now time.Time) ManualClock {
+	return &manualClock{
+		now: now,
+	}
+}
+
+type systemClock struct{}
+
+// Now implements Clock.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type manualClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// Now implements Clock.
+func (clock *manualClock) Now() time.Time {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	return clock.now
+}
+
+// Advance implements ManualClock.
+func (clock *manualClock) Advance(duration time.Duration) {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	clock.now = clock.now.Add(duration)
+}
+
+// Set implements ManualClock.
+func (clock *manualClock) Set(now time.Time) {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	clock.now = now
+}
@@ -8,6 +8,10 @@ import (
 )
 
 // Collection is a generic collection of objects.
+//
+// Iterators and Elems sequences over the collections in this package are fail-fast: if the collection is structurally
+// modified while they are in use, other than through the iterator itself, they panic with an
+// ErrorCodeConcurrentModification error. Collections that iterate over a snapshot are never affected.
 type Collection[O objects.Object] interface {
 	objects.Object
 	objects.Iterable[O]
@@ -35,6 +39,14 @@ type Collection[O objects.Object] interface {
 	// ContainsAll returns true if the collection contains all the values in the given collection.
 	ContainsAll(values Collection[O]) bool
 
+	// RemoveIf removes every element of the collection for which condition returns true, and returns the number of
+	// elements removed.
+	RemoveIf(condition func(value O) bool) int
+
+	// RetainAll removes every element of the collection that isn't in the given collection, and returns the number
+	// of elements removed.
+	RetainAll(values Collection[O]) int
+
 	// Copy returns a copy of the collection. This should return the same implementation type as the original collection.
 	Copy() Collection[O]
 
@@ -48,6 +60,15 @@ type Collection[O objects.Object] interface {
 	Clear()
 }
 
+// MutableIterator is an iterator that can remove elements from the collection it is iterating over.
+type MutableIterator[O objects.Object] interface {
+	objects.Iterator[O]
+
+	// Remove removes the element most recently returned by Next from the underlying collection. It panics with an
+	// ErrorCodeIllegalState error if Next hasn't been called, or if the element has already been removed.
+	Remove()
+}
+
 func collectionContainsAll[O objects.Object](collection Collection[O], target Collection[O]) bool {
 	for iterator := target.Iterator(); iterator.HasNext(); {
 		if !collection.Contains(iterator.Next()) {
@@ -57,9 +78,27 @@ func collectionContainsAll[O objects.Object](collection Collection[O], target Co
 	return true
 }
 
+func collectionRetainAll[O objects.Object](collection Collection[O], target Collection[O]) int {
+	if collection == target {
+		return 0
+	}
+	return collection.RemoveIf(func(value O) bool {
+		return !target.Contains(value)
+	})
+}
+
+// setSnapshot copies the given values into a new hash set, for cheap lookups that don't touch the original.
+func setSnapshot[O objects.Object](values Collection[O]) Collection[O] {
+	set := NewHashSetWith[O](WithCapacity(values.Size()))
+	for _, value := range objects.SliceFrom[O](values) {
+		_ = set.Add(value)
+	}
+	return set
+}
+
 func collectionAddAll[O objects.Object](collection Collection[O], target Collection[O]) error {
 	var multi error
-	for iterator := target.Iterator(); iterator.HasNext(); {
+	for iterator := iteratorFor(collection, target); iterator.HasNext(); {
 		if err := collection.Add(iterator.Next()); err != nil {
 			multi = errors.Append(multi, err)
 		}
@@ -69,10 +108,33 @@ func collectionAddAll[O objects.Object](collection Collection[O], target Collect
 
 func collectionRemoveAll[O objects.Object](collection Collection[O], target Collection[O]) error {
 	var multi error
-	for iterator := target.Iterator(); iterator.HasNext(); {
+	for iterator := iteratorFor(collection, target); iterator.HasNext(); {
 		if err := collection.Remove(iterator.Next()); err != nil {
 			multi = errors.Append(multi, err)
 		}
 	}
 	return multi
 }
+
+// iteratorFor returns an iterator over target that stays valid while collection is modified. This only matters when
+// they are the same collection, in which case the iterator works over a snapshot.
+func iteratorFor[O objects.Object](collection Collection[O], target Collection[O]) objects.Iterator[O] {
+	if collection == target {
+		return objects.NewSliceIterator(objects.SliceFrom[O](target))
+	}
+	return target.Iterator()
+}
+
+// checkForComodification panics with an ErrorCodeConcurrentModification error if a collection has been structurally
+// modified since an iterator over it was created.
+func checkForComodification(expected, actual int) {
+	if expected != actual {
+		panic(errors.New(nil, ErrorCodeConcurrentModification, "concurrent modification"))
+	}
+}
+
+// noCurrentElement panics with an ErrorCodeIllegalState error, for iterators asked to change an element when they
+// haven't returned one, or have already removed it.
+func noCurrentElement() {
+	panic(errors.New(nil, ErrorCodeIllegalState, "no current element"))
+}
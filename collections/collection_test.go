@@ -48,6 +48,45 @@ func runCollectionTests[V objects.Object](t *testing.T, init func() Collection[V
 		tests.ExecuteE(collection.Remove(data["two"])).ErrorCode(t, ErrorCodeNotFound)
 	})
 
+	t.Run("collection_remove_if", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Add(data["one"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["two"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["three"])).NoError(t)
+
+		removed := collection.RemoveIf(func(value V) bool {
+			return value.Equals(data["one"]) || value.Equals(data["three"])
+		})
+		tests.Execute(removed).Equal(t, 2)
+		tests.Execute(collection.Size()).Equal(t, 1)
+		tests.Execute(collection.Contains(data["two"])).Equal(t, true)
+
+		tests.Execute(collection.RemoveIf(func(value V) bool { return false })).Equal(t, 0)
+		tests.Execute(collection.RemoveIf(func(value V) bool { return true })).Equal(t, 1)
+		tests.Execute(collection.IsEmpty()).Equal(t, true)
+	})
+
+	t.Run("collection_retain_all", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Add(data["one"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["two"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["three"])).NoError(t)
+
+		retain := init()
+		tests.ExecuteE(retain.Add(data["two"])).NoError(t)
+
+		tests.Execute(collection.RetainAll(collection)).Equal(t, 0)
+		tests.Execute(collection.RetainAll(retain)).Equal(t, 2)
+		tests.Execute(collection.Size()).Equal(t, 1)
+		tests.Execute(collection.Contains(data["two"])).Equal(t, true)
+		tests.Execute(collection.RetainAll(retain)).Equal(t, 0)
+
+		tests.Execute(collection.RetainAll(init())).Equal(t, 1)
+		tests.Execute(collection.IsEmpty()).Equal(t, true)
+	})
+
 	t.Run("cmp", func(t *testing.T) {
 		one := init()
 		tests.ExecuteE(one.Add(data["one"])).NoError(t)
@@ -65,3 +104,104 @@ func runCollectionTests[V objects.Object](t *testing.T, init func() Collection[V
 		t.Logf("diff: %v", diff)
 	})
 }
+
+// runFailFastTests checks that iterating over a collection panics once the collection is structurally modified. It
+// applies to collections that iterate over their live contents, rather than over a snapshot.
+func runFailFastTests[V objects.Object](t *testing.T, init func() Collection[V], data map[string]V) {
+	fill := func() Collection[V] {
+		collection := init()
+		tests.ExecuteE(collection.Add(data["one"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["two"])).NoError(t)
+		tests.ExecuteE(collection.Add(data["three"])).NoError(t)
+		return collection
+	}
+
+	t.Run("fail_fast_iterator", func(t *testing.T) {
+		collection := fill()
+
+		iterator := collection.Iterator()
+		iterator.Next()
+		tests.ExecuteE(collection.Remove(data["two"])).NoError(t)
+		expectConcurrentModification(t, func() {
+			iterator.Next()
+		})
+	})
+
+	t.Run("fail_fast_add", func(t *testing.T) {
+		collection := fill()
+		tests.ExecuteE(collection.Remove(data["three"])).NoError(t)
+
+		iterator := collection.Iterator()
+		iterator.Next()
+		tests.ExecuteE(collection.Add(data["three"])).NoError(t)
+		expectConcurrentModification(t, func() {
+			iterator.Next()
+		})
+	})
+
+	t.Run("fail_fast_clear", func(t *testing.T) {
+		collection := fill()
+
+		iterator := collection.Iterator()
+		collection.Clear()
+		expectConcurrentModification(t, func() {
+			iterator.Next()
+		})
+	})
+
+	t.Run("fail_fast_elems", func(t *testing.T) {
+		collection := fill()
+
+		expectConcurrentModification(t, func() {
+			for value := range collection.Elems() {
+				_ = collection.Remove(value)
+			}
+		})
+	})
+
+	t.Run("fail_fast_reads", func(t *testing.T) {
+		collection := fill()
+
+		count := 0
+		for iterator := collection.Iterator(); iterator.HasNext(); {
+			value := iterator.Next()
+			tests.Execute(collection.Contains(value)).Equal(t, true)
+			count = count + 1
+		}
+		tests.Execute(count).Equal(t, 3)
+	})
+
+	t.Run("fail_fast_self", func(t *testing.T) {
+		collection := fill()
+
+		tests.ExecuteE(collection.RemoveAll(collection)).NoError(t)
+		tests.Execute(collection.IsEmpty()).Equal(t, true)
+	})
+}
+
+// expectConcurrentModification runs fn, and fails the test unless it panics with an ErrorCodeConcurrentModification
+// error.
+func expectConcurrentModification(t *testing.T, fn func()) {
+	t.Helper()
+	expectErrorCode(t, ErrorCodeConcurrentModification, fn)
+}
+
+// expectErrorCode runs fn, and fails the test unless it panics with an error with the given code.
+func expectErrorCode(t *testing.T, code errors.ErrorCode, fn func()) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+
+		recovered := recover()
+		if recovered == nil {
+			t.Fatalf("expected a panic with error code %s", code)
+		}
+		err, ok := recovered.(error)
+		if !ok {
+			t.Fatalf("expected an error, got %v", recovered)
+		}
+		tests.Execute(errors.GetErrorCode(err)).Equal(t, code)
+	}()
+	fn()
+}
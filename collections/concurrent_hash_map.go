@@ -0,0 +1,423 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+	"sync"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// concurrentHashMapShards is the number of independently locked shards. It
+// must be a power of two.
+const concurrentHashMapShards = 32
+
+// concurrentHashMap stripes its entries over a fixed number of shards, each a
+// hash map guarded by its own lock, so goroutines working on keys in different
+// shards never contend with each other.
+type concurrentHashMap[K, V objects.Object] struct {
+	shards [concurrentHashMapShards]*concurrentHashMapShard[K, V]
+}
+
+type concurrentHashMapShard[K, V objects.Object] struct {
+	lock   sync.RWMutex
+	values *hashMap[K, V]
+}
+
+// NewConcurrentHashMap creates a new hash map that is safe for concurrent use, with the given elements.
+//
+// Operations on a single key are atomic. Operations spanning the whole map, such as iteration, Size, Equals and
+// Copy, visit the shards one at a time and so reflect a consistent view of each shard but not necessarily of the
+// map as a whole.
+func NewConcurrentHashMap[K, V objects.Object](entries ...MapEntry[K, V]) ConcurrentMap[K, V] {
+	m := &concurrentHashMap[K, V]{}
+	for ix := range m.shards {
+		m.shards[ix] = &concurrentHashMapShard[K, V]{
+			values: NewHashMap[K, V]().(*hashMap[K, V]),
+		}
+	}
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *concurrentHashMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *concurrentHashMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *concurrentHashMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *concurrentHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.snapshot())
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *concurrentHashMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot taken when it is created, so it is never
+// affected by concurrent modifications.
+func (m *concurrentHashMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return objects.NewSliceIterator(m.snapshot())
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *concurrentHashMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return objects.SequenceFrom[MapEntry[K, V]](m)
+}
+
+// Add implements Collection.
+func (m *concurrentHashMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *concurrentHashMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *concurrentHashMap[K, V]) Remove(value MapEntry[K, V]) error {
+	shard := m.shard(value.GetKey())
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Remove(value)
+}
+
+// RemoveAll implements Collection.
+func (m *concurrentHashMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *concurrentHashMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	shard := m.shard(value.GetKey())
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.values.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (m *concurrentHashMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection. The shards are filtered one at a time, with the condition called while the shard is
+// locked, so the condition must not use the map.
+func (m *concurrentHashMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	removed := 0
+	for _, shard := range m.shards {
+		shard.lock.Lock()
+		removed = removed + shard.values.RemoveIf(condition)
+		shard.lock.Unlock()
+	}
+	return removed
+}
+
+// RetainAll implements Collection. The given values are copied first, so no shard is locked while reading them.
+func (m *concurrentHashMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	if Collection[MapEntry[K, V]](m) == values {
+		return 0
+	}
+	return collectionRetainAll[MapEntry[K, V]](m, setSnapshot(values))
+}
+
+// Copy implements Collection.
+func (m *concurrentHashMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	newMap := &concurrentHashMap[K, V]{}
+	for ix, shard := range m.shards {
+		shard.lock.RLock()
+		newMap.shards[ix] = &concurrentHashMapShard[K, V]{
+			values: shard.values.Copy().(*hashMap[K, V]),
+		}
+		shard.lock.RUnlock()
+	}
+	return newMap
+}
+
+// Size implements Collection.
+func (m *concurrentHashMap[K, V]) Size() int {
+	size := 0
+	for _, shard := range m.shards {
+		shard.lock.RLock()
+		size = size + shard.values.Size()
+		shard.lock.RUnlock()
+	}
+	return size
+}
+
+// IsEmpty implements Collection.
+func (m *concurrentHashMap[K, V]) IsEmpty() bool {
+	for _, shard := range m.shards {
+		shard.lock.RLock()
+		empty := shard.values.IsEmpty()
+		shard.lock.RUnlock()
+
+		if !empty {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear implements Collection.
+func (m *concurrentHashMap[K, V]) Clear() {
+	for _, shard := range m.shards {
+		shard.lock.Lock()
+		shard.values.Clear()
+		shard.lock.Unlock()
+	}
+}
+
+// Map implementation
+
+// Entries implements Map. The sequence works over a snapshot taken when iteration starts.
+func (m *concurrentHashMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range m.snapshot() {
+			if !yield(entry.GetKey(), entry.GetValue()) {
+				return
+			}
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *concurrentHashMap[K, V]) ContainsKey(key K) bool {
+	shard := m.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.values.ContainsKey(key)
+}
+
+// Put implements Map.
+func (m *concurrentHashMap[K, V]) Put(key K, value V) error {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Put(key, value)
+}
+
+// Replace implements Map.
+func (m *concurrentHashMap[K, V]) Replace(key K, value V) (V, error) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Replace(key, value)
+}
+
+// PutOrReplace implements Map.
+func (m *concurrentHashMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.PutOrReplace(key, value)
+}
+
+// Delete implements Map.
+func (m *concurrentHashMap[K, V]) Delete(key K) (V, error) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Delete(key)
+}
+
+// DeleteIfPresent implements Map.
+func (m *concurrentHashMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.DeleteIfPresent(key)
+}
+
+// Get implements Map.
+func (m *concurrentHashMap[K, V]) Get(key K) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements Map.
+func (m *concurrentHashMap[K, V]) GetSafe(key K) (V, error) {
+	shard := m.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.values.GetSafe(key)
+}
+
+// Keys implements Map. The keys are a snapshot, and don't reflect later changes to the map.
+func (m *concurrentHashMap[K, V]) Keys() Collection[K] {
+	set := NewHashSet[K]()
+	for _, entry := range m.snapshot() {
+		if err := set.Add(entry.GetKey()); err != nil {
+			panic(err)
+		}
+	}
+	return set
+}
+
+// Values implements Map. The values are a snapshot, and don't reflect later changes to the map.
+func (m *concurrentHashMap[K, V]) Values() Collection[V] {
+	list := NewArrayList[V]()
+	for _, entry := range m.snapshot() {
+		if err := list.Add(entry.GetValue()); err != nil {
+			panic(err)
+		}
+	}
+	return list
+}
+
+// GetOrDefault implements Map.
+func (m *concurrentHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	shard := m.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.values.GetOrDefault(key, defaultValue)
+}
+
+// PutIfAbsent implements Map.
+func (m *concurrentHashMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.PutIfAbsent(key, value)
+}
+
+// ComputeIfAbsent implements Map.
+func (m *concurrentHashMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	shard := m.shard(key)
+
+	// Try under the read lock first, so hits on existing keys don't serialise.
+	shard.lock.RLock()
+	current, err := shard.values.GetSafe(key)
+	shard.lock.RUnlock()
+	if err == nil {
+		return current
+	}
+
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	// Someone else may have got there between the two locks, in which case
+	// their value is returned and fn isn't called.
+	return shard.values.ComputeIfAbsent(key, fn)
+}
+
+// ComputeIfPresent implements Map.
+func (m *concurrentHashMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.ComputeIfPresent(key, fn)
+}
+
+// Compute implements Map.
+func (m *concurrentHashMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Compute(key, fn)
+}
+
+// Merge implements Map.
+func (m *concurrentHashMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.values.Merge(key, value, remapping)
+}
+
+// ReplaceAllValues implements Map. The shards are updated one at a time, so other goroutines can see some values
+// replaced and others not.
+func (m *concurrentHashMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	for _, shard := range m.shards {
+		shard.lock.Lock()
+		shard.values.ReplaceAllValues(fn)
+		shard.lock.Unlock()
+	}
+}
+
+// ConcurrentMap implementation
+
+// ReplaceIf implements ConcurrentMap.
+func (m *concurrentHashMap[K, V]) ReplaceIf(key K, value V, condition func(current V) bool) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	current, err := shard.values.GetSafe(key)
+	if err != nil || !condition(current) {
+		return current, false
+	}
+	if _, err := shard.values.Replace(key, value); err != nil {
+		panic(err)
+	}
+	return current, true
+}
+
+// DeleteIf implements ConcurrentMap.
+func (m *concurrentHashMap[K, V]) DeleteIf(key K, condition func(current V) bool) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	current, err := shard.values.GetSafe(key)
+	if err != nil || !condition(current) {
+		return current, false
+	}
+	return shard.values.DeleteIfPresent(key)
+}
+
+// concurrent hash map
+
+// shard returns the shard responsible for the given key.
+func (m *concurrentHashMap[K, V]) shard(key K) *concurrentHashMapShard[K, V] {
+	hash := key.HashCode()
+	// Fold the high bits in, as many hash codes only vary in the low bits.
+	hash = hash ^ (hash >> 32) ^ (hash >> 16)
+	return m.shards[hash&(concurrentHashMapShards-1)]
+}
+
+// snapshot copies out every entry in the map, locking one shard at a time.
+func (m *concurrentHashMap[K, V]) snapshot() []MapEntry[K, V] {
+	var entries []MapEntry[K, V]
+	for _, shard := range m.shards {
+		shard.lock.RLock()
+		for iterator := shard.values.Iterator(); iterator.HasNext(); {
+			entries = append(entries, iterator.Next())
+		}
+		shard.lock.RUnlock()
+	}
+	return entries
+}
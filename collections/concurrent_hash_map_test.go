@@ -0,0 +1,113 @@
+package collections
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestConcurrentHashMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewConcurrentHashMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestConcurrentHashMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewConcurrentHashMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestConcurrentHashMap_CompoundOperations(t *testing.T) {
+	m := NewConcurrentHashMap[*objects.String, *objects.Int]()
+
+	tests.Execute2(m.PutIfAbsent(objects.WrapString("a"), objects.WrapInt(1))).Equal(t, false).Equal(t, objects.WrapInt(1))
+	tests.Execute2(m.PutIfAbsent(objects.WrapString("a"), objects.WrapInt(2))).Equal(t, true).Equal(t, objects.WrapInt(1))
+
+	calls := 0
+	compute := func(key *objects.String) *objects.Int {
+		calls++
+		return objects.WrapInt(3)
+	}
+	tests.Execute(m.ComputeIfAbsent(objects.WrapString("b"), compute)).Equal(t, objects.WrapInt(3))
+	tests.Execute(m.ComputeIfAbsent(objects.WrapString("b"), compute)).Equal(t, objects.WrapInt(3))
+	tests.Execute(calls).Equal(t, 1)
+
+	isOne := func(current *objects.Int) bool {
+		return current.Unwrap() == 1
+	}
+	tests.Execute2(m.ReplaceIf(objects.WrapString("b"), objects.WrapInt(4), isOne)).Equal(t, false)
+	tests.Execute2(m.ReplaceIf(objects.WrapString("a"), objects.WrapInt(4), isOne)).Equal(t, true).Equal(t, objects.WrapInt(1))
+	tests.Execute2(m.ReplaceIf(objects.WrapString("c"), objects.WrapInt(4), isOne)).Equal(t, false)
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(4))
+
+	isFour := func(current *objects.Int) bool {
+		return current.Unwrap() == 4
+	}
+	tests.Execute2(m.DeleteIf(objects.WrapString("b"), isFour)).Equal(t, false)
+	tests.Execute2(m.DeleteIf(objects.WrapString("a"), isFour)).Equal(t, true).Equal(t, objects.WrapInt(4))
+	tests.Execute(m.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+	tests.Execute(m.Size()).Equal(t, 1)
+}
+
+func TestConcurrentHashMap_Parallel(t *testing.T) {
+	m := NewConcurrentHashMap[*objects.String, *objects.Int]()
+
+	const goroutines = 16
+	const keys = 200
+
+	var computed atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for ix := 0; ix < keys; ix++ {
+				key := objects.WrapString(fmt.Sprintf("key-%d", ix))
+
+				// Every goroutine races to create the same keys, only one should win each.
+				m.ComputeIfAbsent(key, func(key *objects.String) *objects.Int {
+					computed.Add(1)
+					return objects.WrapInt(ix)
+				})
+
+				// Mix in reads, iteration and writes to private keys.
+				_ = m.ContainsKey(key)
+				_ = m.Size()
+				own := objects.WrapString(fmt.Sprintf("own-%d-%d", g, ix))
+				_ = m.Put(own, objects.WrapInt(g))
+				if ix%10 == 0 {
+					for range m.Entries() {
+					}
+				}
+				_, _ = m.Delete(own)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	tests.Execute(computed.Load()).Equal(t, int64(keys))
+	tests.Execute(m.Size()).Equal(t, keys)
+}
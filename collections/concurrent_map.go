@@ -0,0 +1,27 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// ConcurrentMap is a map that is safe for concurrent use by multiple goroutines, and offers compound operations that
+// happen atomically with respect to other calls on the same key.
+//
+// PutIfAbsent, ComputeIfAbsent, ComputeIfPresent, Compute and Merge are atomic too. The functions they are given are
+// called at most once per call, and must not access the map.
+type ConcurrentMap[K, V objects.Object] interface {
+	Map[K, V]
+
+	// ReplaceIf replaces the value associated with the given key if the key is in the map and condition returns true
+	// for the current value. It returns the previous value, and true if the value was replaced.
+	ReplaceIf(key K, value V, condition func(current V) bool) (V, bool)
+
+	// DeleteIf removes the key-value pair if the key is in the map and condition returns true for the current value.
+	// It returns the removed value, and true if the pair was removed.
+	DeleteIf(key K, condition func(current V) bool) (V, bool)
+}
+
+// ConcurrentSortedMap is a sorted map that is also a ConcurrentMap. The views returned by HeadMap, TailMap and SubMap
+// are safe for concurrent use too.
+type ConcurrentSortedMap[K, V objects.Object] interface {
+	SortedMap[K, V]
+	ConcurrentMap[K, V]
+}
@@ -0,0 +1,57 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// Deque is a double-ended queue, a collection that supports adding and removing elements at both ends.
+type Deque[O objects.Object] interface {
+	Collection[O]
+
+	// OfferFirst adds an element to the front of the deque.
+	OfferFirst(value O) error
+
+	// OfferLast adds an element to the end of the deque.
+	OfferLast(value O) error
+
+	// PeepFirst returns the element at the front of the deque without removing it.
+	PeepFirst() (O, error)
+
+	// PeepLast returns the element at the end of the deque without removing it.
+	PeepLast() (O, error)
+
+	// PopFirst removes and returns the element at the front of the deque.
+	PopFirst() (O, error)
+
+	// PopLast removes and returns the element at the end of the deque.
+	PopLast() (O, error)
+}
+
+func dequeEquals[O objects.Object](left, right any) bool {
+	lDeque, lOk := left.(Deque[O])
+	rDeque, rOk := right.(Deque[O])
+
+	if !lOk || !rOk {
+		return false
+	}
+
+	if lDeque.Size() != rDeque.Size() {
+		return false
+	}
+
+	lIterator := lDeque.Iterator()
+	rIterator := rDeque.Iterator()
+
+	for lIterator.HasNext() && rIterator.HasNext() {
+		if !lIterator.Next().Equals(rIterator.Next()) {
+			return false
+		}
+	}
+	return lIterator.HasNext() == rIterator.HasNext()
+}
+
+func dequeHashCode[O objects.Object](deque Deque[O]) uint64 {
+	hashcode := uint64(13999)
+	for iterator := deque.Iterator(); iterator.HasNext(); {
+		hashcode = hashcode * iterator.Next().HashCode()
+	}
+	return hashcode
+}
@@ -3,7 +3,13 @@ package collections
 import "github.com/pasataleo/go-errors/errors"
 
 const (
-	ErrorCodeNotFound      errors.ErrorCode = "CollectionsErrorCodeNotFound"
-	ErrorCodeOutOfBounds   errors.ErrorCode = "CollectionsErrorCodeOutOfBounds"
-	ErrorCodeAlreadyExists errors.ErrorCode = "CollectionsErrorCodeAlreadyExists"
+	ErrorCodeNotFound               errors.ErrorCode = "CollectionsErrorCodeNotFound"
+	ErrorCodeOutOfBounds            errors.ErrorCode = "CollectionsErrorCodeOutOfBounds"
+	ErrorCodeAlreadyExists          errors.ErrorCode = "CollectionsErrorCodeAlreadyExists"
+	ErrorCodeClosed                 errors.ErrorCode = "CollectionsErrorCodeClosed"
+	ErrorCodeTimeout                errors.ErrorCode = "CollectionsErrorCodeTimeout"
+	ErrorCodeCancelled              errors.ErrorCode = "CollectionsErrorCodeCancelled"
+	ErrorCodeUnsupported            errors.ErrorCode = "CollectionsErrorCodeUnsupported"
+	ErrorCodeConcurrentModification errors.ErrorCode = "CollectionsErrorCodeConcurrentModification"
+	ErrorCodeIllegalState           errors.ErrorCode = "CollectionsErrorCodeIllegalState"
 )
@@ -0,0 +1,240 @@
+package collections
+
+import (
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// EvictionPolicy decides which key a bounded map evicts next. The map tells the policy about every key it inserts,
+// accesses and removes, and asks it for a victim whenever it is over its budget.
+//
+// A policy holds state about the keys of a single map, so each map needs its own policy.
+type EvictionPolicy[K objects.Object] interface {
+	// Insert records that the key was added to the map.
+	Insert(key K)
+
+	// Access records that the key was read or replaced.
+	Access(key K)
+
+	// Remove records that the key was removed from the map, whether it was evicted or not.
+	Remove(key K)
+
+	// Victim returns the key that should be evicted next, and false if the policy holds no keys.
+	Victim() (K, bool)
+
+	// Clear forgets every key.
+	Clear()
+
+	// Copy returns an independent policy holding the same keys in the same state.
+	Copy() EvictionPolicy[K]
+}
+
+// NewLRUPolicy creates a policy that evicts the least recently used key.
+func NewLRUPolicy[K objects.Object]() EvictionPolicy[K] {
+	return &orderPolicy[K]{
+		keys: newLinkedHashMap[K, K](true),
+	}
+}
+
+// NewFIFOPolicy creates a policy that evicts the least recently inserted key. Accessing a key doesn't change when it
+// is evicted.
+func NewFIFOPolicy[K objects.Object]() EvictionPolicy[K] {
+	return &orderPolicy[K]{
+		keys: newLinkedHashMap[K, K](false),
+	}
+}
+
+// NewLFUPolicy creates a policy that evicts the least frequently used key. Inserting a key counts as its first use.
+// Keys used equally often are evicted in the order they reached that count. Every operation takes constant time.
+func NewLFUPolicy[K objects.Object]() EvictionPolicy[K] {
+	return &lfuPolicy[K]{
+		frequencies: NewHashMap[K, *objects.Int]().(*hashMap[K, *objects.Int]),
+		buckets:     make(map[int]*lfuBucket[K]),
+	}
+}
+
+// orderPolicy evicts keys in the iteration order of a linkedHashMap, which is
+// the LRU order when the map is access-ordered and the FIFO order otherwise.
+// Each key is stored as its own value.
+type orderPolicy[K objects.Object] struct {
+	keys *linkedHashMap[K, K]
+}
+
+// EvictionPolicy implementation
+
+// Insert implements EvictionPolicy.
+func (policy *orderPolicy[K]) Insert(key K) {
+	if _, node := policy.keys.find(key); node != nil {
+		policy.keys.access(node)
+		return
+	}
+	_ = policy.keys.Put(key, key)
+}
+
+// Access implements EvictionPolicy.
+func (policy *orderPolicy[K]) Access(key K) {
+	if _, node := policy.keys.find(key); node != nil {
+		policy.keys.access(node)
+	}
+}
+
+// Remove implements EvictionPolicy.
+func (policy *orderPolicy[K]) Remove(key K) {
+	_, _ = policy.keys.DeleteIfPresent(key)
+}
+
+// Victim implements EvictionPolicy.
+func (policy *orderPolicy[K]) Victim() (K, bool) {
+	if node := policy.keys.order.first; node != nil {
+		return node.value.Key, true
+	}
+	var obj K
+	return obj, false
+}
+
+// Clear implements EvictionPolicy.
+func (policy *orderPolicy[K]) Clear() {
+	policy.keys.Clear()
+}
+
+// Copy implements EvictionPolicy.
+func (policy *orderPolicy[K]) Copy() EvictionPolicy[K] {
+	return &orderPolicy[K]{
+		keys: policy.keys.Copy().(*linkedHashMap[K, K]),
+	}
+}
+
+// lfuPolicy keeps a bucket for each frequency that at least one key has,
+// linked in increasing order of frequency. Using a key moves it into the
+// bucket for the next frequency, creating it if needed, so the victim is
+// always the oldest key in the first bucket.
+type lfuPolicy[K objects.Object] struct {
+	frequencies *hashMap[K, *objects.Int]
+	buckets     map[int]*lfuBucket[K]
+
+	first *lfuBucket[K]
+}
+
+type lfuBucket[K objects.Object] struct {
+	frequency int
+
+	// keys holds the keys used this often, in the order they got here.
+	keys *linkedHashMap[K, K]
+
+	before *lfuBucket[K]
+	after  *lfuBucket[K]
+}
+
+// EvictionPolicy implementation
+
+// Insert implements EvictionPolicy. Inserting a key the policy already holds counts as using it.
+func (policy *lfuPolicy[K]) Insert(key K) {
+	if policy.frequencies.ContainsKey(key) {
+		policy.Access(key)
+		return
+	}
+
+	bucket := policy.first
+	if bucket == nil || bucket.frequency != 1 {
+		bucket = policy.link(1, nil)
+	}
+	_ = bucket.keys.Put(key, key)
+	_ = policy.frequencies.Put(key, objects.WrapInt(1))
+}
+
+// Access implements EvictionPolicy.
+func (policy *lfuPolicy[K]) Access(key K) {
+	frequency := policy.frequencies.GetOrDefault(key, nil)
+	if frequency == nil {
+		return
+	}
+
+	bucket := policy.buckets[frequency.Unwrap()]
+	next := bucket.after
+	if next == nil || next.frequency != bucket.frequency+1 {
+		next = policy.link(bucket.frequency+1, bucket)
+	}
+
+	_ = next.keys.Put(key, key)
+	_, _ = policy.frequencies.Replace(key, objects.WrapInt(next.frequency))
+	policy.removeFrom(bucket, key)
+}
+
+// Remove implements EvictionPolicy.
+func (policy *lfuPolicy[K]) Remove(key K) {
+	if frequency, ok := policy.frequencies.DeleteIfPresent(key); ok {
+		policy.removeFrom(policy.buckets[frequency.Unwrap()], key)
+	}
+}
+
+// Victim implements EvictionPolicy.
+func (policy *lfuPolicy[K]) Victim() (K, bool) {
+	if policy.first != nil {
+		return policy.first.keys.order.first.value.Key, true
+	}
+	var obj K
+	return obj, false
+}
+
+// Clear implements EvictionPolicy.
+func (policy *lfuPolicy[K]) Clear() {
+	policy.frequencies.Clear()
+	policy.buckets = make(map[int]*lfuBucket[K])
+	policy.first = nil
+}
+
+// Copy implements EvictionPolicy.
+func (policy *lfuPolicy[K]) Copy() EvictionPolicy[K] {
+	newPolicy := &lfuPolicy[K]{
+		frequencies: policy.frequencies.Copy().(*hashMap[K, *objects.Int]),
+		buckets:     make(map[int]*lfuBucket[K], len(policy.buckets)),
+	}
+
+	var last *lfuBucket[K]
+	for bucket := policy.first; bucket != nil; bucket = bucket.after {
+		last = newPolicy.link(bucket.frequency, last)
+		last.keys = bucket.keys.Copy().(*linkedHashMap[K, K])
+	}
+	return newPolicy
+}
+
+// lfu policy
+
+// link creates an empty bucket for the given frequency, and links it after the given bucket, or first if it is nil.
+func (policy *lfuPolicy[K]) link(frequency int, before *lfuBucket[K]) *lfuBucket[K] {
+	bucket := &lfuBucket[K]{
+		frequency: frequency,
+		keys:      newLinkedHashMap[K, K](false),
+		before:    before,
+	}
+	if before == nil {
+		bucket.after = policy.first
+		policy.first = bucket
+	} else {
+		bucket.after = before.after
+		before.after = bucket
+	}
+	if bucket.after != nil {
+		bucket.after.before = bucket
+	}
+
+	policy.buckets[frequency] = bucket
+	return bucket
+}
+
+// removeFrom removes the key from the bucket, and unlinks the bucket if that leaves it empty.
+func (policy *lfuPolicy[K]) removeFrom(bucket *lfuBucket[K], key K) {
+	_, _ = bucket.keys.DeleteIfPresent(key)
+	if !bucket.keys.IsEmpty() {
+		return
+	}
+
+	if bucket.before == nil {
+		policy.first = bucket.after
+	} else {
+		bucket.before.after = bucket.after
+	}
+	if bucket.after != nil {
+		bucket.after.before = bucket.before
+	}
+	delete(policy.buckets, bucket.frequency)
+}
@@ -0,0 +1,80 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestEvictionPolicy_LRU(t *testing.T) {
+	policy := NewLRUPolicy[*objects.String]()
+	for _, key := range []string{"a", "b", "c"} {
+		policy.Insert(objects.WrapString(key))
+	}
+
+	policy.Access(objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("b"))
+
+	policy.Remove(objects.WrapString("b"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("c"))
+
+	copied := policy.Copy()
+	copied.Access(objects.WrapString("c"))
+	tests.Execute2(copied.Victim()).Equal(t, true).Equal(t, objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("c"))
+
+	policy.Clear()
+	tests.Execute2(policy.Victim()).Equal(t, false)
+}
+
+func TestEvictionPolicy_FIFO(t *testing.T) {
+	policy := NewFIFOPolicy[*objects.String]()
+	for _, key := range []string{"a", "b", "c"} {
+		policy.Insert(objects.WrapString(key))
+	}
+
+	// Accesses don't change the order.
+	policy.Access(objects.WrapString("a"))
+	policy.Insert(objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("a"))
+
+	policy.Remove(objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("b"))
+}
+
+func TestEvictionPolicy_LFU(t *testing.T) {
+	policy := NewLFUPolicy[*objects.String]()
+	for _, key := range []string{"a", "b", "c"} {
+		policy.Insert(objects.WrapString(key))
+	}
+
+	policy.Access(objects.WrapString("a"))
+	policy.Access(objects.WrapString("a"))
+	policy.Access(objects.WrapString("b"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("c"))
+
+	// c catches up with b, but b got there first so it goes first.
+	policy.Access(objects.WrapString("c"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("b"))
+
+	policy.Remove(objects.WrapString("b"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("c"))
+
+	// A new key has been used least of all.
+	policy.Insert(objects.WrapString("d"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("d"))
+
+	copied := policy.Copy()
+	copied.Remove(objects.WrapString("d"))
+	copied.Remove(objects.WrapString("c"))
+	tests.Execute2(copied.Victim()).Equal(t, true).Equal(t, objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, true).Equal(t, objects.WrapString("d"))
+
+	// Removing every key from a bucket unlinks it.
+	policy.Remove(objects.WrapString("d"))
+	policy.Remove(objects.WrapString("c"))
+	policy.Remove(objects.WrapString("a"))
+	tests.Execute2(policy.Victim()).Equal(t, false)
+	tests.Execute(len(policy.(*lfuPolicy[*objects.String]).buckets)).Equal(t, 0)
+}
@@ -0,0 +1,675 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// ExpiringMap is a map whose entries expire a set time after they are written. Expired entries are invisible to every
+// method, exactly as if they had been deleted.
+//
+// Each write, whether through Put, Replace, Compute or any other method, restarts the time to live of the entry it
+// writes. Methods without a TTL parameter use the default TTL given when the map was created. Iteration, Entries,
+// Keys and Values all work over a snapshot of the live entries.
+//
+// An ExpiringMap is safe for concurrent use. The functions passed to methods such as Compute and RemoveIf are called
+// while the map is locked, so they must not use the map.
+type ExpiringMap[K, V objects.Object] interface {
+	Map[K, V]
+
+	// PutWithTTL inserts a key-value pair that expires after the given duration. A duration of zero or less means the
+	// entry never expires.
+	PutWithTTL(key K, value V, ttl time.Duration) error
+
+	// PutOrReplaceWithTTL inserts or replaces a key-value pair that expires after the given duration. It returns the
+	// same values as PutOrReplace.
+	PutOrReplaceWithTTL(key K, value V, ttl time.Duration) (V, bool)
+
+	// Sweep removes every expired entry now, and returns the number removed.
+	Sweep() int
+
+	// Close stops the background sweeper, if there is one. The map can still be used afterward, and expired entries
+	// are still removed as they are found.
+	Close() error
+}
+
+// expiringMap stores each entry alongside its deadline, and keeps the entries
+// that can expire in a heap ordered by deadline. Sweeping pops entries off the
+// heap until it reaches one that is still live, so it only touches expired
+// entries. Entries that are overwritten or deleted stay in the heap, and are
+// discarded when they are popped because the map no longer holds them. The
+// heap is rebuilt from the map once it holds more than twice as many entries
+// as the map, so the stale entries never outnumber the live ones.
+type expiringMap[K, V objects.Object] struct {
+	lock sync.Mutex
+
+	entries   *hashMap[K, *expiringEntry[K, V]]
+	deadlines *heap[*expiringEntry[K, V]]
+
+	clock Clock
+	ttl   time.Duration
+
+	// done stops the background sweeper when closed. It is nil if there is no
+	// sweeper, or once it has been stopped.
+	done chan struct{}
+}
+
+// NewExpiringMap creates a new map whose entries expire after the given default TTL, configured by the given options.
+// A TTL of zero or less means entries only expire if they are given a TTL of their own. WithClock sets the clock the
+// deadlines are measured against, and WithSweepInterval starts a background sweeper that Close stops.
+func NewExpiringMap[K, V objects.Object](ttl time.Duration, opts ...Option) ExpiringMap[K, V] {
+	options := newOptions(opts)
+	m := newExpiringMap[K, V](ttl, options.clock, opts...)
+	if options.sweepInterval > 0 {
+		m.done = make(chan struct{})
+		go m.sweepEvery(options.sweepInterval, m.done)
+	}
+	return m
+}
+
+func newExpiringMap[K, V objects.Object](ttl time.Duration, clock Clock, opts ...Option) *expiringMap[K, V] {
+	return &expiringMap[K, V]{
+		entries: NewHashMapWith[K, *expiringEntry[K, V]](opts...).(*hashMap[K, *expiringEntry[K, V]]),
+		deadlines: &heap[*expiringEntry[K, V]]{
+			comparator: expiringEntryComparator[K, V]{},
+		},
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *expiringMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m.snapshot(), other)
+}
+
+// HashCode implements objects.Object.
+func (m *expiringMap[K, V]) HashCode() uint64 {
+	return m.snapshot().HashCode()
+}
+
+// String implements objects.Object.
+func (m *expiringMap[K, V]) String() string {
+	return m.snapshot().String()
+}
+
+// MarshalJSON implements objects.Object. The deadlines aren't written.
+func (m *expiringMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.snapshot().MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object. Every entry read is given the default TTL.
+func (m *expiringMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.clear()
+	now := m.clock.Now()
+	for _, entry := range entries {
+		if m.live(entry.Key, now) != nil {
+			return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", entry.Key)
+		}
+		m.write(entry.Key, entry.Value, m.ttl, now)
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot of the live entries.
+func (m *expiringMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &unmodifiableIterator[MapEntry[K, V]]{
+		iterator: m.snapshot().Iterator(),
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection. The sequence works over a snapshot taken when iteration starts.
+func (m *expiringMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return func(yield func(MapEntry[K, V]) bool) {
+		for iterator := m.Iterator(); iterator.HasNext(); {
+			if !yield(iterator.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Add implements Collection.
+func (m *expiringMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *expiringMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *expiringMap[K, V]) Remove(value MapEntry[K, V]) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry := m.live(value.GetKey(), m.clock.Now())
+	if entry == nil || !entry.value.Equals(value.GetValue()) {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+
+	_, _ = m.entries.DeleteIfPresent(value.GetKey())
+	m.compact()
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (m *expiringMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *expiringMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry := m.live(value.GetKey(), m.clock.Now())
+	return entry != nil && entry.value.Equals(value.GetValue())
+}
+
+// ContainsAll implements Collection.
+func (m *expiringMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection.
+func (m *expiringMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sweep(m.clock.Now())
+	removed := m.entries.RemoveIf(func(entry MapEntry[K, *expiringEntry[K, V]]) bool {
+		return condition(&mapEntry[K, V]{
+			Key:   entry.GetKey(),
+			Value: entry.GetValue().value,
+		})
+	})
+	m.compact()
+	return removed
+}
+
+// RetainAll implements Collection. The given values are copied first, so the map isn't locked while reading them.
+func (m *expiringMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	if Collection[MapEntry[K, V]](m) == values {
+		return 0
+	}
+	return collectionRetainAll[MapEntry[K, V]](m, setSnapshot(values))
+}
+
+// Copy implements Collection. The copy holds the live entries with their current deadlines, and uses the same clock
+// and default TTL, but has no background sweeper.
+func (m *expiringMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sweep(m.clock.Now())
+	newMap := newExpiringMap[K, V](m.ttl, m.clock, WithCapacity(m.entries.Size()))
+	for key, entry := range m.entries.Entries() {
+		copied := &expiringEntry[K, V]{
+			key:       key,
+			value:     entry.value,
+			expiresAt: entry.expiresAt,
+		}
+		_ = newMap.entries.Put(key, copied)
+		newMap.schedule(copied)
+	}
+	return newMap
+}
+
+// Size implements Collection. Only live entries are counted.
+func (m *expiringMap[K, V]) Size() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sweep(m.clock.Now())
+	return m.entries.Size()
+}
+
+// IsEmpty implements Collection.
+func (m *expiringMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear implements Collection.
+func (m *expiringMap[K, V]) Clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.clear()
+}
+
+// Map implementation
+
+// Entries implements Map. The sequence works over a snapshot taken when iteration starts.
+func (m *expiringMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range m.snapshot().Entries() {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *expiringMap[K, V]) ContainsKey(key K) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.live(key, m.clock.Now()) != nil
+}
+
+// Put implements Map.
+func (m *expiringMap[K, V]) Put(key K, value V) error {
+	return m.PutWithTTL(key, value, m.ttl)
+}
+
+// Replace implements Map.
+func (m *expiringMap[K, V]) Replace(key K, value V) (V, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	entry := m.live(key, now)
+	if entry == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+
+	m.write(key, value, m.ttl, now)
+	return entry.value, nil
+}
+
+// PutOrReplace implements Map.
+func (m *expiringMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	return m.PutOrReplaceWithTTL(key, value, m.ttl)
+}
+
+// Delete implements Map.
+func (m *expiringMap[K, V]) Delete(key K) (V, error) {
+	value, ok := m.DeleteIfPresent(key)
+	if !ok {
+		return value, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *expiringMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry := m.live(key, m.clock.Now())
+	if entry == nil {
+		var obj V
+		return obj, false
+	}
+
+	_, _ = m.entries.DeleteIfPresent(key)
+	m.compact()
+	return entry.value, true
+}
+
+// Get implements Map.
+func (m *expiringMap[K, V]) Get(key K) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements Map.
+func (m *expiringMap[K, V]) GetSafe(key K) (V, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry := m.live(key, m.clock.Now())
+	if entry == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return entry.value, nil
+}
+
+// GetOrDefault implements Map.
+func (m *expiringMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// PutIfAbsent implements Map.
+func (m *expiringMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	if entry := m.live(key, now); entry != nil {
+		return entry.value, true
+	}
+
+	m.write(key, value, m.ttl, now)
+	return value, false
+}
+
+// ComputeIfAbsent implements Map.
+func (m *expiringMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	if entry := m.live(key, now); entry != nil {
+		return entry.value
+	}
+
+	value := fn(key)
+	m.write(key, value, m.ttl, now)
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (m *expiringMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	entry := m.live(key, now)
+	if entry == nil {
+		var obj V
+		return obj, false
+	}
+
+	value, keep := fn(key, entry.value)
+	return m.apply(key, value, keep, now)
+}
+
+// Compute implements Map.
+func (m *expiringMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	var current V
+	entry := m.live(key, now)
+	if entry != nil {
+		current = entry.value
+	}
+
+	value, keep := fn(key, current, entry != nil)
+	return m.apply(key, value, keep, now)
+}
+
+// Merge implements Map.
+func (m *expiringMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	entry := m.live(key, now)
+	if entry == nil {
+		m.write(key, value, m.ttl, now)
+		return value, true
+	}
+
+	merged, keep := remapping(entry.value, value)
+	return m.apply(key, merged, keep, now)
+}
+
+// ReplaceAllValues implements Map.
+func (m *expiringMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	m.sweep(now)
+	m.entries.ReplaceAllValues(func(key K, entry *expiringEntry[K, V]) *expiringEntry[K, V] {
+		return m.track(key, fn(key, entry.value), m.ttl, now)
+	})
+	m.compact()
+}
+
+// Keys implements Map. The keys are a snapshot of the live keys.
+func (m *expiringMap[K, V]) Keys() Collection[K] {
+	return m.snapshot().Keys()
+}
+
+// Values implements Map. The values are a snapshot of the live values.
+func (m *expiringMap[K, V]) Values() Collection[V] {
+	return m.snapshot().Values()
+}
+
+// ExpiringMap implementation
+
+// PutWithTTL implements ExpiringMap.
+func (m *expiringMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	if m.live(key, now) != nil {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+
+	m.write(key, value, ttl, now)
+	return nil
+}
+
+// PutOrReplaceWithTTL implements ExpiringMap.
+func (m *expiringMap[K, V]) PutOrReplaceWithTTL(key K, value V, ttl time.Duration) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Now()
+	entry := m.live(key, now)
+	m.write(key, value, ttl, now)
+	if entry == nil {
+		return value, false
+	}
+	return entry.value, true
+}
+
+// Sweep implements ExpiringMap.
+func (m *expiringMap[K, V]) Sweep() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.sweep(m.clock.Now())
+}
+
+// Close implements ExpiringMap.
+func (m *expiringMap[K, V]) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.done != nil {
+		close(m.done)
+		m.done = nil
+	}
+	return nil
+}
+
+// expiring map
+
+// live returns the entry for the given key, or nil if there isn't one or it has expired. Expired entries are removed
+// as they are found.
+func (m *expiringMap[K, V]) live(key K, now time.Time) *expiringEntry[K, V] {
+	entry := m.entries.GetOrDefault(key, nil)
+	if entry == nil {
+		return nil
+	}
+	if entry.expired(now) {
+		_, _ = m.entries.DeleteIfPresent(key)
+		return nil
+	}
+	return entry
+}
+
+// write associates the key with a new entry holding the value, which expires after the given TTL.
+func (m *expiringMap[K, V]) write(key K, value V, ttl time.Duration, now time.Time) {
+	_, _ = m.entries.PutOrReplace(key, m.track(key, value, ttl, now))
+	m.compact()
+}
+
+// track returns a new entry holding the value, which expires after the given TTL. The caller stores it in entries.
+func (m *expiringMap[K, V]) track(key K, value V, ttl time.Duration, now time.Time) *expiringEntry[K, V] {
+	entry := &expiringEntry[K, V]{
+		key:   key,
+		value: value,
+	}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	m.schedule(entry)
+	return entry
+}
+
+// schedule adds the entry to the deadlines heap if it can expire.
+func (m *expiringMap[K, V]) schedule(entry *expiringEntry[K, V]) {
+	if !entry.expiresAt.IsZero() {
+		_ = m.deadlines.Offer(entry)
+	}
+}
+
+// compact rebuilds the deadlines heap from the entries in the map once it holds more than twice as many. Each entry
+// in the map is in the heap at most once, so at that point more than half of the heap is stale. Rebuilding only when
+// the heap has doubled keeps the cost to a constant per write.
+func (m *expiringMap[K, V]) compact() {
+	if m.deadlines.Size() <= 2*m.entries.Size() {
+		return
+	}
+
+	m.deadlines.Clear()
+	for _, entry := range m.entries.Entries() {
+		if !entry.expiresAt.IsZero() {
+			_ = m.deadlines.Offer(entry)
+		}
+	}
+}
+
+// apply stores the value computed for the key, or removes the key if keep is false.
+func (m *expiringMap[K, V]) apply(key K, value V, keep bool, now time.Time) (V, bool) {
+	if !keep {
+		_, _ = m.entries.DeleteIfPresent(key)
+		m.compact()
+		var obj V
+		return obj, false
+	}
+
+	m.write(key, value, m.ttl, now)
+	return value, true
+}
+
+// sweep removes every entry that has expired by the given time, and returns the number removed.
+func (m *expiringMap[K, V]) sweep(now time.Time) int {
+	removed := 0
+	for {
+		next, err := m.deadlines.Peep()
+		if err != nil || !next.expired(now) {
+			return removed
+		}
+
+		_, _ = m.deadlines.Pop()
+		if m.entries.GetOrDefault(next.key, nil) == next {
+			_, _ = m.entries.DeleteIfPresent(next.key)
+			removed = removed + 1
+		}
+	}
+}
+
+// sweepEvery sweeps the map at the given interval until done is closed.
+func (m *expiringMap[K, V]) sweepEvery(interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.Sweep()
+		case <-done:
+			return
+		}
+	}
+}
+
+func (m *expiringMap[K, V]) clear() {
+	m.entries.Clear()
+	m.deadlines.Clear()
+}
+
+// snapshot returns a new map holding the live entries.
+func (m *expiringMap[K, V]) snapshot() Map[K, V] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sweep(m.clock.Now())
+	snapshot := NewHashMapWith[K, V](WithCapacity(m.entries.Size()))
+	for key, entry := range m.entries.Entries() {
+		_ = snapshot.Put(key, entry.value)
+	}
+	return snapshot
+}
+
+// expiringEntry is a value in an expiring map, along with its key and the
+// time it expires. A zero expiresAt means the entry never expires.
+//
+// It implements objects.Object so it can be held by the map and the heap,
+// and behaves as the value it holds.
+type expiringEntry[K, V objects.Object] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func (entry *expiringEntry[K, V]) expired(now time.Time) bool {
+	return !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt)
+}
+
+func (entry *expiringEntry[K, V]) Equals(other any) bool {
+	if other, ok := other.(*expiringEntry[K, V]); ok {
+		return entry.value.Equals(other.value)
+	}
+	return false
+}
+
+func (entry *expiringEntry[K, V]) HashCode() uint64 {
+	return entry.value.HashCode()
+}
+
+func (entry *expiringEntry[K, V]) String() string {
+	return entry.value.String()
+}
+
+func (entry *expiringEntry[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(entry.value)
+}
+
+func (entry *expiringEntry[K, V]) UnmarshalJSON(bytes []byte) error {
+	return json.Unmarshal(bytes, &entry.value)
+}
+
+// expiringEntryComparator orders entries by when they expire, soonest first.
+type expiringEntryComparator[K, V objects.Object] struct{}
+
+func (expiringEntryComparator[K, V]) Compare(left, right *expiringEntry[K, V]) int {
+	return left.expiresAt.Compare(right.expiresAt)
+}
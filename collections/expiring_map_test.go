@@ -0,0 +1,173 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestExpiringMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewExpiringMap[*objects.String, *objects.String](time.Hour)
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestExpiringMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewExpiringMap[*objects.String, *objects.String](time.Hour)
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestExpiringMap_Expiry(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	m := NewExpiringMap[*objects.String, *objects.Int](time.Minute, WithClock(clock))
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.PutWithTTL(objects.WrapString("b"), objects.WrapInt(2), 2*time.Minute)).NoError(t)
+	tests.ExecuteE(m.PutWithTTL(objects.WrapString("c"), objects.WrapInt(3), 0)).NoError(t)
+
+	clock.Advance(time.Minute - time.Second)
+	tests.Execute(m.Size()).Equal(t, 3)
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(1))
+
+	// Entries expire exactly at their deadline.
+	clock.Advance(time.Second)
+	tests.Execute(m.ContainsKey(objects.WrapString("a"))).Equal(t, false)
+	tests.Execute2E(m.GetSafe(objects.WrapString("a"))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute(m.GetOrDefault(objects.WrapString("a"), objects.WrapInt(0))).Equal(t, objects.WrapInt(0))
+	tests.Execute(m.Size()).Equal(t, 2)
+
+	clock.Advance(time.Hour)
+	tests.Execute(m.Size()).Equal(t, 1)
+	tests.Execute(m.String()).Equal(t, "{c:3}")
+
+	var keys []string
+	for entry := range m.Elems() {
+		keys = append(keys, entry.GetKey().String())
+	}
+	tests.Execute(keys).Equal(t, []string{"c"})
+
+	// An expired key can be put again.
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(4))).NoError(t)
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(4))
+}
+
+func TestExpiringMap_WritesRestartTTL(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	m := NewExpiringMap[*objects.String, *objects.Int](time.Minute, WithClock(clock))
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+
+	clock.Advance(30 * time.Second)
+	tests.Execute2E(m.Replace(objects.WrapString("a"), objects.WrapInt(3))).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.Execute2(m.PutOrReplaceWithTTL(objects.WrapString("c"), objects.WrapInt(4), time.Hour)).Equal(t, false)
+
+	// b reaches its deadline, while a was replaced and lives on. The heap
+	// still holds the old entry for a, which the sweep discards.
+	clock.Advance(30 * time.Second)
+	tests.Execute(m.Sweep()).Equal(t, 1)
+	tests.Execute(m.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(3))
+	tests.Execute(m.ContainsKey(objects.WrapString("b"))).Equal(t, false)
+
+	clock.Advance(30 * time.Second)
+	tests.Execute(m.Sweep()).Equal(t, 1)
+	tests.Execute(m.Sweep()).Equal(t, 0)
+	tests.Execute(m.Size()).Equal(t, 1)
+	tests.Execute(m.Get(objects.WrapString("c"))).Equal(t, objects.WrapInt(4))
+}
+
+func TestExpiringMap_Copy(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	m := NewExpiringMap[*objects.String, *objects.Int](time.Minute, WithClock(clock))
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	clock.Advance(30 * time.Second)
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+
+	copied := m.Copy().(ExpiringMap[*objects.String, *objects.Int])
+	tests.Execute(copied.Equals(m)).Equal(t, true)
+
+	// The copy keeps the original deadlines.
+	clock.Advance(30 * time.Second)
+	tests.Execute(copied.Size()).Equal(t, 1)
+	tests.Execute(copied.ContainsKey(objects.WrapString("b"))).Equal(t, true)
+	tests.Execute(copied.Equals(m)).Equal(t, true)
+}
+
+func TestExpiringMap_Close(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	m := NewExpiringMap[*objects.String, *objects.Int](time.Minute, WithClock(clock), WithSweepInterval(time.Millisecond))
+
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	clock.Advance(time.Minute)
+
+	// The background sweeper removes the entry without the map being read.
+	deadline := time.Now().Add(5 * time.Second)
+	for m.(*expiringMap[*objects.String, *objects.Int]).count() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	tests.Execute(m.(*expiringMap[*objects.String, *objects.Int]).count()).Equal(t, 0)
+
+	tests.ExecuteE(m.Close()).NoError(t)
+	tests.ExecuteE(m.Close()).NoError(t)
+
+	// The map still expires entries lazily once closed.
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+	clock.Advance(time.Minute)
+	tests.Execute(m.ContainsKey(objects.WrapString("b"))).Equal(t, false)
+}
+
+// count returns the number of entries held, including expired entries that
+// haven't been removed yet.
+func (m *expiringMap[K, V]) count() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.entries.Size()
+}
+
+func TestExpiringMap_Overwrites(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	m := NewExpiringMap[*objects.String, *objects.Int](time.Hour, WithClock(clock)).(*expiringMap[*objects.String, *objects.Int])
+
+	// Overwriting a hot key leaves stale deadlines behind, but the heap is
+	// rebuilt before they can outnumber the live entries.
+	for ix := range 100_000 {
+		_, _ = m.PutOrReplace(objects.WrapString("hot"), objects.WrapInt(ix))
+		_, _ = m.PutOrReplaceWithTTL(objects.WrapString(fmt.Sprintf("key%d", ix%10)), objects.WrapInt(ix), time.Duration(ix%7+1)*time.Minute)
+	}
+	tests.Execute(m.Size()).Equal(t, 11)
+	tests.Execute(m.deadlines.Size() <= 2*m.Size()).Equal(t, true)
+
+	for ix := range 10 {
+		_, _ = m.Delete(objects.WrapString(fmt.Sprintf("key%d", ix)))
+	}
+	tests.Execute(m.deadlines.Size() <= 2).Equal(t, true)
+
+	// The deadlines kept are still the current ones.
+	tests.Execute(m.Get(objects.WrapString("hot"))).Equal(t, objects.WrapInt(99_999))
+	clock.Advance(time.Hour)
+	tests.Execute(m.Sweep()).Equal(t, 1)
+	tests.Execute(m.deadlines.Size()).Equal(t, 0)
+}
@@ -0,0 +1,263 @@
+package collections
+
+import (
+	"iter"
+	"math/bits"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+const (
+	hamtBits  = 5
+	hamtWidth = 1 << hamtBits
+	hamtMask  = hamtWidth - 1
+)
+
+// hamt is a persistent hash array mapped trie, the shared core of the
+// immutable map and set. Each node consumes hamtBits of the key hash to pick a
+// slot, and only allocates the slots in use, tracked by a bitmap.
+//
+// A slot holds either a child node, or a bucket of entries whose keys all have
+// the same hash. Buckets only hold more than one entry once the hashes of
+// their keys collide completely.
+//
+// Nodes are never changed once they are reachable from a hamt, so updates copy
+// the path from the root to the changed slot and share everything else.
+type hamt[K objects.Object, V any] struct {
+	root *hamtNode[K, V]
+	size int
+}
+
+type hamtNode[K objects.Object, V any] struct {
+	bitmap uint32
+	slots  []hamtSlot[K, V]
+}
+
+type hamtSlot[K objects.Object, V any] struct {
+	child *hamtNode[K, V]
+
+	hash    uint64
+	entries []hamtEntry[K, V]
+}
+
+type hamtEntry[K objects.Object, V any] struct {
+	key   K
+	value V
+}
+
+// find returns the value associated with the given key.
+func (trie hamt[K, V]) find(key K) (V, bool) {
+	hash := key.HashCode()
+	node := trie.root
+	for shift := uint(0); node != nil; shift += hamtBits {
+		bit := uint32(1) << ((hash >> shift) & hamtMask)
+		if node.bitmap&bit == 0 {
+			break
+		}
+
+		slot := node.slots[node.index(bit)]
+		if slot.child != nil {
+			node = slot.child
+			continue
+		}
+		if slot.hash == hash {
+			for _, entry := range slot.entries {
+				if key.Equals(entry.key) {
+					return entry.value, true
+				}
+			}
+		}
+		break
+	}
+
+	var null V
+	return null, false
+}
+
+// with returns a new trie with the given key associated with the given value, and whether the key was added rather
+// than replaced.
+func (trie hamt[K, V]) with(key K, value V) (hamt[K, V], bool) {
+	root := trie.root
+	if root == nil {
+		root = &hamtNode[K, V]{}
+	}
+
+	newRoot, added := root.with(0, key.HashCode(), hamtEntry[K, V]{key: key, value: value})
+	newTrie := hamt[K, V]{
+		root: newRoot,
+		size: trie.size,
+	}
+	if added {
+		newTrie.size++
+	}
+	return newTrie, added
+}
+
+// without returns a new trie without the given key, and whether the key was removed.
+func (trie hamt[K, V]) without(key K) (hamt[K, V], bool) {
+	if trie.root == nil {
+		return trie, false
+	}
+
+	newRoot, removed := trie.root.without(0, key.HashCode(), key)
+	if !removed {
+		return trie, false
+	}
+	return hamt[K, V]{
+		root: newRoot,
+		size: trie.size - 1,
+	}, true
+}
+
+// entries returns a sequence over every entry in the trie.
+func (trie hamt[K, V]) entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if trie.root != nil {
+			trie.root.entries(yield)
+		}
+	}
+}
+
+// hamt node
+
+// index returns the position in slots of the slot for the given bit.
+func (node *hamtNode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(node.bitmap & (bit - 1))
+}
+
+func (node *hamtNode[K, V]) with(shift uint, hash uint64, entry hamtEntry[K, V]) (*hamtNode[K, V], bool) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	ix := node.index(bit)
+
+	if node.bitmap&bit == 0 {
+		newNode := &hamtNode[K, V]{
+			bitmap: node.bitmap | bit,
+			slots:  make([]hamtSlot[K, V], len(node.slots)+1),
+		}
+		copy(newNode.slots, node.slots[:ix])
+		newNode.slots[ix] = hamtSlot[K, V]{hash: hash, entries: []hamtEntry[K, V]{entry}}
+		copy(newNode.slots[ix+1:], node.slots[ix:])
+		return newNode, true
+	}
+
+	slot := node.slots[ix]
+	var newSlot hamtSlot[K, V]
+	added := true
+	switch {
+	case slot.child != nil:
+		child, childAdded := slot.child.with(shift+hamtBits, hash, entry)
+		newSlot = hamtSlot[K, V]{child: child}
+		added = childAdded
+	case slot.hash == hash:
+		entries := make([]hamtEntry[K, V], len(slot.entries), len(slot.entries)+1)
+		copy(entries, slot.entries)
+		for ix, contained := range entries {
+			if entry.key.Equals(contained.key) {
+				entries[ix] = entry
+				added = false
+				break
+			}
+		}
+		if added {
+			entries = append(entries, entry)
+		}
+		newSlot = hamtSlot[K, V]{hash: hash, entries: entries}
+	default:
+		// Two different hashes share this slot, so push both down a level
+		// where they can be told apart.
+		child := &hamtNode[K, V]{
+			bitmap: uint32(1) << ((slot.hash >> (shift + hamtBits)) & hamtMask),
+			slots:  []hamtSlot[K, V]{slot},
+		}
+		child, _ = child.with(shift+hamtBits, hash, entry)
+		newSlot = hamtSlot[K, V]{child: child}
+	}
+
+	return node.replace(ix, newSlot), added
+}
+
+func (node *hamtNode[K, V]) without(shift uint, hash uint64, key K) (*hamtNode[K, V], bool) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+
+	ix := node.index(bit)
+	slot := node.slots[ix]
+
+	if slot.child != nil {
+		child, removed := slot.child.without(shift+hamtBits, hash, key)
+		if !removed {
+			return node, false
+		}
+		if child == nil {
+			return node.remove(ix, bit), true
+		}
+		if len(child.slots) == 1 && child.slots[0].child == nil {
+			// Pull lone buckets back up, so the trie stays as shallow as it can.
+			return node.replace(ix, child.slots[0]), true
+		}
+		return node.replace(ix, hamtSlot[K, V]{child: child}), true
+	}
+
+	if slot.hash != hash {
+		return node, false
+	}
+	for entryIx, entry := range slot.entries {
+		if !key.Equals(entry.key) {
+			continue
+		}
+
+		if len(slot.entries) == 1 {
+			return node.remove(ix, bit), true
+		}
+		entries := make([]hamtEntry[K, V], 0, len(slot.entries)-1)
+		entries = append(entries, slot.entries[:entryIx]...)
+		entries = append(entries, slot.entries[entryIx+1:]...)
+		return node.replace(ix, hamtSlot[K, V]{hash: hash, entries: entries}), true
+	}
+	return node, false
+}
+
+// replace returns a copy of the node with the slot at the given position replaced.
+func (node *hamtNode[K, V]) replace(ix int, slot hamtSlot[K, V]) *hamtNode[K, V] {
+	newNode := &hamtNode[K, V]{
+		bitmap: node.bitmap,
+		slots:  make([]hamtSlot[K, V], len(node.slots)),
+	}
+	copy(newNode.slots, node.slots)
+	newNode.slots[ix] = slot
+	return newNode
+}
+
+// remove returns a copy of the node without the slot at the given position, or nil if that leaves it empty.
+func (node *hamtNode[K, V]) remove(ix int, bit uint32) *hamtNode[K, V] {
+	if len(node.slots) == 1 {
+		return nil
+	}
+
+	newNode := &hamtNode[K, V]{
+		bitmap: node.bitmap &^ bit,
+		slots:  make([]hamtSlot[K, V], 0, len(node.slots)-1),
+	}
+	newNode.slots = append(newNode.slots, node.slots[:ix]...)
+	newNode.slots = append(newNode.slots, node.slots[ix+1:]...)
+	return newNode
+}
+
+func (node *hamtNode[K, V]) entries(yield func(K, V) bool) bool {
+	for _, slot := range node.slots {
+		if slot.child != nil {
+			if !slot.child.entries(yield) {
+				return false
+			}
+			continue
+		}
+		for _, entry := range slot.entries {
+			if !yield(entry.key, entry.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,426 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// hashBiMap keeps two hash maps, one in each direction, and updates them
+// together. Its inverse is another hashBiMap over the same two maps with their
+// roles swapped, so neither side can fall out of step with the other.
+type hashBiMap[K, V objects.Object] struct {
+	forward  *hashMap[K, V]
+	backward *hashMap[V, K]
+
+	inverse *hashBiMap[V, K]
+}
+
+// NewHashBiMap creates a new bidirectional hash map with the given elements. Entries whose key or value is already
+// in the map are skipped.
+func NewHashBiMap[K, V objects.Object](entries ...MapEntry[K, V]) BiMap[K, V] {
+	m := newHashBiMap[K, V]()
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+func newHashBiMap[K, V objects.Object]() *hashBiMap[K, V] {
+	m := &hashBiMap[K, V]{
+		forward:  NewHashMap[K, V]().(*hashMap[K, V]),
+		backward: NewHashMap[V, K]().(*hashMap[V, K]),
+	}
+	m.inverse = &hashBiMap[V, K]{
+		forward:  m.backward,
+		backward: m.forward,
+		inverse:  m,
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *hashBiMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *hashBiMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *hashBiMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *hashBiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.forward.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *hashBiMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator implements MutableIterator, so entries can be removed while
+// iterating.
+func (m *hashBiMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &hashBiMapIterator[K, V]{
+		iterator: m.forward.Iterator().(MutableIterator[MapEntry[K, V]]),
+		backward: m.backward,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *hashBiMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return m.forward.Elems()
+}
+
+// Add implements Collection.
+func (m *hashBiMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *hashBiMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *hashBiMap[K, V]) Remove(value MapEntry[K, V]) error {
+	if !m.forward.Contains(value) {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+
+	m.delete(value.GetKey(), value.GetValue())
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (m *hashBiMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *hashBiMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	return m.forward.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (m *hashBiMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection.
+func (m *hashBiMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	return m.forward.RemoveIf(func(value MapEntry[K, V]) bool {
+		if !condition(value) {
+			return false
+		}
+		_, _ = m.backward.DeleteIfPresent(value.GetValue())
+		return true
+	})
+}
+
+// RetainAll implements Collection.
+func (m *hashBiMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	return collectionRetainAll[MapEntry[K, V]](m, values)
+}
+
+// Copy implements Collection. The copy is independent of the original and its inverse.
+func (m *hashBiMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	newMap := newHashBiMap[K, V]()
+	for key, value := range m.forward.Entries() {
+		newMap.set(key, value)
+	}
+	return newMap
+}
+
+// Size implements Collection.
+func (m *hashBiMap[K, V]) Size() int {
+	return m.forward.Size()
+}
+
+// IsEmpty implements Collection.
+func (m *hashBiMap[K, V]) IsEmpty() bool {
+	return m.forward.IsEmpty()
+}
+
+// Clear implements Collection.
+func (m *hashBiMap[K, V]) Clear() {
+	m.forward.Clear()
+	m.backward.Clear()
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *hashBiMap[K, V]) Entries() iter.Seq2[K, V] {
+	return m.forward.Entries()
+}
+
+// ContainsKey implements Map.
+func (m *hashBiMap[K, V]) ContainsKey(key K) bool {
+	return m.forward.ContainsKey(key)
+}
+
+// Put implements Map.
+func (m *hashBiMap[K, V]) Put(key K, value V) error {
+	if m.forward.ContainsKey(key) {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+	if m.backward.ContainsKey(value) {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "value", value)
+	}
+
+	m.set(key, value)
+	return nil
+}
+
+// Replace implements Map.
+func (m *hashBiMap[K, V]) Replace(key K, value V) (V, error) {
+	current, err := m.forward.GetSafe(key)
+	if err != nil {
+		return current, err
+	}
+	if err := m.checkValue(key, value); err != nil {
+		var obj V
+		return obj, err
+	}
+
+	m.set(key, value)
+	return current, nil
+}
+
+// PutOrReplace implements Map. It panics with an ErrorCodeAlreadyExists error if the value is associated with a
+// different key, as do PutIfAbsent, ComputeIfAbsent, ComputeIfPresent, Compute, Merge and ReplaceAllValues.
+func (m *hashBiMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	m.mustCheckValue(key, value)
+	return m.set(key, value)
+}
+
+// Delete implements Map.
+func (m *hashBiMap[K, V]) Delete(key K) (V, error) {
+	value, err := m.forward.Delete(key)
+	if err != nil {
+		return value, err
+	}
+
+	_, _ = m.backward.DeleteIfPresent(value)
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *hashBiMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	value, ok := m.forward.DeleteIfPresent(key)
+	if ok {
+		_, _ = m.backward.DeleteIfPresent(value)
+	}
+	return value, ok
+}
+
+// Get implements Map.
+func (m *hashBiMap[K, V]) Get(key K) V {
+	return m.forward.Get(key)
+}
+
+// GetSafe implements Map.
+func (m *hashBiMap[K, V]) GetSafe(key K) (V, error) {
+	return m.forward.GetSafe(key)
+}
+
+// GetOrDefault implements Map.
+func (m *hashBiMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	return m.forward.GetOrDefault(key, defaultValue)
+}
+
+// PutIfAbsent implements Map.
+func (m *hashBiMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if current, err := m.forward.GetSafe(key); err == nil {
+		return current, true
+	}
+
+	m.mustCheckValue(key, value)
+	m.set(key, value)
+	return value, false
+}
+
+// ComputeIfAbsent implements Map.
+func (m *hashBiMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	if current, err := m.forward.GetSafe(key); err == nil {
+		return current
+	}
+
+	modCount := m.forward.modCount
+	value := fn(key)
+	checkForComodification(modCount, m.forward.modCount)
+
+	m.mustCheckValue(key, value)
+	m.set(key, value)
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (m *hashBiMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	current, err := m.forward.GetSafe(key)
+	if err != nil {
+		var obj V
+		return obj, false
+	}
+	return m.apply(key, current, true, func() (V, bool) {
+		return fn(key, current)
+	})
+}
+
+// Compute implements Map.
+func (m *hashBiMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	current, err := m.forward.GetSafe(key)
+	return m.apply(key, current, err == nil, func() (V, bool) {
+		return fn(key, current, err == nil)
+	})
+}
+
+// Merge implements Map.
+func (m *hashBiMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	current, err := m.forward.GetSafe(key)
+	if err != nil {
+		m.mustCheckValue(key, value)
+		m.set(key, value)
+		return value, true
+	}
+	return m.apply(key, current, true, func() (V, bool) {
+		return remapping(current, value)
+	})
+}
+
+// ReplaceAllValues implements Map. Every new value is worked out before any are replaced, so if two keys would end up
+// with the same value the map is left unchanged.
+func (m *hashBiMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	replaced := NewHashMapWith[V, K](WithCapacity(m.forward.Size()))
+	for key, value := range m.forward.Entries() {
+		value = fn(key, value)
+		if err := replaced.Put(value, key); err != nil {
+			panic(errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "value", value))
+		}
+	}
+
+	m.backward.Clear()
+	for value, key := range replaced.Entries() {
+		_, _ = m.forward.Replace(key, value)
+		_ = m.backward.Put(value, key)
+	}
+}
+
+// Keys implements Map.
+func (m *hashBiMap[K, V]) Keys() Collection[K] {
+	return m.forward.Keys()
+}
+
+// Values implements Map. The values are unique, so they are returned as a set.
+func (m *hashBiMap[K, V]) Values() Collection[V] {
+	return m.backward.Keys()
+}
+
+// BiMap implementation
+
+// Inverse implements BiMap.
+func (m *hashBiMap[K, V]) Inverse() BiMap[V, K] {
+	return m.inverse
+}
+
+// hash bimap
+
+// checkValue returns an error if the given value is associated with a key other than the given key.
+func (m *hashBiMap[K, V]) checkValue(key K, value V) error {
+	if owner, err := m.backward.GetSafe(value); err == nil && !owner.Equals(key) {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "value", value)
+	}
+	return nil
+}
+
+// mustCheckValue panics if the given value is associated with a key other than the given key.
+func (m *hashBiMap[K, V]) mustCheckValue(key K, value V) {
+	if err := m.checkValue(key, value); err != nil {
+		panic(err)
+	}
+}
+
+// set associates the key with the value in both directions, removing the key's previous value. The value must not
+// belong to a different key.
+func (m *hashBiMap[K, V]) set(key K, value V) (V, bool) {
+	current, replaced := m.forward.PutOrReplace(key, value)
+	if replaced {
+		_, _ = m.backward.DeleteIfPresent(current)
+	}
+	_, _ = m.backward.PutOrReplace(value, key)
+	return current, replaced
+}
+
+// delete removes the key and value from both directions.
+func (m *hashBiMap[K, V]) delete(key K, value V) {
+	_, _ = m.forward.DeleteIfPresent(key)
+	_, _ = m.backward.DeleteIfPresent(value)
+}
+
+// apply calls fn to work out the new value for the key, then stores it, or removes the key if fn returns false.
+func (m *hashBiMap[K, V]) apply(key K, current V, present bool, fn func() (V, bool)) (V, bool) {
+	modCount := m.forward.modCount
+	value, keep := fn()
+	checkForComodification(modCount, m.forward.modCount)
+
+	if !keep {
+		if present {
+			m.delete(key, current)
+		}
+		var obj V
+		return obj, false
+	}
+
+	m.mustCheckValue(key, value)
+	m.set(key, value)
+	return value, true
+}
+
+type hashBiMapIterator[K, V objects.Object] struct {
+	iterator MutableIterator[MapEntry[K, V]]
+	backward *hashMap[V, K]
+	last     MapEntry[K, V]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *hashBiMapIterator[K, V]) HasNext() bool {
+	return iterator.iterator.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *hashBiMapIterator[K, V]) Next() MapEntry[K, V] {
+	iterator.last = iterator.iterator.Next()
+	return iterator.last
+}
+
+// Remove implements MutableIterator.
+func (iterator *hashBiMapIterator[K, V]) Remove() {
+	iterator.iterator.Remove()
+	_, _ = iterator.backward.DeleteIfPresent(iterator.last.GetValue())
+}
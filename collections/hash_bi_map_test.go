@@ -0,0 +1,138 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestHashBiMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewHashBiMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestHashBiMap_FailFast(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runFailFastTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewHashBiMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestHashBiMap(t *testing.T) {
+	m := NewHashBiMap[*objects.Int, *objects.String]()
+	inverse := m.Inverse()
+
+	tests.ExecuteE(m.Put(objects.WrapInt(1), objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapInt(2), objects.WrapString("two"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapInt(3), objects.WrapString("one"))).ErrorCode(t, ErrorCodeAlreadyExists)
+	tests.ExecuteE(m.Put(objects.WrapInt(1), objects.WrapString("three"))).ErrorCode(t, ErrorCodeAlreadyExists)
+
+	tests.Execute(inverse.Get(objects.WrapString("one"))).Equal(t, objects.WrapInt(1))
+	tests.Execute(inverse.Size()).Equal(t, 2)
+	tests.Execute(inverse.Inverse()).Equal(t, m)
+
+	// Replacing a value frees up the old one.
+	tests.Execute2E(m.Replace(objects.WrapInt(1), objects.WrapString("uno"))).NoError(t).Equal(t, objects.WrapString("one"))
+	tests.Execute(inverse.ContainsKey(objects.WrapString("one"))).Equal(t, false)
+	tests.Execute(inverse.Get(objects.WrapString("uno"))).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(m.Replace(objects.WrapInt(1), objects.WrapString("two"))).ErrorCode(t, ErrorCodeAlreadyExists)
+	tests.Execute2E(m.Replace(objects.WrapInt(1), objects.WrapString("uno"))).NoError(t)
+
+	// Deleting through either side keeps both in step.
+	tests.Execute2E(m.Delete(objects.WrapInt(2))).NoError(t).Equal(t, objects.WrapString("two"))
+	tests.Execute(inverse.ContainsKey(objects.WrapString("two"))).Equal(t, false)
+	tests.Execute2E(inverse.Delete(objects.WrapString("uno"))).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.Execute(m.IsEmpty()).Equal(t, true)
+
+	tests.ExecuteE(inverse.Put(objects.WrapString("four"), objects.WrapInt(4))).NoError(t)
+	tests.Execute(m.Get(objects.WrapInt(4))).Equal(t, objects.WrapString("four"))
+}
+
+func TestHashBiMap_Panics(t *testing.T) {
+	m := NewHashBiMap[*objects.Int, *objects.String]()
+	tests.ExecuteE(m.Put(objects.WrapInt(1), objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapInt(2), objects.WrapString("two"))).NoError(t)
+
+	expectErrorCode(t, ErrorCodeAlreadyExists, func() {
+		m.PutOrReplace(objects.WrapInt(3), objects.WrapString("one"))
+	})
+	expectErrorCode(t, ErrorCodeAlreadyExists, func() {
+		m.Merge(objects.WrapInt(2), objects.WrapString("two"), func(current, value *objects.String) (*objects.String, bool) {
+			return objects.WrapString("one"), true
+		})
+	})
+	expectErrorCode(t, ErrorCodeAlreadyExists, func() {
+		m.ReplaceAllValues(func(key *objects.Int, value *objects.String) *objects.String {
+			return objects.WrapString("same")
+		})
+	})
+
+	// Nothing changed.
+	tests.Execute(m.String()).Equal(t, NewHashBiMap[*objects.Int, *objects.String](
+		&mapEntry[*objects.Int, *objects.String]{Key: objects.WrapInt(1), Value: objects.WrapString("one")},
+		&mapEntry[*objects.Int, *objects.String]{Key: objects.WrapInt(2), Value: objects.WrapString("two")}).String())
+	tests.Execute(m.Inverse().Size()).Equal(t, 2)
+
+	// Swapping values is fine, as the result is still unique.
+	m.ReplaceAllValues(func(key *objects.Int, value *objects.String) *objects.String {
+		if key.Unwrap() == 1 {
+			return objects.WrapString("two")
+		}
+		return objects.WrapString("one")
+	})
+	tests.Execute(m.Inverse().Get(objects.WrapString("two"))).Equal(t, objects.WrapInt(1))
+	tests.Execute(m.Inverse().Get(objects.WrapString("one"))).Equal(t, objects.WrapInt(2))
+}
+
+func TestHashBiMap_IteratorRemove(t *testing.T) {
+	m := NewHashBiMap[*objects.Int, *objects.String]()
+	tests.ExecuteE(m.Put(objects.WrapInt(1), objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapInt(2), objects.WrapString("two"))).NoError(t)
+
+	iterator := m.Iterator().(MutableIterator[MapEntry[*objects.Int, *objects.String]])
+	for iterator.HasNext() {
+		if iterator.Next().GetKey().Unwrap() == 1 {
+			iterator.Remove()
+		}
+	}
+	tests.Execute(m.Size()).Equal(t, 1)
+	tests.Execute(m.Inverse().ContainsKey(objects.WrapString("one"))).Equal(t, false)
+
+	tests.Execute(m.RemoveIf(func(value MapEntry[*objects.Int, *objects.String]) bool {
+		return true
+	})).Equal(t, 1)
+	tests.Execute(m.Inverse().IsEmpty()).Equal(t, true)
+}
+
+func TestHashBiMap_JSON(t *testing.T) {
+	m := NewHashBiMap[*objects.Int, *objects.String]()
+	tests.ExecuteE(json.Unmarshal([]byte(`[{"key":1,"value":"one"}]`), m)).NoError(t)
+	tests.Execute(m.Inverse().Get(objects.WrapString("one"))).Equal(t, objects.WrapInt(1))
+
+	err := json.Unmarshal([]byte(`[{"key":1,"value":"one"},{"key":2,"value":"one"}]`), m)
+	tests.ExecuteE(err).ErrorCode(t, ErrorCodeAlreadyExists)
+}
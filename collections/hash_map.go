@@ -5,20 +5,56 @@ import (
 	"encoding/json"
 	"fmt"
 	"iter"
+	"math/bits"
 
 	"github.com/pasataleo/go-errors/errors"
 	"github.com/pasataleo/go-objects/objects"
 )
 
+const (
+	hashMapMinCapacity = 8
+
+	// The table grows once it is more than this full, unless configured otherwise. Robin Hood hashing keeps probe
+	// sequences short even at high load.
+	hashMapDefaultLoadFactor = 0.875
+)
+
+// hashMap is an open-addressing hash table using Robin Hood hashing. Entries
+// are stored in place in the slots, so adding an entry doesn't allocate
+// unless the table has to grow.
+//
+// Each entry is kept in a run of slots starting at its ideal slot. When
+// inserting, an entry that is further from its ideal slot than the resident
+// of a slot takes that slot, and the resident moves further along instead.
+// This keeps the distances even, and means a lookup can stop as soon as it
+// finds a resident closer to its ideal slot than the key would be.
 type hashMap[K objects.Object, V objects.Object] struct {
-	values map[uint64][]MapEntry[K, V]
-	size   int
+	slots []hashMapSlot[K, V]
+	size  int
+
+	loadFactor float64
+
+	// shift turns a mixed hash into a slot index, by keeping its top bits.
+	shift uint
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
+}
+
+type hashMapSlot[K, V objects.Object] struct {
+	key   K
+	value V
+	hash  uint64
+
+	// distance is one more than how far the slot is from the ideal slot for
+	// its entry, so the zero value marks an empty slot.
+	distance int
 }
 
 // NewHashMap creates a new hash map with the given elements.
 func NewHashMap[K objects.Object, V objects.Object](entries ...MapEntry[K, V]) Map[K, V] {
 	m := &hashMap[K, V]{
-		values: make(map[uint64][]MapEntry[K, V]),
+		loadFactor: hashMapDefaultLoadFactor,
 	}
 	for _, entry := range entries {
 		_ = m.Put(entry.GetKey(), entry.GetValue())
@@ -27,29 +63,21 @@ func NewHashMap[K objects.Object, V objects.Object](entries ...MapEntry[K, V]) M
 	return m
 }
 
+// NewHashMapWith creates an empty hash map, with its table configured by the given options.
+func NewHashMapWith[K objects.Object, V objects.Object](opts ...Option) Map[K, V] {
+	options := newOptions(opts)
+	m := &hashMap[K, V]{
+		loadFactor: options.loadFactor,
+	}
+	m.EnsureCapacity(options.capacity)
+	return m
+}
+
 // Object implementation
 
 // Equals implements objects.Object.
 func (h *hashMap[K, V]) Equals(other any) bool {
-	if lMap, ok := other.(Map[K, V]); ok {
-		if lMap.Size() != h.Size() {
-			return false
-		}
-
-		for iterator := h.Iterator(); iterator.HasNext(); {
-			entry := iterator.Next()
-
-			if !h.ContainsKey(entry.GetKey()) {
-				return false
-			}
-
-			if !entry.GetValue().Equals(h.Get(entry.GetKey())) {
-				return false
-			}
-		}
-		return true
-	}
-	return false
+	return mapEquals[K, V](h, other)
 }
 
 // HashCode implements objects.Object.
@@ -84,7 +112,7 @@ func (h *hashMap[K, V]) String() string {
 
 // MarshalJSON implements objects.Object.
 func (h *hashMap[K, V]) MarshalJSON() ([]byte, error) {
-	var entries []MapEntry[K, V]
+	entries := make([]MapEntry[K, V], 0, h.size)
 	for iterator := h.Iterator(); iterator.HasNext(); {
 		entries = append(entries, iterator.Next())
 	}
@@ -93,14 +121,14 @@ func (h *hashMap[K, V]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements objects.Object.
 func (h *hashMap[K, V]) UnmarshalJSON(bytes []byte) error {
-	var entries []MapEntry[K, V]
+	var entries []*mapEntry[K, V]
 	if err := json.Unmarshal(bytes, &entries); err != nil {
 		return err
 	}
 
 	h.Clear()
 	for _, entry := range entries {
-		if err := h.Put(entry.GetKey(), entry.GetValue()); err != nil {
+		if err := h.Put(entry.Key, entry.Value); err != nil {
 			return err
 		}
 	}
@@ -109,17 +137,21 @@ func (h *hashMap[K, V]) UnmarshalJSON(bytes []byte) error {
 
 // Iterable implementation
 
-// Iterator implements objects.Iterable.
+// Iterator implements objects.Iterable. Each entry returned is a copy, so it isn't affected by later changes to the
+// map. The iterator implements MutableIterator, so entries can be removed while iterating.
 func (h *hashMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	start := 0
+	for ix := range h.slots {
+		if h.slots[ix].distance == 0 {
+			start = ix + 1
+			break
+		}
+	}
 	return &hashMapIterator[K, V]{
-		set: h,
-		keys: func() []uint64 {
-			var keys []uint64
-			for key := range h.values {
-				keys = append(keys, key)
-			}
-			return keys
-		}(),
+		m:        h,
+		start:    start,
+		last:     -1,
+		modCount: h.modCount,
 	}
 }
 
@@ -142,12 +174,13 @@ func (h *hashMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
 
 // Remove implements Collection.
 func (h *hashMap[K, V]) Remove(value MapEntry[K, V]) error {
-	if !h.Contains(value) {
+	ix := h.find(value.GetKey())
+	if ix < 0 || !value.GetValue().Equals(h.slots[ix].value) {
 		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", value.GetKey())
 	}
 
-	_, err := h.Delete(value.GetKey())
-	return err
+	h.deleteAt(ix)
+	return nil
 }
 
 // RemoveAll implements Collection.
@@ -157,12 +190,8 @@ func (h *hashMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
 
 // Contains implements Collection.
 func (h *hashMap[K, V]) Contains(value MapEntry[K, V]) bool {
-	if !h.ContainsKey(value.GetKey()) {
-		return false
-	}
-
-	contained := h.Get(value.GetKey())
-	return value.GetValue().Equals(contained)
+	ix := h.find(value.GetKey())
+	return ix >= 0 && value.GetValue().Equals(h.slots[ix].value)
 }
 
 // ContainsAll implements Collection.
@@ -170,12 +199,34 @@ func (h *hashMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
 	return collectionContainsAll[MapEntry[K, V]](h, values)
 }
 
+// RemoveIf implements Collection. The table is filtered in a single pass, with each removal shifting the rest of its
+// run back into place.
+func (h *hashMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	removed := 0
+	for iterator := h.Iterator().(*hashMapIterator[K, V]); iterator.HasNext(); {
+		if condition(iterator.Next()) {
+			iterator.Remove()
+			removed = removed + 1
+		}
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (h *hashMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	return collectionRetainAll[MapEntry[K, V]](h, values)
+}
+
 // Copy implements Collection.
 func (h *hashMap[K, V]) Copy() Collection[MapEntry[K, V]] {
-	newMap := NewHashMap[K, V]()
-	for iterator := h.Iterator(); iterator.HasNext(); {
-		_ = newMap.Add(iterator.Next())
+	// The slots hold no pointers into the table, so copying them copies the map.
+	newMap := &hashMap[K, V]{
+		slots:      make([]hashMapSlot[K, V], len(h.slots)),
+		size:       h.size,
+		loadFactor: h.loadFactor,
+		shift:      h.shift,
 	}
+	copy(newMap.slots, h.slots)
 	return newMap
 }
 
@@ -191,8 +242,10 @@ func (h *hashMap[K, V]) IsEmpty() bool {
 
 // Clear implements Collection.
 func (h *hashMap[K, V]) Clear() {
-	h.values = make(map[uint64][]MapEntry[K, V])
+	h.slots = nil
 	h.size = 0
+	h.shift = 0
+	h.modCount = h.modCount + 1
 }
 
 // Map implementation
@@ -200,167 +253,229 @@ func (h *hashMap[K, V]) Clear() {
 // Entries implements Map.
 func (h *hashMap[K, V]) Entries() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
-		for iterator := h.Iterator(); iterator.HasNext(); {
-			entry := iterator.Next()
-			if !yield(entry.GetKey(), entry.GetValue()) {
+		modCount := h.modCount
+		for ix := 0; ix < len(h.slots); ix++ {
+			slot := &h.slots[ix]
+			if slot.distance == 0 {
+				continue
+			}
+			if !yield(slot.key, slot.value) {
 				return
 			}
+			checkForComodification(modCount, h.modCount)
 		}
 	}
 }
 
 // ContainsKey implements Map.
 func (h *hashMap[K, V]) ContainsKey(key K) bool {
-	hash := key.HashCode()
-
-	values := h.values[hash]
-	for _, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			return true
-		}
-	}
-	return false
+	return h.find(key) >= 0
 }
 
 // Put implements Map.
 func (h *hashMap[K, V]) Put(key K, value V) error {
-	hash := key.HashCode()
-
-	newEntry := &mapEntry[K, V]{
-		Key:   key,
-		Value: value,
+	if h.find(key) >= 0 {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
 	}
 
-	values := h.values[hash]
-	for _, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
-		}
-	}
-	values = append(values, newEntry)
-	h.values[hash] = values
-	h.size = h.size + 1
+	h.insert(key, value)
 	return nil
 }
 
 // Replace implements Map.
 func (h *hashMap[K, V]) Replace(key K, value V) (V, error) {
-	hash := key.HashCode()
-
-	newEntry := &mapEntry[K, V]{
-		Key:   key,
-		Value: value,
-	}
-
-	values := h.values[hash]
-	for ix, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			oldValue := entry.GetValue()
-			values[ix] = newEntry
-			h.values[hash] = values
-			return oldValue, nil
-		}
+	ix := h.find(key)
+	if ix < 0 {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
 	}
 
-	var obj V
-	return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	oldValue := h.slots[ix].value
+	h.slots[ix].key = key
+	h.slots[ix].value = value
+	return oldValue, nil
 }
 
 // PutOrReplace implements Map.
 func (h *hashMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
-	hash := key.HashCode()
-
-	newEntry := &mapEntry[K, V]{
-		Key:   key,
-		Value: value,
-	}
-
-	values := h.values[hash]
-	for ix, entry := range values {
-		if key.Equals(key) {
-			oldValue := entry.GetValue()
-			values[ix] = newEntry
-			h.values[hash] = values
-			return oldValue, true
-		}
+	if ix := h.find(key); ix >= 0 {
+		oldValue := h.slots[ix].value
+		h.slots[ix].key = key
+		h.slots[ix].value = value
+		return oldValue, true
 	}
 
-	values = append(values, newEntry)
-	h.values[hash] = values
-	h.size = h.size + 1
+	h.insert(key, value)
 	return value, false
 }
 
 // Delete implements Map.
 func (h *hashMap[K, V]) Delete(key K) (V, error) {
-	hash := key.HashCode()
-
-	values := h.values[hash]
-	for ix, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			newValues := append(values[:ix], values[ix+1:]...)
-			h.values[hash] = newValues
-			h.size = h.size - 1
-			return entry.GetValue(), nil
-		}
+	ix := h.find(key)
+	if ix < 0 {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
 	}
 
-	var obj V
-	return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	value := h.slots[ix].value
+	h.deleteAt(ix)
+	return value, nil
 }
 
 // DeleteIfPresent implements Map.
 func (h *hashMap[K, V]) DeleteIfPresent(key K) (V, bool) {
-	hash := key.HashCode()
-
-	values := h.values[hash]
-	for ix, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			newValues := append(values[:ix], values[ix+1:]...)
-			h.values[hash] = newValues
-			h.size = h.size - 1
-			return entry.GetValue(), true
-		}
+	ix := h.find(key)
+	if ix < 0 {
+		var obj V
+		return obj, false
 	}
 
-	var obj V
-	return obj, false
+	value := h.slots[ix].value
+	h.deleteAt(ix)
+	return value, true
 }
 
 // Get implements Map.
 func (h *hashMap[K, V]) Get(key K) V {
-	hash := key.HashCode()
-
-	values := h.values[hash]
-	for _, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			return entry.GetValue()
-		}
+	ix := h.find(key)
+	if ix < 0 {
+		panic("not found")
 	}
-	panic("not found")
+	return h.slots[ix].value
 }
 
 // GetSafe implements Map.
 func (h *hashMap[K, V]) GetSafe(key K) (V, error) {
-	hash := key.HashCode()
+	ix := h.find(key)
+	if ix < 0 {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return h.slots[ix].value, nil
+}
+
+// GetOrDefault implements Map.
+func (h *hashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	ix := h.find(key)
+	if ix < 0 {
+		return defaultValue
+	}
+	return h.slots[ix].value
+}
+
+// PutIfAbsent implements Map.
+func (h *hashMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	hash, ix, distance, found := h.probe(key)
+	if found {
+		return h.slots[ix].value, true
+	}
+
+	h.insertAt(ix, distance, hashMapSlot[K, V]{key: key, value: value, hash: hash})
+	return value, false
+}
+
+// ComputeIfAbsent implements Map.
+func (h *hashMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	hash, ix, distance, found := h.probe(key)
+	if found {
+		return h.slots[ix].value
+	}
+
+	modCount := h.modCount
+	value := fn(key)
+	checkForComodification(modCount, h.modCount)
 
-	values := h.values[hash]
-	for _, entry := range values {
-		if key.Equals(entry.GetKey()) {
-			return entry.GetValue(), nil
+	h.insertAt(ix, distance, hashMapSlot[K, V]{key: key, value: value, hash: hash})
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (h *hashMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	ix := h.find(key)
+	if ix < 0 {
+		var obj V
+		return obj, false
+	}
+
+	modCount := h.modCount
+	value, keep := fn(key, h.slots[ix].value)
+	checkForComodification(modCount, h.modCount)
+
+	if !keep {
+		h.deleteAt(ix)
+		var obj V
+		return obj, false
+	}
+	h.slots[ix].value = value
+	return value, true
+}
+
+// Compute implements Map.
+func (h *hashMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	hash, ix, distance, found := h.probe(key)
+
+	var current V
+	if found {
+		current = h.slots[ix].value
+	}
+
+	modCount := h.modCount
+	value, keep := fn(key, current, found)
+	checkForComodification(modCount, h.modCount)
+
+	if !keep {
+		if found {
+			h.deleteAt(ix)
 		}
+		var obj V
+		return obj, false
+	}
+
+	if found {
+		h.slots[ix].value = value
+	} else {
+		h.insertAt(ix, distance, hashMapSlot[K, V]{key: key, value: value, hash: hash})
+	}
+	return value, true
+}
+
+// Merge implements Map.
+func (h *hashMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	hash, ix, distance, found := h.probe(key)
+	if !found {
+		h.insertAt(ix, distance, hashMapSlot[K, V]{key: key, value: value, hash: hash})
+		return value, true
 	}
 
-	var obj V
-	return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	modCount := h.modCount
+	merged, keep := remapping(h.slots[ix].value, value)
+	checkForComodification(modCount, h.modCount)
+
+	if !keep {
+		h.deleteAt(ix)
+		var obj V
+		return obj, false
+	}
+	h.slots[ix].value = merged
+	return merged, true
+}
+
+// ReplaceAllValues implements Map.
+func (h *hashMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	modCount := h.modCount
+	for ix := range h.slots {
+		if slot := &h.slots[ix]; slot.distance != 0 {
+			slot.value = fn(slot.key, slot.value)
+			checkForComodification(modCount, h.modCount)
+		}
+	}
 }
 
 // Keys implements Map.
 func (h *hashMap[K, V]) Keys() Collection[K] {
 	set := NewHashSet[K]()
-	for iterator := h.Iterator(); iterator.HasNext(); {
-		value := iterator.Next()
-		if err := set.Add(value.GetKey()); err != nil {
+	for key := range h.Entries() {
+		if err := set.Add(key); err != nil {
 			panic(err)
 		}
 	}
@@ -370,11 +485,169 @@ func (h *hashMap[K, V]) Keys() Collection[K] {
 // Values implements Map.
 func (h *hashMap[K, V]) Values() Collection[V] {
 	list := NewArrayList[V]()
-	for iterator := h.Iterator(); iterator.HasNext(); {
-		value := iterator.Next()
-		if err := list.Add(value.GetValue()); err != nil {
+	for _, value := range h.Entries() {
+		if err := list.Add(value); err != nil {
 			panic(err)
 		}
 	}
 	return list
 }
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (h *hashMap[K, V]) EnsureCapacity(capacity int) {
+	if slots := h.slotsFor(capacity); slots > len(h.slots) {
+		h.resize(slots)
+	}
+}
+
+// TrimToSize implements Resizable.
+func (h *hashMap[K, V]) TrimToSize() {
+	if h.size == 0 {
+		h.Clear()
+		return
+	}
+	if slots := h.slotsFor(h.size); slots < len(h.slots) {
+		h.resize(slots)
+	}
+}
+
+// hash map
+
+// home returns the ideal slot for the given hash. The hash is mixed first, as many hash codes only vary in their low
+// bits.
+func (h *hashMap[K, V]) home(hash uint64) int {
+	return int((hash * 0x9e3779b97f4a7c15) >> h.shift)
+}
+
+// find returns the index of the slot holding the given key, or -1 if the key isn't in the map.
+func (h *hashMap[K, V]) find(key K) int {
+	if h.size == 0 {
+		return -1
+	}
+
+	hash := key.HashCode()
+	mask := len(h.slots) - 1
+	for ix, distance := h.home(hash), 1; ; ix, distance = (ix+1)&mask, distance+1 {
+		slot := &h.slots[ix]
+		if slot.distance < distance {
+			// Either the slot is empty, or the key would have displaced its
+			// resident, so the key isn't in the map.
+			return -1
+		}
+		if slot.hash == hash && key.Equals(slot.key) {
+			return ix
+		}
+	}
+}
+
+// probe looks for the slot holding the given key. If the key isn't in the map, it returns the slot and distance
+// where an entry for the key would be placed, so the entry can be inserted without a second lookup. The slot is -1 if
+// the table hasn't been allocated yet.
+func (h *hashMap[K, V]) probe(key K) (hash uint64, ix int, distance int, found bool) {
+	hash = key.HashCode()
+	if len(h.slots) == 0 {
+		return hash, -1, 0, false
+	}
+
+	mask := len(h.slots) - 1
+	for ix, distance = h.home(hash), 1; ; ix, distance = (ix+1)&mask, distance+1 {
+		slot := &h.slots[ix]
+		if slot.distance < distance {
+			return hash, ix, distance, false
+		}
+		if slot.hash == hash && key.Equals(slot.key) {
+			return hash, ix, distance, true
+		}
+	}
+}
+
+// insert adds an entry for the given key, which must not already be in the map.
+func (h *hashMap[K, V]) insert(key K, value V) {
+	h.insertAt(-1, 0, hashMapSlot[K, V]{
+		key:   key,
+		value: value,
+		hash:  key.HashCode(),
+	})
+}
+
+// insertAt adds the entry at the slot and distance returned by probe. If the table has to grow first, or no slot is
+// given, the entry is placed from its ideal slot instead.
+func (h *hashMap[K, V]) insertAt(ix int, distance int, entry hashMapSlot[K, V]) {
+	if float64(h.size+1) > float64(len(h.slots))*h.loadFactor {
+		h.resize(max(len(h.slots)*2, hashMapMinCapacity))
+		ix = -1
+	}
+
+	if ix < 0 {
+		h.place(entry)
+	} else {
+		entry.distance = distance
+		h.placeFrom(ix, entry)
+	}
+	h.size = h.size + 1
+	h.modCount = h.modCount + 1
+}
+
+// place puts the entry into the table, displacing entries closer to their ideal slots as it goes.
+func (h *hashMap[K, V]) place(entry hashMapSlot[K, V]) {
+	entry.distance = 1
+	h.placeFrom(h.home(entry.hash), entry)
+}
+
+// placeFrom puts the entry into the table, starting at the given slot. The entry's distance must already be set for
+// that slot.
+func (h *hashMap[K, V]) placeFrom(ix int, entry hashMapSlot[K, V]) {
+	mask := len(h.slots) - 1
+	for ; ; ix = (ix + 1) & mask {
+		slot := &h.slots[ix]
+		if slot.distance == 0 {
+			*slot = entry
+			return
+		}
+		if slot.distance < entry.distance {
+			*slot, entry = entry, *slot
+		}
+		entry.distance = entry.distance + 1
+	}
+}
+
+// deleteAt empties the given slot, and shifts the entries after it back towards their ideal slots, so there are no
+// gaps in the runs that lookups rely on.
+func (h *hashMap[K, V]) deleteAt(ix int) {
+	mask := len(h.slots) - 1
+	for next := (ix + 1) & mask; h.slots[next].distance > 1; ix, next = next, (next+1)&mask {
+		h.slots[ix] = h.slots[next]
+		h.slots[ix].distance = h.slots[ix].distance - 1
+	}
+	h.slots[ix] = hashMapSlot[K, V]{}
+	h.size = h.size - 1
+	h.modCount = h.modCount + 1
+}
+
+// slotsFor returns the number of slots the table needs to hold the given number of entries without growing.
+func (h *hashMap[K, V]) slotsFor(capacity int) int {
+	if capacity == 0 {
+		return 0
+	}
+
+	slots := hashMapMinCapacity
+	for float64(capacity) > float64(slots)*h.loadFactor {
+		slots = slots * 2
+	}
+	return slots
+}
+
+// resize moves every entry into a new table with the given capacity, which must be a power of two.
+func (h *hashMap[K, V]) resize(capacity int) {
+	slots := h.slots
+	h.slots = make([]hashMapSlot[K, V], capacity)
+	h.shift = uint(64 - bits.TrailingZeros(uint(capacity)))
+	h.modCount = h.modCount + 1
+	for _, slot := range slots {
+		if slot.distance != 0 {
+			h.place(slot)
+		}
+	}
+}
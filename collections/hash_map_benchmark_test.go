@@ -0,0 +1,195 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// chainedHashMap is the previous hashMap implementation, which chained
+// entries with the same hash code in slices held by a Go map. It is kept here
+// so the benchmarks can compare against it.
+type chainedHashMap[K, V objects.Object] struct {
+	values map[uint64][]MapEntry[K, V]
+	size   int
+}
+
+func newChainedHashMap[K, V objects.Object]() *chainedHashMap[K, V] {
+	return &chainedHashMap[K, V]{
+		values: make(map[uint64][]MapEntry[K, V]),
+	}
+}
+
+func (h *chainedHashMap[K, V]) Put(key K, value V) bool {
+	hash := key.HashCode()
+	values := h.values[hash]
+	for _, entry := range values {
+		if key.Equals(entry.GetKey()) {
+			return false
+		}
+	}
+	h.values[hash] = append(values, &mapEntry[K, V]{Key: key, Value: value})
+	h.size = h.size + 1
+	return true
+}
+
+func (h *chainedHashMap[K, V]) GetSafe(key K) (V, bool) {
+	for _, entry := range h.values[key.HashCode()] {
+		if key.Equals(entry.GetKey()) {
+			return entry.GetValue(), true
+		}
+	}
+	var null V
+	return null, false
+}
+
+func (h *chainedHashMap[K, V]) Delete(key K) bool {
+	hash := key.HashCode()
+	values := h.values[hash]
+	for ix, entry := range values {
+		if key.Equals(entry.GetKey()) {
+			values = append(values[:ix], values[ix+1:]...)
+			if len(values) == 0 {
+				delete(h.values, hash)
+			} else {
+				h.values[hash] = values
+			}
+			h.size = h.size - 1
+			return true
+		}
+	}
+	return false
+}
+
+func (h *chainedHashMap[K, V]) Iterate(fn func(MapEntry[K, V])) {
+	// Match the old iterator, which collected every hash up front.
+	var keys []uint64
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		for _, entry := range h.values[key] {
+			fn(entry)
+		}
+	}
+}
+
+var hashMapBenchmarkSizes = []int{1_000, 10_000, 100_000, 1_000_000}
+
+func hashMapBenchmarkKeys(size int) []*objects.Int {
+	keys := make([]*objects.Int, size)
+	for ix := range keys {
+		keys[ix] = objects.WrapInt(ix * 7919)
+	}
+	return keys
+}
+
+func BenchmarkHashMap_Put(b *testing.B) {
+	for _, size := range hashMapBenchmarkSizes {
+		keys := hashMapBenchmarkKeys(size)
+
+		b.Run(fmt.Sprintf("open/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				m := NewHashMap[*objects.Int, *objects.Int]()
+				for _, key := range keys {
+					_ = m.Put(key, key)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("chained/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				m := newChainedHashMap[*objects.Int, *objects.Int]()
+				for _, key := range keys {
+					_ = m.Put(key, key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHashMap_Get(b *testing.B) {
+	for _, size := range hashMapBenchmarkSizes {
+		keys := hashMapBenchmarkKeys(size)
+
+		open := NewHashMap[*objects.Int, *objects.Int]()
+		chained := newChainedHashMap[*objects.Int, *objects.Int]()
+		for _, key := range keys {
+			_ = open.Put(key, key)
+			_ = chained.Put(key, key)
+		}
+
+		b.Run(fmt.Sprintf("open/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_, _ = open.GetSafe(keys[n%size])
+			}
+		})
+		b.Run(fmt.Sprintf("chained/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_, _ = chained.GetSafe(keys[n%size])
+			}
+		})
+	}
+}
+
+func BenchmarkHashMap_Delete(b *testing.B) {
+	for _, size := range hashMapBenchmarkSizes {
+		keys := hashMapBenchmarkKeys(size)
+
+		open := NewHashMap[*objects.Int, *objects.Int]()
+		chained := newChainedHashMap[*objects.Int, *objects.Int]()
+		for _, key := range keys {
+			_ = open.Put(key, key)
+			_ = chained.Put(key, key)
+		}
+
+		// Put every deleted key straight back, so the size stays the same.
+		b.Run(fmt.Sprintf("open/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				key := keys[n%size]
+				_, _ = open.Delete(key)
+				_ = open.Put(key, key)
+			}
+		})
+		b.Run(fmt.Sprintf("chained/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				key := keys[n%size]
+				_ = chained.Delete(key)
+				_ = chained.Put(key, key)
+			}
+		})
+	}
+}
+
+func BenchmarkHashMap_Iterate(b *testing.B) {
+	for _, size := range hashMapBenchmarkSizes {
+		keys := hashMapBenchmarkKeys(size)
+
+		open := NewHashMap[*objects.Int, *objects.Int]()
+		chained := newChainedHashMap[*objects.Int, *objects.Int]()
+		for _, key := range keys {
+			_ = open.Put(key, key)
+			_ = chained.Put(key, key)
+		}
+
+		b.Run(fmt.Sprintf("open/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				for range open.Entries() {
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("chained/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				chained.Iterate(func(MapEntry[*objects.Int, *objects.Int]) {})
+			}
+		})
+	}
+}
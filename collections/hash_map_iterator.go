@@ -2,33 +2,66 @@ package collections
 
 import "github.com/pasataleo/go-objects/objects"
 
+// hashMapIterator is an iterator for hashMap. It implements MutableIterator.
+//
+// Removing an entry shifts the rest of its run back by a slot, which can wrap around from the start of the table to
+// the end. So the iterator starts just after an empty slot instead of at the start of the table. No run crosses an
+// empty slot, so entries are only ever shifted back to a position the iterator is about to visit again.
 type hashMapIterator[K, V objects.Object] struct {
-	set *hashMap[K, V]
+	m *hashMap[K, V]
 
-	keys []uint64
-	keyI int
+	// start is the index of the slot the iterator starts from.
+	start int
 
-	valueI int
+	// position is the number of slots, counting from start, that the iterator has already checked.
+	position int
+
+	// last is the position of the entry most recently returned by Next, or -1 if there isn't one.
+	last int
+
+	// modCount is the modification count of the map when the iterator was created, or last changed the map.
+	modCount int
 }
 
 // HasNext implements objects.Iterator.
 func (iterator *hashMapIterator[K, V]) HasNext() bool {
-	return iterator.keyI < len(iterator.keys)
+	for iterator.position < len(iterator.m.slots) && iterator.m.slots[iterator.slot(iterator.position)].distance == 0 {
+		iterator.position = iterator.position + 1
+	}
+	return iterator.position < len(iterator.m.slots)
 }
 
 // Next implements objects.Iterator.
 func (iterator *hashMapIterator[K, V]) Next() MapEntry[K, V] {
-	if iterator.keyI < 0 || iterator.keyI >= len(iterator.keys) {
+	checkForComodification(iterator.modCount, iterator.m.modCount)
+	if !iterator.HasNext() {
 		panic("out of bounds")
 	}
 
-	currentSlice := iterator.set.values[iterator.keys[iterator.keyI]]
+	slot := &iterator.m.slots[iterator.slot(iterator.position)]
+	iterator.last = iterator.position
+	iterator.position = iterator.position + 1
+	return &mapEntry[K, V]{
+		Key:   slot.key,
+		Value: slot.value,
+	}
+}
 
-	value := currentSlice[iterator.valueI]
-	iterator.valueI = iterator.valueI + 1
-	if iterator.valueI >= len(currentSlice) {
-		iterator.keyI = iterator.keyI + 1
-		iterator.valueI = 0
+// Remove implements MutableIterator.
+func (iterator *hashMapIterator[K, V]) Remove() {
+	if iterator.last < 0 {
+		noCurrentElement()
 	}
-	return value
+	checkForComodification(iterator.modCount, iterator.m.modCount)
+
+	iterator.m.deleteAt(iterator.slot(iterator.last))
+	// The next entry in the run, if any, has moved back into the removed slot.
+	iterator.position = iterator.last
+	iterator.last = -1
+	iterator.modCount = iterator.m.modCount
+}
+
+// slot converts a position into an index into the slots of the map.
+func (iterator *hashMapIterator[K, V]) slot(position int) int {
+	return (iterator.start + position) & (len(iterator.m.slots) - 1)
 }
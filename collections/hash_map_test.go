@@ -1,9 +1,11 @@
 package collections
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
 )
 
 func TestHashMap_Collection(t *testing.T) {
@@ -23,6 +25,36 @@ func TestHashMap_Collection(t *testing.T) {
 	})
 }
 
+func TestHashMap_FailFast(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runFailFastTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewHashMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestHashMap_MapFailFast(t *testing.T) {
+	runMapFailFastTests(t, func() Map[*objects.String, *objects.String] {
+		return NewHashMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
 func TestHashMap_Map(t *testing.T) {
 	runMapTests(t, func() Map[*objects.String, *objects.String] {
 		return NewHashMap[*objects.String, *objects.String]()
@@ -35,3 +67,131 @@ func TestHashMap_Map(t *testing.T) {
 		"five":  objects.WrapString("five"),
 	})
 }
+
+func TestHashMap_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	// Check the map against a Go map that receives the same operations. The
+	// small key range means there are plenty of deletes and re-inserts to
+	// exercise the backward shifting.
+	expected := make(map[int]int)
+	m := NewHashMap[*objects.Int, *objects.Int]()
+	for ix := 0; ix < 20000; ix++ {
+		key := random.Intn(2000)
+		if random.Intn(2) == 0 {
+			_, existed := expected[key]
+			delete(expected, key)
+			_, deleted := m.DeleteIfPresent(objects.WrapInt(key))
+			tests.Execute(deleted).Equal(t, existed)
+		} else {
+			expected[key] = ix
+			_, _ = m.PutOrReplace(objects.WrapInt(key), objects.WrapInt(ix))
+		}
+	}
+
+	tests.Execute(m.Size()).Equal(t, len(expected))
+	for key, value := range expected {
+		tests.Execute2E(m.GetSafe(objects.WrapInt(key))).NoError(t).Equal(t, objects.WrapInt(value))
+	}
+	count := 0
+	for key, value := range m.Entries() {
+		tests.Execute(value.Unwrap()).Equal(t, expected[key.Unwrap()])
+		count++
+	}
+	tests.Execute(count).Equal(t, len(expected))
+}
+
+func TestHashMap_RandomCompute(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	// As TestHashMap_Random, but through Merge and Compute, which insert at the
+	// slot found by their own lookup rather than probing again.
+	expected := make(map[int]int)
+	m := NewHashMap[*objects.Int, *objects.Int]()
+	for ix := 0; ix < 20000; ix++ {
+		key := random.Intn(2000)
+		if random.Intn(2) == 0 {
+			_, existed := expected[key]
+			delete(expected, key)
+			_, present := m.Compute(objects.WrapInt(key), func(key, current *objects.Int, present bool) (*objects.Int, bool) {
+				tests.Execute(present).Equal(t, existed)
+				return nil, false
+			})
+			tests.Execute(present).Equal(t, false)
+		} else {
+			expected[key] = expected[key] + ix
+			_, _ = m.Merge(objects.WrapInt(key), objects.WrapInt(ix), func(current, value *objects.Int) (*objects.Int, bool) {
+				return objects.WrapInt(current.Unwrap() + value.Unwrap()), true
+			})
+		}
+	}
+
+	tests.Execute(m.Size()).Equal(t, len(expected))
+	for key, value := range expected {
+		tests.Execute(m.GetOrDefault(objects.WrapInt(key), objects.WrapInt(-1))).Equal(t, objects.WrapInt(value))
+	}
+}
+
+func TestHashMap_Options(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewHashMapWith[*objects.String, *objects.String](WithCapacity(2), WithLoadFactor(0.5))
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestHashMap_Capacity(t *testing.T) {
+	m := NewHashMapWith[*objects.Int, *objects.Int](WithCapacity(100))
+	slots := len(m.(*hashMap[*objects.Int, *objects.Int]).slots)
+	tests.Execute(slots).Equal(t, 128)
+
+	// Filling up to the requested capacity mustn't grow the table.
+	for ix := 0; ix < 100; ix++ {
+		tests.ExecuteE(m.Put(objects.WrapInt(ix), objects.WrapInt(ix))).NoError(t)
+	}
+	tests.Execute(len(m.(*hashMap[*objects.Int, *objects.Int]).slots)).Equal(t, slots)
+
+	for ix := 10; ix < 100; ix++ {
+		tests.Execute2E(m.Delete(objects.WrapInt(ix))).NoError(t)
+	}
+	m.(Resizable).TrimToSize()
+	tests.Execute(len(m.(*hashMap[*objects.Int, *objects.Int]).slots)).Equal(t, 16)
+	for ix := 0; ix < 10; ix++ {
+		tests.Execute(m.Get(objects.WrapInt(ix))).Equal(t, objects.WrapInt(ix))
+	}
+}
+
+func TestHashMap_IteratorRemove(t *testing.T) {
+	m := NewHashMap[*objects.Int, *objects.Int]()
+	for ix := 0; ix < 1000; ix++ {
+		tests.ExecuteE(m.Put(objects.WrapInt(ix), objects.WrapInt(ix))).NoError(t)
+	}
+
+	// Remove every odd key in a single pass, checking each entry is visited
+	// exactly once even as the runs shift back.
+	seen := make(map[int]bool)
+	iterator := m.Iterator().(MutableIterator[MapEntry[*objects.Int, *objects.Int]])
+	for iterator.HasNext() {
+		key := iterator.Next().GetKey().Unwrap()
+		tests.Execute(seen[key]).Equal(t, false)
+		seen[key] = true
+		if key%2 == 1 {
+			iterator.Remove()
+		}
+	}
+	tests.Execute(len(seen)).Equal(t, 1000)
+	tests.Execute(m.Size()).Equal(t, 500)
+	for ix := 0; ix < 1000; ix++ {
+		tests.Execute(m.ContainsKey(objects.WrapInt(ix))).Equal(t, ix%2 == 0)
+	}
+
+	// Nothing has been returned yet, so there's nothing to remove.
+	expectErrorCode(t, ErrorCodeIllegalState, func() {
+		m.Iterator().(MutableIterator[MapEntry[*objects.Int, *objects.Int]]).Remove()
+	})
+}
@@ -0,0 +1,248 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// hashMultiMap keeps the values for each key in their own collection, held in
+// a hash map. A key is removed along with its last value, so the collections
+// in the map are never empty.
+type hashMultiMap[K, V objects.Object] struct {
+	values *hashMap[K, Collection[V]]
+
+	// newValues creates the collection for a key when it gets its first value.
+	newValues func() Collection[V]
+
+	// size counts the values across every key, so ValueCount doesn't have to.
+	size int
+}
+
+// NewListMultiMap creates a new multimap that keeps the values for each key in an array list, with the given
+// elements. A key can be associated with the same value more than once, and its values are kept in insertion order.
+func NewListMultiMap[K, V objects.Object](entries ...MapEntry[K, V]) MultiMap[K, V] {
+	return newHashMultiMap[K, V](func() Collection[V] {
+		return NewArrayList[V]()
+	}, entries...)
+}
+
+// NewSetMultiMap creates a new multimap that keeps the values for each key in a hash set, with the given elements.
+// Putting a value that is already associated with a key returns an ErrorCodeAlreadyExists error.
+func NewSetMultiMap[K, V objects.Object](entries ...MapEntry[K, V]) MultiMap[K, V] {
+	return newHashMultiMap[K, V](func() Collection[V] {
+		return NewHashSet[V]()
+	}, entries...)
+}
+
+func newHashMultiMap[K, V objects.Object](newValues func() Collection[V], entries ...MapEntry[K, V]) *hashMultiMap[K, V] {
+	m := &hashMultiMap[K, V]{
+		values:    NewHashMap[K, Collection[V]]().(*hashMap[K, Collection[V]]),
+		newValues: newValues,
+	}
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object. Multimaps are equal if they hold the same keys, and the values for each key are
+// equal. List values are only equal to list values, and set values to set values.
+func (m *hashMultiMap[K, V]) Equals(other any) bool {
+	if other, ok := other.(*hashMultiMap[K, V]); ok {
+		return m.values.Equals(other.values)
+	}
+	return false
+}
+
+// HashCode implements objects.Object.
+func (m *hashMultiMap[K, V]) HashCode() uint64 {
+	return m.values.HashCode()
+}
+
+// String implements objects.Object.
+func (m *hashMultiMap[K, V]) String() string {
+	return m.values.String()
+}
+
+// MarshalJSON implements objects.Object. The multimap is written as a list of objects, each holding a key and its
+// values.
+func (m *hashMultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	entries := make([]hashMultiMapEntry[K, V], 0, m.values.Size())
+	for key, values := range m.values.Entries() {
+		entries = append(entries, hashMultiMapEntry[K, V]{
+			Key:    key,
+			Values: objects.SliceFrom[V](values),
+		})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *hashMultiMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []hashMultiMapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		for _, value := range entry.Values {
+			if err := m.Put(entry.Key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MultiMap implementation
+
+// Entries implements MultiMap.
+func (m *hashMultiMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.values.Entries() {
+			for value := range values.Elems() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ContainsKey implements MultiMap.
+func (m *hashMultiMap[K, V]) ContainsKey(key K) bool {
+	return m.values.ContainsKey(key)
+}
+
+// ContainsEntry implements MultiMap.
+func (m *hashMultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	values := m.values.GetOrDefault(key, nil)
+	return values != nil && values.Contains(value)
+}
+
+// Put implements MultiMap.
+func (m *hashMultiMap[K, V]) Put(key K, value V) error {
+	values := m.values.ComputeIfAbsent(key, m.create)
+	if err := values.Add(value); err != nil {
+		return err
+	}
+	m.size = m.size + 1
+	return nil
+}
+
+// PutAll implements MultiMap.
+func (m *hashMultiMap[K, V]) PutAll(key K, values Collection[V]) error {
+	if values.IsEmpty() {
+		return nil
+	}
+
+	current := m.values.ComputeIfAbsent(key, m.create)
+	size := current.Size()
+	err := current.AddAll(values)
+	m.size = m.size + current.Size() - size
+	return err
+}
+
+// Get implements MultiMap.
+func (m *hashMultiMap[K, V]) Get(key K) Collection[V] {
+	return &multiMapValues[K, V]{
+		m:   m,
+		key: key,
+	}
+}
+
+// RemoveValue implements MultiMap.
+func (m *hashMultiMap[K, V]) RemoveValue(key K, value V) error {
+	values, err := m.values.GetSafe(key)
+	if err != nil {
+		return err
+	}
+
+	if err := values.Remove(value); err != nil {
+		return errors.Embed(err, "key", key)
+	}
+	m.size = m.size - 1
+	if values.IsEmpty() {
+		_, _ = m.values.DeleteIfPresent(key)
+	}
+	return nil
+}
+
+// RemoveKey implements MultiMap.
+func (m *hashMultiMap[K, V]) RemoveKey(key K) (Collection[V], error) {
+	values, err := m.values.Delete(key)
+	if err != nil {
+		return nil, err
+	}
+
+	m.size = m.size - values.Size()
+	return values, nil
+}
+
+// Keys implements MultiMap.
+func (m *hashMultiMap[K, V]) Keys() Collection[K] {
+	return m.values.Keys()
+}
+
+// KeyCount implements MultiMap.
+func (m *hashMultiMap[K, V]) KeyCount() int {
+	return m.values.Size()
+}
+
+// ValueCount implements MultiMap.
+func (m *hashMultiMap[K, V]) ValueCount() int {
+	return m.size
+}
+
+// IsEmpty implements MultiMap.
+func (m *hashMultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear implements MultiMap.
+func (m *hashMultiMap[K, V]) Clear() {
+	m.values.Clear()
+	m.size = 0
+}
+
+// AsMap implements MultiMap.
+func (m *hashMultiMap[K, V]) AsMap() Map[K, Collection[V]] {
+	copied := NewHashMapWith[K, Collection[V]](WithCapacity(m.values.Size()))
+	for key, values := range m.values.Entries() {
+		_ = copied.Put(key, values.Copy())
+	}
+	return copied
+}
+
+// hash multimap
+
+// create returns a new, empty, collection for the values of the given key.
+func (m *hashMultiMap[K, V]) create(key K) Collection[V] {
+	return m.newValues()
+}
+
+// removeIf removes the values of the given key that match the condition, and the key itself if no values are left.
+func (m *hashMultiMap[K, V]) removeIf(key K, condition func(value V) bool) int {
+	values, err := m.values.GetSafe(key)
+	if err != nil {
+		return 0
+	}
+
+	removed := values.RemoveIf(condition)
+	m.size = m.size - removed
+	if values.IsEmpty() {
+		_, _ = m.values.DeleteIfPresent(key)
+	}
+	return removed
+}
+
+type hashMultiMapEntry[K, V objects.Object] struct {
+	Key    K   `json:"key"`
+	Values []V `json:"values"`
+}
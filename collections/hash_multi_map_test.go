@@ -0,0 +1,163 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestListMultiMap_Values(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewListMultiMap[*objects.String, *objects.String]().Get(objects.WrapString("key"))
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestSetMultiMap_Values(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewSetMultiMap[*objects.String, *objects.String]().Get(objects.WrapString("key"))
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestListMultiMap(t *testing.T) {
+	m := NewListMultiMap[*objects.String, *objects.Int]()
+	a, b := objects.WrapString("a"), objects.WrapString("b")
+
+	tests.ExecuteE(m.Put(a, objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(a, objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(m.Put(a, objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(b, objects.WrapInt(3))).NoError(t)
+
+	tests.Execute(m.KeyCount()).Equal(t, 2)
+	tests.Execute(m.ValueCount()).Equal(t, 4)
+	tests.Execute(m.Get(a).String()).Equal(t, "[1,2,1]")
+	tests.Execute(m.ContainsEntry(a, objects.WrapInt(2))).Equal(t, true)
+	tests.Execute(m.ContainsEntry(b, objects.WrapInt(2))).Equal(t, false)
+
+	// Removing the last value of a key removes the key.
+	tests.ExecuteE(m.RemoveValue(b, objects.WrapInt(3))).NoError(t)
+	tests.ExecuteE(m.RemoveValue(b, objects.WrapInt(3))).ErrorCode(t, ErrorCodeNotFound)
+	tests.ExecuteE(m.RemoveValue(a, objects.WrapInt(3))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute(m.ContainsKey(b)).Equal(t, false)
+	tests.Execute(m.KeyCount()).Equal(t, 1)
+	tests.Execute(m.ValueCount()).Equal(t, 3)
+
+	removed, err := m.RemoveKey(a)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(removed.String()).Equal(t, "[1,2,1]")
+	tests.Execute(m.IsEmpty()).Equal(t, true)
+	tests.Execute2E(m.RemoveKey(a)).ErrorCode(t, ErrorCodeNotFound)
+}
+
+func TestSetMultiMap(t *testing.T) {
+	m := NewSetMultiMap[*objects.String, *objects.Int]()
+	a := objects.WrapString("a")
+
+	tests.ExecuteE(m.Put(a, objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(a, objects.WrapInt(1))).ErrorCode(t, ErrorCodeAlreadyExists)
+	tests.ExecuteE(m.PutAll(a, NewArrayList[*objects.Int](ints(1, 2, 3)...))).ErrorCode(t, ErrorCodeAlreadyExists)
+
+	tests.Execute(m.ValueCount()).Equal(t, 3)
+	tests.Execute(m.Get(a).Copy().Equals(hashSetOf(1, 2, 3))).Equal(t, true)
+}
+
+func TestListMultiMap_View(t *testing.T) {
+	m := NewListMultiMap[*objects.String, *objects.Int]()
+	a := objects.WrapString("a")
+
+	// The view works before the key exists, and writes through to the map.
+	view := m.Get(a)
+	tests.Execute(view.IsEmpty()).Equal(t, true)
+	tests.Execute(m.ContainsKey(a)).Equal(t, false)
+	tests.ExecuteE(view.Add(objects.WrapInt(1))).NoError(t)
+	tests.Execute(m.ContainsKey(a)).Equal(t, true)
+	tests.Execute(m.ValueCount()).Equal(t, 1)
+
+	// Changes to the map are visible through the view.
+	tests.ExecuteE(m.Put(a, objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(m.Put(a, objects.WrapInt(3))).NoError(t)
+	tests.Execute(view.Size()).Equal(t, 3)
+
+	tests.Execute(view.RemoveIf(func(value *objects.Int) bool {
+		return value.Unwrap() > 1
+	})).Equal(t, 2)
+	tests.Execute(m.ValueCount()).Equal(t, 1)
+
+	view.Clear()
+	tests.Execute(m.IsEmpty()).Equal(t, true)
+	tests.Execute(m.KeyCount()).Equal(t, 0)
+
+	tests.ExecuteE(m.Put(a, objects.WrapInt(4))).NoError(t)
+	tests.Execute(view.String()).Equal(t, "[4]")
+}
+
+func TestMultiMap_ViewEquals(t *testing.T) {
+	for name, m := range map[string]MultiMap[*objects.String, *objects.Int]{
+		"list": NewListMultiMap[*objects.String, *objects.Int](),
+		"set":  NewSetMultiMap[*objects.String, *objects.Int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			a, b := objects.WrapString("a"), objects.WrapString("b")
+			tests.ExecuteE(m.PutAll(a, NewArrayList[*objects.Int](ints(1, 2)...))).NoError(t)
+			tests.ExecuteE(m.PutAll(b, NewArrayList[*objects.Int](ints(1, 2)...))).NoError(t)
+
+			// Equality with the collections holding the same values is
+			// symmetric, so neither side accepts the other.
+			values := m.Get(a).Copy()
+			tests.Execute(values.Equals(m.Get(a))).Equal(t, false)
+			tests.Execute(m.Get(a).Equals(values)).Equal(t, false)
+
+			// Views are equal to views with equal values, and hash the same.
+			tests.Execute(m.Get(a).Equals(m.Get(b))).Equal(t, true)
+			tests.Execute(m.Get(b).Equals(m.Get(a))).Equal(t, true)
+			tests.Execute(m.Get(a).HashCode()).Equal(t, m.Get(b).HashCode())
+
+			tests.ExecuteE(m.Put(b, objects.WrapInt(3))).NoError(t)
+			tests.Execute(m.Get(a).Equals(m.Get(b))).Equal(t, false)
+			tests.Execute(m.Get(b).Equals(m.Get(a))).Equal(t, false)
+		})
+	}
+}
+
+func TestListMultiMap_AsMap(t *testing.T) {
+	m := NewListMultiMap[*objects.String, *objects.Int](
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("a"), Value: objects.WrapInt(1)},
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("a"), Value: objects.WrapInt(2)})
+
+	asMap := m.AsMap()
+	tests.Execute(asMap.Size()).Equal(t, 1)
+	tests.Execute(asMap.Get(objects.WrapString("a")).String()).Equal(t, "[1,2]")
+
+	// The map is a copy, so changing it leaves the multimap alone.
+	tests.ExecuteE(asMap.Get(objects.WrapString("a")).Add(objects.WrapInt(3))).NoError(t)
+	tests.Execute(m.ValueCount()).Equal(t, 2)
+}
+
+func TestListMultiMap_JSON(t *testing.T) {
+	m := NewListMultiMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(2))).NoError(t)
+
+	data, err := json.Marshal(m)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(string(data)).Equal(t, `[{"key":"a","values":[1,2]}]`)
+
+	decoded := NewListMultiMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, decoded)).NoError(t)
+	tests.Execute(decoded.Equals(m)).Equal(t, true)
+	tests.Execute(decoded.ValueCount()).Equal(t, 2)
+
+	// List and set multimaps with the same entries aren't equal.
+	tests.Execute(decoded.Equals(NewSetMultiMap[*objects.String, *objects.Int](
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("a"), Value: objects.WrapInt(1)},
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("a"), Value: objects.WrapInt(2)}))).Equal(t, false)
+}
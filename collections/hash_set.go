@@ -13,6 +13,9 @@ import (
 type hashSet[O objects.Object] struct {
 	values map[uint64][]O
 	size   int
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
 }
 
 // NewHashSet creates a new hash set.
@@ -23,6 +26,16 @@ func NewHashSet[O objects.Object]() Set[O] {
 	}
 }
 
+// NewHashSetWith creates a new hash set, with its backing storage configured by the given options. WithLoadFactor is
+// ignored, as the set's buckets are managed by a Go map.
+func NewHashSetWith[O objects.Object](opts ...Option) Set[O] {
+	options := newOptions(opts)
+	return &hashSet[O]{
+		values: make(map[uint64][]O, options.capacity),
+		size:   0,
+	}
+}
+
 // Object implementation
 
 // Equals implements objects.Object.
@@ -97,10 +110,13 @@ func (set *hashSet[O]) UnmarshalJSON(bytes []byte) error {
 
 // Iterable implementation
 
-// Iterator implements objects.Iterable.
+// Iterator implements objects.Iterable. The iterator implements MutableIterator, so elements can be removed while
+// iterating.
 func (set *hashSet[O]) Iterator() objects.Iterator[O] {
 	return &hashSetIterator[O]{
-		set: set,
+		set:      set,
+		lastKeyI: -1,
+		modCount: set.modCount,
 		keys: func() []uint64 {
 			var keys []uint64
 			for key := range set.values {
@@ -115,13 +131,7 @@ func (set *hashSet[O]) Iterator() objects.Iterator[O] {
 
 // Elems implements Collection.
 func (set *hashSet[O]) Elems() iter.Seq[O] {
-	return func(yield func(O) bool) {
-		for _, values := range set.values {
-			for _, value := range values {
-				yield(value)
-			}
-		}
-	}
+	return objects.SequenceFrom[O](set)
 }
 
 // Contains implements Collection.
@@ -140,6 +150,41 @@ func (set *hashSet[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](set, values)
 }
 
+// RemoveIf implements Collection. Each bucket is filtered in place.
+func (set *hashSet[O]) RemoveIf(condition func(value O) bool) int {
+	removed := 0
+	for hash, values := range set.values {
+		kept := values[:0]
+		for _, value := range values {
+			if !condition(value) {
+				kept = append(kept, value)
+			}
+		}
+		if len(kept) == len(values) {
+			continue
+		}
+
+		removed = removed + len(values) - len(kept)
+		if len(kept) == 0 {
+			delete(set.values, hash)
+		} else {
+			clear(values[len(kept):])
+			set.values[hash] = kept
+		}
+	}
+
+	if removed > 0 {
+		set.size = set.size - removed
+		set.modCount = set.modCount + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (set *hashSet[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](set, values)
+}
+
 // Add implements Collection.
 func (set *hashSet[O]) Add(value O) error {
 	hash := value.HashCode()
@@ -152,6 +197,7 @@ func (set *hashSet[O]) Add(value O) error {
 	values = append(values, value)
 	set.values[hash] = values
 	set.size = set.size + 1
+	set.modCount = set.modCount + 1
 	return nil
 }
 
@@ -166,8 +212,7 @@ func (set *hashSet[O]) Remove(value O) error {
 	values := set.values[hash]
 	for ix, contained := range values {
 		if value.Equals(contained) {
-			set.values[hash] = append(values[:ix], values[ix+1:]...)
-			set.size = set.size - 1
+			set.removeAt(hash, ix)
 			return nil
 		}
 	}
@@ -202,4 +247,56 @@ func (set *hashSet[O]) IsEmpty() bool {
 func (set *hashSet[O]) Clear() {
 	set.values = make(map[uint64][]O)
 	set.size = 0
+	set.modCount = set.modCount + 1
+}
+
+// Set implementation
+
+// Union implements Set.
+func (set *hashSet[O]) Union(other Set[O]) Set[O] {
+	return Union[O](set, other)
+}
+
+// Intersection implements Set.
+func (set *hashSet[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](set, other)
+}
+
+// Difference implements Set.
+func (set *hashSet[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](set, other)
+}
+
+// SymmetricDifference implements Set.
+func (set *hashSet[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](set, other)
+}
+
+// IsSubsetOf implements Set.
+func (set *hashSet[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](set, other)
+}
+
+// IsSupersetOf implements Set.
+func (set *hashSet[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](set, other)
+}
+
+// IsDisjoint implements Set.
+func (set *hashSet[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](set, other)
+}
+
+// hash set
+
+// removeAt removes the element at the given index of the bucket for the given hash.
+func (set *hashSet[O]) removeAt(hash uint64, ix int) {
+	values := set.values[hash]
+	if len(values) == 1 {
+		delete(set.values, hash)
+	} else {
+		set.values[hash] = append(values[:ix], values[ix+1:]...)
+	}
+	set.size = set.size - 1
+	set.modCount = set.modCount + 1
 }
@@ -2,7 +2,7 @@ package collections
 
 import "github.com/pasataleo/go-objects/objects"
 
-// hashSetIterator is an iterator for hashSet.
+// hashSetIterator is an iterator for hashSet. It implements MutableIterator.
 type hashSetIterator[O objects.Object] struct {
 	set *hashSet[O]
 
@@ -10,6 +10,13 @@ type hashSetIterator[O objects.Object] struct {
 	keyI int
 
 	valueI int
+
+	// lastKeyI and lastValueI locate the element most recently returned by Next. lastKeyI is -1 if there isn't one.
+	lastKeyI   int
+	lastValueI int
+
+	// modCount is the modification count of the set when the iterator was created, or last changed the set.
+	modCount int
 }
 
 // HasNext implements objects.Iterator.
@@ -19,6 +26,7 @@ func (iterator *hashSetIterator[O]) HasNext() bool {
 
 // Next implements objects.Iterator.
 func (iterator *hashSetIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.set.modCount)
 	if iterator.keyI < 0 || iterator.keyI >= len(iterator.keys) {
 		panic("out of bounds")
 	}
@@ -26,6 +34,8 @@ func (iterator *hashSetIterator[O]) Next() O {
 	currentSlice := iterator.set.values[iterator.keys[iterator.keyI]]
 
 	value := currentSlice[iterator.valueI]
+	iterator.lastKeyI = iterator.keyI
+	iterator.lastValueI = iterator.valueI
 	iterator.valueI = iterator.valueI + 1
 	if iterator.valueI >= len(currentSlice) {
 		iterator.keyI = iterator.keyI + 1
@@ -33,3 +43,19 @@ func (iterator *hashSetIterator[O]) Next() O {
 	}
 	return value
 }
+
+// Remove implements MutableIterator.
+func (iterator *hashSetIterator[O]) Remove() {
+	if iterator.lastKeyI < 0 {
+		noCurrentElement()
+	}
+	checkForComodification(iterator.modCount, iterator.set.modCount)
+
+	iterator.set.removeAt(iterator.keys[iterator.lastKeyI], iterator.lastValueI)
+	if iterator.keyI == iterator.lastKeyI {
+		// The rest of the bucket has moved down to fill the gap.
+		iterator.valueI = iterator.lastValueI
+	}
+	iterator.lastKeyI = -1
+	iterator.modCount = iterator.set.modCount
+}
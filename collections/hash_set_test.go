@@ -1,9 +1,11 @@
 package collections
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
 )
 
 func TestHashSet_Collection(t *testing.T) {
@@ -15,3 +17,39 @@ func TestHashSet_Collection(t *testing.T) {
 		"three": objects.WrapString("three"),
 	})
 }
+
+func TestHashSet_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewHashSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestHashSet_IteratorRemove(t *testing.T) {
+	set := NewHashSet[collidingString]()
+	for ix := 0; ix < 100; ix++ {
+		// The hash is the length of the string, so there are only two buckets.
+		tests.ExecuteE(set.Add(collidingString{value: fmt.Sprintf("%d", ix)})).NoError(t)
+	}
+
+	seen := make(map[string]bool)
+	iterator := set.Iterator().(MutableIterator[collidingString])
+	for iterator.HasNext() {
+		value := iterator.Next().value
+		tests.Execute(seen[value]).Equal(t, false)
+		seen[value] = true
+		if len(value)%2 == 0 {
+			iterator.Remove()
+		}
+	}
+	tests.Execute(len(seen)).Equal(t, 100)
+	tests.Execute(set.Size()).Equal(t, 10)
+
+	// Nothing has been returned yet, so there's nothing to remove.
+	expectErrorCode(t, ErrorCodeIllegalState, func() {
+		set.Iterator().(MutableIterator[collidingString]).Remove()
+	})
+}
@@ -0,0 +1,626 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// ImmutableList is a list that can't be changed once created. The methods that would change the list instead return
+// a new list, which shares most of its structure with the original, so they take O(log n) time and space.
+//
+// Immutable lists are safe to share between goroutines, and to use as keys in maps and sets.
+type ImmutableList[O objects.Object] interface {
+	objects.Object
+	objects.Iterable[O]
+
+	// Elems returns a sequence of all elements in the list.
+	Elems() iter.Seq[O]
+
+	// Size returns the number of elements in the list.
+	Size() int
+
+	// IsEmpty returns true if the list is empty.
+	IsEmpty() bool
+
+	// Contains returns true if the list contains the given value.
+	Contains(value O) bool
+
+	// IndexOf returns the index of the first occurrence of the given value in the list, or -1 if the value isn't in
+	// the list.
+	IndexOf(value O) int
+
+	// Get returns the value at the given index.
+	Get(ix int) (O, error)
+
+	// With returns a new list with the given value added to the end.
+	With(value O) ImmutableList[O]
+
+	// Set returns a new list with the value at the given index replaced by the given value.
+	Set(ix int, value O) (ImmutableList[O], error)
+
+	// Without returns a new list with the value at the given index removed, and the values after it moved down by
+	// one.
+	Without(ix int) (ImmutableList[O], error)
+
+	// WithoutLast returns a new list with the last value removed.
+	WithoutLast() (ImmutableList[O], error)
+
+	// ToList returns a mutable copy of the list.
+	ToList() List[O]
+}
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// immutableList is a persistent vector: a trie with up to vectorWidth children
+// per node, holding the elements in its leaves in index order. The last leaf is
+// kept outside the trie in tail, so adding to the end of the list usually only
+// copies the tail.
+//
+// A trie built only by adding to the end is dense, and indexes are found from
+// their bits alone. Removing from the middle leaves a shorter leaf, so every
+// node on the path to it becomes relaxed and records the sizes of its children
+// to find indexes by, as in an RRB tree. Relaxed nodes keep removal to a single
+// path, rather than moving every later element down.
+//
+// Nodes and tails are never changed once they are reachable from a list, so
+// they can be shared freely between versions.
+type immutableList[O objects.Object] struct {
+	size  int
+	shift uint
+	root  *vectorNode[O]
+	tail  []O
+}
+
+type vectorNode[O objects.Object] struct {
+	children []*vectorNode[O]
+	values   []O
+
+	// sizes holds the number of elements under each child and the children
+	// before it, and is nil for dense nodes. The children of a dense node are
+	// full dense nodes, apart from the last which may be partial.
+	sizes []int
+}
+
+// NewImmutableList creates a new immutable list with the given elements.
+func NewImmutableList[O objects.Object](elems ...O) ImmutableList[O] {
+	list := newImmutableList[O]()
+	for _, elem := range elems {
+		list = list.with(elem)
+	}
+	return list
+}
+
+func newImmutableList[O objects.Object]() *immutableList[O] {
+	return &immutableList[O]{
+		shift: vectorBits,
+		root:  &vectorNode[O]{},
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (list *immutableList[O]) Equals(other any) bool {
+	otherList, ok := other.(ImmutableList[O])
+	if !ok {
+		return false
+	}
+
+	if list.Size() != otherList.Size() {
+		return false
+	}
+
+	iterator := otherList.Iterator()
+	for value := range list.Elems() {
+		if !value.Equals(iterator.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCode implements objects.Object.
+func (list *immutableList[O]) HashCode() uint64 {
+	hash := uint64(13001)
+	for value := range list.Elems() {
+		hash = hash*31 + value.HashCode()
+	}
+	return hash
+}
+
+// String implements objects.Object.
+func (list *immutableList[O]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+	first := true
+	for value := range list.Elems() {
+		if first {
+			buffer.WriteString(value.String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", value))
+		}
+		first = false
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (list *immutableList[O]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(objects.SliceFrom[O](list))
+}
+
+// UnmarshalJSON implements objects.Object. As it replaces the contents of the list in place, it should only be used
+// to decode into a new list.
+func (list *immutableList[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	*list = *NewImmutableList[O](values...).(*immutableList[O])
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (list *immutableList[O]) Iterator() objects.Iterator[O] {
+	return &immutableListIterator[O]{
+		list: list,
+	}
+}
+
+// ImmutableList implementation
+
+// Elems implements ImmutableList.
+func (list *immutableList[O]) Elems() iter.Seq[O] {
+	return func(yield func(O) bool) {
+		if !list.root.all(list.shift, yield) {
+			return
+		}
+		for _, value := range list.tail {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Size implements ImmutableList.
+func (list *immutableList[O]) Size() int {
+	return list.size
+}
+
+// IsEmpty implements ImmutableList.
+func (list *immutableList[O]) IsEmpty() bool {
+	return list.size == 0
+}
+
+// Contains implements ImmutableList.
+func (list *immutableList[O]) Contains(value O) bool {
+	return list.IndexOf(value) >= 0
+}
+
+// IndexOf implements ImmutableList.
+func (list *immutableList[O]) IndexOf(value O) int {
+	ix := 0
+	for contained := range list.Elems() {
+		if value.Equals(contained) {
+			return ix
+		}
+		ix++
+	}
+	return -1
+}
+
+// Get implements ImmutableList.
+func (list *immutableList[O]) Get(ix int) (O, error) {
+	if ix < 0 || ix >= list.size {
+		var null O
+		return null, errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
+	}
+	leaf, jx := list.leaf(ix)
+	return leaf[jx], nil
+}
+
+// With implements ImmutableList.
+func (list *immutableList[O]) With(value O) ImmutableList[O] {
+	return list.with(value)
+}
+
+// Set implements ImmutableList.
+func (list *immutableList[O]) Set(ix int, value O) (ImmutableList[O], error) {
+	if ix < 0 || ix >= list.size {
+		return nil, errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
+	}
+
+	newList := *list
+	if ix >= list.tailOffset() {
+		newList.tail = make([]O, len(list.tail))
+		copy(newList.tail, list.tail)
+		newList.tail[ix-list.tailOffset()] = value
+	} else {
+		newList.root = list.root.set(list.shift, ix, value)
+	}
+	return &newList, nil
+}
+
+// Without implements ImmutableList. Only the path to the index is copied, and its nodes become relaxed.
+func (list *immutableList[O]) Without(ix int) (ImmutableList[O], error) {
+	if ix < 0 || ix >= list.size {
+		return nil, errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
+	}
+	if ix == list.size-1 {
+		return list.withoutLast(), nil
+	}
+
+	newList := *list
+	newList.size = list.size - 1
+
+	if offset := list.tailOffset(); ix >= offset {
+		newList.tail = make([]O, 0, len(list.tail)-1)
+		newList.tail = append(newList.tail, list.tail[:ix-offset]...)
+		newList.tail = append(newList.tail, list.tail[ix-offset+1:]...)
+		return &newList, nil
+	}
+
+	newList.root = list.root.without(list.shift, ix)
+	newList.trim()
+	return &newList, nil
+}
+
+// WithoutLast implements ImmutableList.
+func (list *immutableList[O]) WithoutLast() (ImmutableList[O], error) {
+	if list.size == 0 {
+		return nil, errors.New(nil, ErrorCodeOutOfBounds, "out of bounds")
+	}
+	return list.withoutLast(), nil
+}
+
+// ToList implements ImmutableList.
+func (list *immutableList[O]) ToList() List[O] {
+	return NewArrayList[O](objects.SliceFrom[O](list)...)
+}
+
+// immutable list
+
+func (list *immutableList[O]) readOnly() {}
+
+// withoutLast returns a new list with the last value removed. The list must not be empty.
+func (list *immutableList[O]) withoutLast() *immutableList[O] {
+	if list.size == 1 {
+		return newImmutableList[O]()
+	}
+
+	newList := *list
+	newList.size = list.size - 1
+
+	if len(list.tail) > 1 {
+		// The tail is never written to once it belongs to a list, so it is
+		// safe to share a prefix of it.
+		newList.tail = list.tail[:len(list.tail)-1]
+		return &newList
+	}
+
+	// The tail is about to be empty, so the last leaf of the trie becomes the new tail.
+	newList.root, newList.tail = list.root.popTail(list.shift)
+	newList.trim()
+	return &newList
+}
+
+// trim replaces an empty root with a new one, and removes levels from the top of the trie while the root only has one
+// child.
+func (list *immutableList[O]) trim() {
+	if list.root == nil {
+		list.root = &vectorNode[O]{}
+		list.shift = vectorBits
+		return
+	}
+	for list.shift > vectorBits && len(list.root.children) == 1 {
+		list.root = list.root.children[0]
+		list.shift = list.shift - vectorBits
+	}
+}
+
+// tailOffset returns the index of the first element in the tail.
+func (list *immutableList[O]) tailOffset() int {
+	return list.size - len(list.tail)
+}
+
+// leaf returns the values of the leaf holding the element at the given index, and the index of the element within
+// them.
+func (list *immutableList[O]) leaf(ix int) ([]O, int) {
+	if offset := list.tailOffset(); ix >= offset {
+		return list.tail, ix - offset
+	}
+
+	node := list.root
+	for level := list.shift; level > 0; level -= vectorBits {
+		var child int
+		child, ix = node.child(level, ix)
+		node = node.children[child]
+	}
+	return node.values, ix
+}
+
+func (list *immutableList[O]) with(value O) *immutableList[O] {
+	newList := *list
+	newList.size = list.size + 1
+
+	if len(list.tail) < vectorWidth {
+		newList.tail = make([]O, len(list.tail)+1)
+		copy(newList.tail, list.tail)
+		newList.tail[len(list.tail)] = value
+		return &newList
+	}
+
+	// The tail is full, so push it into the trie and start a new one.
+	leaf := &vectorNode[O]{values: list.tail}
+	newList.root = list.root.pushTail(list.shift, leaf)
+	if newList.root == nil {
+		// The trie is full, so it needs another level.
+		newList.root = &vectorNode[O]{
+			children: []*vectorNode[O]{list.root, vectorPath(list.shift, leaf)},
+		}
+		if list.root.sizes != nil {
+			size := list.root.size(list.shift)
+			newList.root.sizes = []int{size, size + len(leaf.values)}
+		}
+		newList.shift = list.shift + vectorBits
+	}
+	newList.tail = []O{value}
+	return &newList
+}
+
+// child returns the index of the child holding the element at the given index, and the index of the element within
+// that child.
+func (node *vectorNode[O]) child(level uint, ix int) (int, int) {
+	child := ix >> level
+	if node.sizes == nil {
+		return child, ix - child<<level
+	}
+
+	// No child holds more than a dense one, so the element is in this child
+	// or a later one.
+	for node.sizes[child] <= ix {
+		child++
+	}
+	if child > 0 {
+		ix = ix - node.sizes[child-1]
+	}
+	return child, ix
+}
+
+// size returns the number of elements under the node.
+func (node *vectorNode[O]) size(level uint) int {
+	if level == 0 {
+		return len(node.values)
+	}
+
+	last := len(node.children) - 1
+	if node.sizes != nil {
+		return node.sizes[last]
+	}
+	return last<<level + node.children[last].size(level-vectorBits)
+}
+
+// relaxedSizes returns the sizes of the node, working them out if it is dense.
+func (node *vectorNode[O]) relaxedSizes(level uint) []int {
+	if node.sizes != nil {
+		return node.sizes
+	}
+
+	sizes := make([]int, len(node.children))
+	for ix := range sizes {
+		sizes[ix] = (ix + 1) << level
+	}
+	last := len(sizes) - 1
+	sizes[last] = last<<level + node.children[last].size(level-vectorBits)
+	return sizes
+}
+
+// all yields the elements under the node in order, and returns false if yield asked to stop.
+func (node *vectorNode[O]) all(level uint, yield func(O) bool) bool {
+	if level == 0 {
+		for _, value := range node.values {
+			if !yield(value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, child := range node.children {
+		if !child.all(level-vectorBits, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// pushTail returns a copy of node with the given leaf added after its last leaf, or nil if the node is full.
+func (node *vectorNode[O]) pushTail(level uint, leaf *vectorNode[O]) *vectorNode[O] {
+	last := len(node.children) - 1
+
+	if level > vectorBits && last >= 0 {
+		if child := node.children[last].pushTail(level-vectorBits, leaf); child != nil {
+			newNode := &vectorNode[O]{
+				children: make([]*vectorNode[O], len(node.children)),
+			}
+			copy(newNode.children, node.children)
+			newNode.children[last] = child
+			if node.sizes != nil {
+				newNode.sizes = make([]int, len(node.sizes))
+				copy(newNode.sizes, node.sizes)
+				newNode.sizes[last] = node.sizes[last] + len(leaf.values)
+			}
+			return newNode
+		}
+	}
+
+	if len(node.children) == vectorWidth {
+		return nil
+	}
+
+	newNode := &vectorNode[O]{
+		children: make([]*vectorNode[O], len(node.children)+1),
+	}
+	copy(newNode.children, node.children)
+	newNode.children[last+1] = vectorPath(level-vectorBits, leaf)
+	if node.sizes != nil {
+		newNode.sizes = make([]int, len(node.sizes)+1)
+		copy(newNode.sizes, node.sizes)
+		newNode.sizes[last+1] = node.sizes[last] + len(leaf.values)
+	}
+	return newNode
+}
+
+// popTail returns a copy of node without its last leaf, or nil if that leaves it empty, and the values of that leaf.
+func (node *vectorNode[O]) popTail(level uint) (*vectorNode[O], []O) {
+	last := len(node.children) - 1
+
+	child, values := (*vectorNode[O])(nil), node.children[last].values
+	if level > vectorBits {
+		child, values = node.children[last].popTail(level - vectorBits)
+	}
+
+	if child == nil {
+		if last == 0 {
+			return nil, values
+		}
+
+		newNode := &vectorNode[O]{
+			children: node.children[:last:last],
+		}
+		if node.sizes != nil {
+			newNode.sizes = node.sizes[:last:last]
+		}
+		return newNode, values
+	}
+
+	newNode := &vectorNode[O]{
+		children: make([]*vectorNode[O], len(node.children)),
+	}
+	copy(newNode.children, node.children)
+	newNode.children[last] = child
+	if node.sizes != nil {
+		newNode.sizes = make([]int, len(node.sizes))
+		copy(newNode.sizes, node.sizes)
+		newNode.sizes[last] = node.sizes[last] - len(values)
+	}
+	return newNode, values
+}
+
+// without returns a relaxed copy of node with the element at the given index removed, or nil if that leaves it empty.
+func (node *vectorNode[O]) without(level uint, ix int) *vectorNode[O] {
+	if level == 0 {
+		if len(node.values) == 1 {
+			return nil
+		}
+
+		newNode := &vectorNode[O]{
+			values: make([]O, 0, len(node.values)-1),
+		}
+		newNode.values = append(newNode.values, node.values[:ix]...)
+		newNode.values = append(newNode.values, node.values[ix+1:]...)
+		return newNode
+	}
+
+	ix, jx := node.child(level, ix)
+	child := node.children[ix].without(level-vectorBits, jx)
+	sizes := node.relaxedSizes(level)
+
+	newNode := &vectorNode[O]{}
+	if child == nil {
+		if len(node.children) == 1 {
+			return nil
+		}
+		newNode.children = make([]*vectorNode[O], 0, len(node.children)-1)
+		newNode.children = append(newNode.children, node.children[:ix]...)
+		newNode.children = append(newNode.children, node.children[ix+1:]...)
+		newNode.sizes = make([]int, 0, len(sizes)-1)
+		newNode.sizes = append(newNode.sizes, sizes[:ix]...)
+		newNode.sizes = append(newNode.sizes, sizes[ix+1:]...)
+	} else {
+		newNode.children = make([]*vectorNode[O], len(node.children))
+		copy(newNode.children, node.children)
+		newNode.children[ix] = child
+		newNode.sizes = make([]int, len(sizes))
+		copy(newNode.sizes, sizes)
+	}
+
+	// Every child from the one that lost the element onwards holds one less
+	// element before its end.
+	for kx := ix; kx < len(newNode.sizes); kx++ {
+		newNode.sizes[kx] = newNode.sizes[kx] - 1
+	}
+	return newNode
+}
+
+// set returns a copy of node with the element at the given index replaced.
+func (node *vectorNode[O]) set(level uint, ix int, value O) *vectorNode[O] {
+	if level == 0 {
+		newNode := &vectorNode[O]{
+			values: make([]O, len(node.values)),
+		}
+		copy(newNode.values, node.values)
+		newNode.values[ix] = value
+		return newNode
+	}
+
+	newNode := &vectorNode[O]{
+		children: make([]*vectorNode[O], len(node.children)),
+		sizes:    node.sizes,
+	}
+	copy(newNode.children, node.children)
+	child, ix := node.child(level, ix)
+	newNode.children[child] = node.children[child].set(level-vectorBits, ix, value)
+	return newNode
+}
+
+// vectorPath wraps the given node in parents until it reaches the given level.
+func vectorPath[O objects.Object](level uint, node *vectorNode[O]) *vectorNode[O] {
+	if level == 0 {
+		return node
+	}
+	return &vectorNode[O]{
+		children: []*vectorNode[O]{vectorPath(level-vectorBits, node)},
+	}
+}
+
+// immutableListIterator walks an immutable list a leaf at a time.
+type immutableListIterator[O objects.Object] struct {
+	list *immutableList[O]
+	ix   int
+	leaf []O
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *immutableListIterator[O]) HasNext() bool {
+	return iterator.ix < iterator.list.size
+}
+
+// Next implements objects.Iterator.
+func (iterator *immutableListIterator[O]) Next() O {
+	if iterator.ix >= iterator.list.size {
+		panic("out of bounds")
+	}
+	if len(iterator.leaf) == 0 {
+		leaf, jx := iterator.list.leaf(iterator.ix)
+		iterator.leaf = leaf[jx:]
+	}
+	value := iterator.leaf[0]
+	iterator.leaf = iterator.leaf[1:]
+	iterator.ix++
+	return value
+}
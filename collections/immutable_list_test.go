@@ -0,0 +1,211 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestImmutableList(t *testing.T) {
+	// Enough elements for the trie to need three levels.
+	const size = 2000
+
+	list := NewImmutableList[*objects.Int]()
+	versions := []ImmutableList[*objects.Int]{list}
+	for ix := 0; ix < size; ix++ {
+		list = list.With(objects.WrapInt(ix))
+		versions = append(versions, list)
+	}
+
+	tests.Execute(list.Size()).Equal(t, size)
+	for ix := 0; ix < size; ix++ {
+		tests.Execute2E(list.Get(ix)).NoError(t).Equal(t, objects.WrapInt(ix))
+	}
+	tests.Execute2E(list.Get(size)).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute2E(list.Get(-1)).ErrorCode(t, ErrorCodeOutOfBounds)
+
+	// Every earlier version is untouched.
+	for size, version := range versions {
+		tests.Execute(version.Size()).Equal(t, size)
+	}
+
+	ix := 0
+	for value := range list.Elems() {
+		tests.Execute(value).Equal(t, objects.WrapInt(ix))
+		ix++
+	}
+	tests.Execute(ix).Equal(t, size)
+	tests.Execute(NewImmutableList[*objects.Int](objects.SliceFrom[*objects.Int](list.ToList())...).Equals(list)).Equal(t, true)
+
+	// Popping back down must give lists equal to the versions on the way up.
+	for size := size; size > 0; size-- {
+		tests.Execute(list.Equals(versions[size])).Equal(t, true)
+		tests.Execute(list.HashCode()).Equal(t, versions[size].HashCode())
+
+		var err error
+		list, err = list.WithoutLast()
+		tests.ExecuteE(err).NoError(t)
+	}
+	tests.Execute(list.IsEmpty()).Equal(t, true)
+	tests.Execute2E(list.WithoutLast()).ErrorCode(t, ErrorCodeOutOfBounds)
+}
+
+func TestImmutableList_Set(t *testing.T) {
+	original := NewImmutableList[*objects.Int]()
+	for ix := 0; ix < 100; ix++ {
+		original = original.With(objects.WrapInt(ix))
+	}
+
+	updated, err := original.Set(10, objects.WrapInt(-10))
+	tests.ExecuteE(err).NoError(t)
+	updated, err = updated.Set(99, objects.WrapInt(-99))
+	tests.ExecuteE(err).NoError(t)
+
+	tests.Execute2E(updated.Get(10)).NoError(t).Equal(t, objects.WrapInt(-10))
+	tests.Execute2E(updated.Get(99)).NoError(t).Equal(t, objects.WrapInt(-99))
+	tests.Execute2E(original.Get(10)).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(original.Get(99)).NoError(t).Equal(t, objects.WrapInt(99))
+	tests.Execute(updated.IndexOf(objects.WrapInt(-99))).Equal(t, 99)
+	tests.Execute(original.Contains(objects.WrapInt(-99))).Equal(t, false)
+	tests.Execute(original.Equals(updated)).Equal(t, false)
+
+	tests.Execute2E(original.Set(100, objects.WrapInt(0))).ErrorCode(t, ErrorCodeOutOfBounds)
+}
+
+func TestImmutableList_Without(t *testing.T) {
+	// Enough elements for the trie to need three levels.
+	const size = 1100
+
+	list := NewImmutableList[*objects.Int]()
+	versions := []ImmutableList[*objects.Int]{list}
+	for ix := 0; ix < size; ix++ {
+		list = list.With(objects.WrapInt(ix))
+		versions = append(versions, list)
+	}
+
+	// Remove from several versions, at the start, leaf boundaries, the tail
+	// and the end, and check every other version is untouched.
+	for _, version := range []int{1, 32, 33, 100, 1024, 1025, size} {
+		for _, ix := range []int{0, 31, 32, version / 2, version - 1} {
+			if ix >= version {
+				continue
+			}
+
+			removed, err := versions[version].Without(ix)
+			tests.ExecuteE(err).NoError(t, tests.Fatal)
+			tests.Execute(removed.Size()).Equal(t, version-1)
+
+			expected := 0
+			for value := range removed.Elems() {
+				if expected == ix {
+					expected++
+				}
+				tests.Execute(value).Equal(t, objects.WrapInt(expected))
+				expected++
+			}
+
+			// Adding to the new version mustn't show through to the old one.
+			_ = removed.With(objects.WrapInt(-1))
+		}
+	}
+	for size, version := range versions {
+		tests.Execute(version.Size()).Equal(t, size)
+		for ix := 0; ix < size; ix += 7 {
+			tests.Execute2E(version.Get(ix)).NoError(t).Equal(t, objects.WrapInt(ix))
+		}
+	}
+
+	// Only the path to the removed value is copied.
+	removed, err := list.Without(0)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(removed.(*immutableList[*objects.Int]).root.children[1] == list.(*immutableList[*objects.Int]).root.children[1]).Equal(t, true)
+
+	// Removing the last value matches WithoutLast.
+	withoutLast, err := list.WithoutLast()
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute2E(list.Without(size-1)).NoError(t).Equal(t, withoutLast)
+
+	tests.Execute2E(list.Without(size)).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute2E(list.Without(-1)).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute2E(NewImmutableList[*objects.Int]().Without(0)).ErrorCode(t, ErrorCodeOutOfBounds)
+}
+
+func TestImmutableList_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	// Check every version of the list against a slice that receives the same operations.
+	var expected []*objects.Int
+	list := NewImmutableList[*objects.Int]()
+	versions := []ImmutableList[*objects.Int]{list}
+	snapshots := [][]*objects.Int{nil}
+	for ix := 0; ix < 20000; ix++ {
+		var err error
+		switch op := random.Intn(10); {
+		case op < 5 || len(expected) == 0:
+			expected = append(expected, objects.WrapInt(ix))
+			list = list.With(objects.WrapInt(ix))
+		case op < 8:
+			jx := random.Intn(len(expected))
+			expected = append(expected[:jx:jx], expected[jx+1:]...)
+			list, err = list.Without(jx)
+		case op < 9:
+			last := len(expected) - 1
+			expected = expected[:last:last]
+			list, err = list.WithoutLast()
+		default:
+			jx := random.Intn(len(expected))
+			expected = append(expected[:jx:jx], expected[jx:]...)
+			expected[jx] = objects.WrapInt(-ix)
+			list, err = list.Set(jx, objects.WrapInt(-ix))
+		}
+		tests.ExecuteE(err).NoError(t, tests.Fatal)
+
+		if ix%500 == 0 {
+			versions = append(versions, list)
+			snapshots = append(snapshots, expected)
+		}
+	}
+	versions = append(versions, list)
+	snapshots = append(snapshots, expected)
+
+	for ix, version := range versions {
+		tests.Execute(version.Size()).Equal(t, len(snapshots[ix]))
+		for jx, value := range snapshots[ix] {
+			tests.Execute2E(version.Get(jx)).NoError(t).Equal(t, value)
+		}
+
+		jx := 0
+		for value := range version.Elems() {
+			tests.Execute(value).Equal(t, snapshots[ix][jx])
+			jx++
+		}
+		tests.Execute(jx).Equal(t, len(snapshots[ix]))
+	}
+}
+
+func TestImmutableList_Keys(t *testing.T) {
+	m := NewHashMap[ImmutableList[*objects.String], *objects.Int]()
+
+	key := NewImmutableList[*objects.String](objects.WrapString("a"), objects.WrapString("b"))
+	tests.ExecuteE(m.Put(key, objects.WrapInt(1))).NoError(t)
+
+	// An equal list built separately finds the same entry.
+	lookup := NewImmutableList[*objects.String](objects.WrapString("a")).With(objects.WrapString("b"))
+	tests.Execute2E(m.GetSafe(lookup)).NoError(t).Equal(t, objects.WrapInt(1))
+}
+
+func TestImmutableList_JSON(t *testing.T) {
+	list := NewImmutableList[*objects.String](objects.WrapString("a"), objects.WrapString("b"))
+
+	data, err := json.Marshal(list)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(string(data)).Equal(t, `["a","b"]`)
+
+	decoded := NewImmutableList[*objects.String]()
+	tests.ExecuteE(json.Unmarshal(data, decoded)).NoError(t)
+	tests.Execute(decoded.Equals(list)).Equal(t, true)
+	tests.Execute(decoded.String()).Equal(t, "[a,b]")
+}
@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// ImmutableMap is a map that can't be changed once created. The methods that would change the map instead return a
+// new map, which shares most of its structure with the original, so they take O(log n) time and space.
+//
+// Immutable maps are safe to share between goroutines, and to use as keys in maps and sets.
+type ImmutableMap[K, V objects.Object] interface {
+	objects.Object
+	objects.Iterable[MapEntry[K, V]]
+
+	// Entries returns an iterator over the entries in the map.
+	Entries() iter.Seq2[K, V]
+
+	// Size returns the number of entries in the map.
+	Size() int
+
+	// IsEmpty returns true if the map is empty.
+	IsEmpty() bool
+
+	// ContainsKey returns true if the map contains the given key.
+	ContainsKey(key K) bool
+
+	// Get returns the value associated with the given key.
+	Get(key K) V
+
+	// GetSafe returns the value associated with the given key or an error if the key does not exist.
+	GetSafe(key K) (V, error)
+
+	// With returns a new map with the given key associated with the given value.
+	With(key K, value V) ImmutableMap[K, V]
+
+	// Without returns a new map without the given key.
+	Without(key K) ImmutableMap[K, V]
+
+	// Keys returns the keys in the map.
+	Keys() ImmutableSet[K]
+
+	// Values returns the values in the map.
+	Values() ImmutableList[V]
+
+	// ToMap returns a mutable copy of the map.
+	ToMap() Map[K, V]
+}
+
+type immutableMap[K, V objects.Object] struct {
+	trie hamt[K, V]
+}
+
+// NewImmutableMap creates a new immutable map with the given entries.
+func NewImmutableMap[K, V objects.Object](entries ...MapEntry[K, V]) ImmutableMap[K, V] {
+	m := &immutableMap[K, V]{}
+	for _, entry := range entries {
+		m.trie, _ = m.trie.with(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *immutableMap[K, V]) Equals(other any) bool {
+	otherMap, ok := other.(ImmutableMap[K, V])
+	if !ok {
+		return false
+	}
+
+	if m.Size() != otherMap.Size() {
+		return false
+	}
+
+	for key, value := range m.Entries() {
+		contained, err := otherMap.GetSafe(key)
+		if err != nil || !value.Equals(contained) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCode implements objects.Object.
+func (m *immutableMap[K, V]) HashCode() uint64 {
+	// Sum the entries, so the hash doesn't depend on the iteration order.
+	hash := uint64(13001)
+	for key, value := range m.Entries() {
+		hash = hash + (key.HashCode()*31 ^ value.HashCode())
+	}
+	return hash
+}
+
+// String implements objects.Object.
+func (m *immutableMap[K, V]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	first := true
+	for iterator := m.Iterator(); iterator.HasNext(); {
+		entry := iterator.Next()
+		if first {
+			buffer.WriteString(entry.String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", entry))
+		}
+		first = false
+	}
+	buffer.WriteString("}")
+	return buffer.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (m *immutableMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(objects.SliceFrom[MapEntry[K, V]](m))
+}
+
+// UnmarshalJSON implements objects.Object. As it replaces the contents of the map in place, it should only be used
+// to decode into a new map.
+func (m *immutableMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.trie = hamt[K, V]{}
+	for _, entry := range entries {
+		m.trie, _ = m.trie.with(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (m *immutableMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	entries := make([]MapEntry[K, V], 0, m.trie.size)
+	for key, value := range m.Entries() {
+		entries = append(entries, &mapEntry[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return objects.NewSliceIterator(entries)
+}
+
+// ImmutableMap implementation
+
+// Entries implements ImmutableMap.
+func (m *immutableMap[K, V]) Entries() iter.Seq2[K, V] {
+	return m.trie.entries()
+}
+
+// Size implements ImmutableMap.
+func (m *immutableMap[K, V]) Size() int {
+	return m.trie.size
+}
+
+// IsEmpty implements ImmutableMap.
+func (m *immutableMap[K, V]) IsEmpty() bool {
+	return m.trie.size == 0
+}
+
+// ContainsKey implements ImmutableMap.
+func (m *immutableMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.trie.find(key)
+	return ok
+}
+
+// Get implements ImmutableMap.
+func (m *immutableMap[K, V]) Get(key K) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements ImmutableMap.
+func (m *immutableMap[K, V]) GetSafe(key K) (V, error) {
+	value, ok := m.trie.find(key)
+	if !ok {
+		return value, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return value, nil
+}
+
+// With implements ImmutableMap.
+func (m *immutableMap[K, V]) With(key K, value V) ImmutableMap[K, V] {
+	trie, _ := m.trie.with(key, value)
+	return &immutableMap[K, V]{
+		trie: trie,
+	}
+}
+
+// Without implements ImmutableMap.
+func (m *immutableMap[K, V]) Without(key K) ImmutableMap[K, V] {
+	trie, removed := m.trie.without(key)
+	if !removed {
+		return m
+	}
+	return &immutableMap[K, V]{
+		trie: trie,
+	}
+}
+
+// Keys implements ImmutableMap.
+func (m *immutableMap[K, V]) Keys() ImmutableSet[K] {
+	set := &immutableSet[K]{}
+	for key := range m.Entries() {
+		set.trie, _ = set.trie.with(key, struct{}{})
+	}
+	return set
+}
+
+// Values implements ImmutableMap.
+func (m *immutableMap[K, V]) Values() ImmutableList[V] {
+	list := newImmutableList[V]()
+	for _, value := range m.Entries() {
+		list = list.with(value)
+	}
+	return list
+}
+
+// ToMap implements ImmutableMap.
+func (m *immutableMap[K, V]) ToMap() Map[K, V] {
+	newMap := NewHashMap[K, V]()
+	for key, value := range m.Entries() {
+		_ = newMap.Put(key, value)
+	}
+	return newMap
+}
+
+// immutable map
+
+func (m *immutableMap[K, V]) readOnly() {}
@@ -0,0 +1,131 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+// collidingString is a string whose hash code only depends on its length, so
+// that tests can force hash collisions.
+type collidingString struct {
+	value string
+}
+
+func (s collidingString) Equals(other any) bool {
+	if otherString, ok := other.(collidingString); ok {
+		return s.value == otherString.value
+	}
+	return false
+}
+
+func (s collidingString) HashCode() uint64 {
+	return uint64(len(s.value))
+}
+
+func (s collidingString) String() string {
+	return s.value
+}
+
+func (s collidingString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.value)
+}
+
+func (s collidingString) UnmarshalJSON(bytes []byte) error {
+	return errors.New(nil, ErrorCodeUnsupported, "unsupported")
+}
+
+func TestImmutableMap(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	// Check every version of the map against a mutable map that receives the same operations.
+	expected := NewHashMap[*objects.Int, *objects.Int]()
+	m := NewImmutableMap[*objects.Int, *objects.Int]()
+	for ix := 0; ix < 5000; ix++ {
+		key := objects.WrapInt(random.Intn(1000) - 500)
+		if random.Intn(3) == 0 {
+			_, _ = expected.DeleteIfPresent(key)
+			m = m.Without(key)
+		} else {
+			_, _ = expected.PutOrReplace(key, objects.WrapInt(ix))
+			m = m.With(key, objects.WrapInt(ix))
+		}
+	}
+
+	tests.Execute(m.Size()).Equal(t, expected.Size())
+	for key, value := range expected.Entries() {
+		tests.Execute2E(m.GetSafe(key)).NoError(t).Equal(t, value)
+	}
+	count := 0
+	for key, value := range m.Entries() {
+		tests.Execute(expected.Get(key)).Equal(t, value)
+		count++
+	}
+	tests.Execute(count).Equal(t, expected.Size())
+	tests.Execute(m.Keys().Size()).Equal(t, expected.Size())
+	tests.Execute(m.Values().Size()).Equal(t, expected.Size())
+	tests.Execute(mapEquals[*objects.Int, *objects.Int](expected, m.ToMap())).Equal(t, true)
+
+	for key := range expected.Entries() {
+		m = m.Without(key)
+	}
+	tests.Execute(m.IsEmpty()).Equal(t, true)
+}
+
+func TestImmutableMap_Versions(t *testing.T) {
+	original := NewImmutableMap[*objects.String, *objects.Int]().
+		With(objects.WrapString("a"), objects.WrapInt(1)).
+		With(objects.WrapString("b"), objects.WrapInt(2))
+
+	updated := original.With(objects.WrapString("a"), objects.WrapInt(3)).Without(objects.WrapString("b"))
+
+	tests.Execute(original.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(1))
+	tests.Execute(original.ContainsKey(objects.WrapString("b"))).Equal(t, true)
+	tests.Execute(updated.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(3))
+	tests.Execute2E(updated.GetSafe(objects.WrapString("b"))).ErrorCode(t, ErrorCodeNotFound)
+
+	// Maps are equal regardless of the order their entries were added in.
+	reordered := NewImmutableMap[*objects.String, *objects.Int]().
+		With(objects.WrapString("b"), objects.WrapInt(2)).
+		With(objects.WrapString("a"), objects.WrapInt(1))
+	tests.Execute(original.Equals(reordered)).Equal(t, true)
+	tests.Execute(original.HashCode()).Equal(t, reordered.HashCode())
+	tests.Execute(original.Equals(updated)).Equal(t, false)
+}
+
+func TestImmutableMap_Collisions(t *testing.T) {
+	a := collidingString{"a"}
+	b := collidingString{"b"}
+	c := collidingString{"cc"}
+
+	m := NewImmutableMap[collidingString, *objects.Int]().
+		With(a, objects.WrapInt(1)).
+		With(b, objects.WrapInt(2)).
+		With(c, objects.WrapInt(3))
+
+	tests.Execute(m.Size()).Equal(t, 3)
+	tests.Execute(m.Get(a)).Equal(t, objects.WrapInt(1))
+	tests.Execute(m.Get(b)).Equal(t, objects.WrapInt(2))
+	tests.Execute(m.Get(c)).Equal(t, objects.WrapInt(3))
+
+	m = m.With(b, objects.WrapInt(4)).Without(a)
+	tests.Execute(m.Size()).Equal(t, 2)
+	tests.Execute(m.ContainsKey(a)).Equal(t, false)
+	tests.Execute(m.Get(b)).Equal(t, objects.WrapInt(4))
+}
+
+func TestImmutableMap_JSON(t *testing.T) {
+	m := NewImmutableMap[*objects.String, *objects.Int]().With(objects.WrapString("a"), objects.WrapInt(1))
+
+	data, err := json.Marshal(m)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(string(data)).Equal(t, `[{"key":"a","value":1}]`)
+
+	decoded := NewImmutableMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, decoded)).NoError(t)
+	tests.Execute(decoded.Equals(m)).Equal(t, true)
+}
@@ -0,0 +1,196 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// ImmutableSet is a set that can't be changed once created. The methods that would change the set instead return a
+// new set, which shares most of its structure with the original, so they take O(log n) time and space.
+//
+// Immutable sets are safe to share between goroutines, and to use as keys in maps and sets.
+type ImmutableSet[O objects.Object] interface {
+	objects.Object
+	objects.Iterable[O]
+
+	// Elems returns a sequence of all elements in the set.
+	Elems() iter.Seq[O]
+
+	// Size returns the number of elements in the set.
+	Size() int
+
+	// IsEmpty returns true if the set is empty.
+	IsEmpty() bool
+
+	// Contains returns true if the set contains the given value.
+	Contains(value O) bool
+
+	// With returns a new set with the given value added.
+	With(value O) ImmutableSet[O]
+
+	// Without returns a new set without the given value.
+	Without(value O) ImmutableSet[O]
+
+	// ToSet returns a mutable copy of the set.
+	ToSet() Set[O]
+}
+
+type immutableSet[O objects.Object] struct {
+	trie hamt[O, struct{}]
+}
+
+// NewImmutableSet creates a new immutable set with the given elements.
+func NewImmutableSet[O objects.Object](elems ...O) ImmutableSet[O] {
+	set := &immutableSet[O]{}
+	for _, elem := range elems {
+		set.trie, _ = set.trie.with(elem, struct{}{})
+	}
+	return set
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (set *immutableSet[O]) Equals(other any) bool {
+	otherSet, ok := other.(ImmutableSet[O])
+	if !ok {
+		return false
+	}
+
+	if set.Size() != otherSet.Size() {
+		return false
+	}
+
+	for value := range set.Elems() {
+		if !otherSet.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCode implements objects.Object.
+func (set *immutableSet[O]) HashCode() uint64 {
+	// Sum the elements, so the hash doesn't depend on the iteration order.
+	hash := uint64(13997)
+	for value := range set.Elems() {
+		hash = hash + value.HashCode()
+	}
+	return hash
+}
+
+// String implements objects.Object.
+func (set *immutableSet[O]) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+	first := true
+	for value := range set.Elems() {
+		if first {
+			buffer.WriteString(value.String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", value))
+		}
+		first = false
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (set *immutableSet[O]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(objects.SliceFrom[O](set))
+}
+
+// UnmarshalJSON implements objects.Object. As it replaces the contents of the set in place, it should only be used
+// to decode into a new set.
+func (set *immutableSet[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	set.trie = hamt[O, struct{}]{}
+	for _, value := range values {
+		set.trie, _ = set.trie.with(value, struct{}{})
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (set *immutableSet[O]) Iterator() objects.Iterator[O] {
+	values := make([]O, 0, set.trie.size)
+	for value := range set.Elems() {
+		values = append(values, value)
+	}
+	return objects.NewSliceIterator(values)
+}
+
+// ImmutableSet implementation
+
+// Elems implements ImmutableSet.
+func (set *immutableSet[O]) Elems() iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for value := range set.trie.entries() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Size implements ImmutableSet.
+func (set *immutableSet[O]) Size() int {
+	return set.trie.size
+}
+
+// IsEmpty implements ImmutableSet.
+func (set *immutableSet[O]) IsEmpty() bool {
+	return set.trie.size == 0
+}
+
+// Contains implements ImmutableSet.
+func (set *immutableSet[O]) Contains(value O) bool {
+	_, ok := set.trie.find(value)
+	return ok
+}
+
+// With implements ImmutableSet.
+func (set *immutableSet[O]) With(value O) ImmutableSet[O] {
+	trie, added := set.trie.with(value, struct{}{})
+	if !added {
+		return set
+	}
+	return &immutableSet[O]{
+		trie: trie,
+	}
+}
+
+// Without implements ImmutableSet.
+func (set *immutableSet[O]) Without(value O) ImmutableSet[O] {
+	trie, removed := set.trie.without(value)
+	if !removed {
+		return set
+	}
+	return &immutableSet[O]{
+		trie: trie,
+	}
+}
+
+// ToSet implements ImmutableSet.
+func (set *immutableSet[O]) ToSet() Set[O] {
+	newSet := NewHashSet[O]()
+	for value := range set.Elems() {
+		_ = newSet.Add(value)
+	}
+	return newSet
+}
+
+// immutable set
+
+func (set *immutableSet[O]) readOnly() {}
@@ -0,0 +1,36 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestImmutableSet(t *testing.T) {
+	original := NewImmutableSet[*objects.String](objects.WrapString("a"), objects.WrapString("b"))
+	updated := original.With(objects.WrapString("c")).Without(objects.WrapString("a"))
+
+	tests.Execute(original.Size()).Equal(t, 2)
+	tests.Execute(original.Contains(objects.WrapString("a"))).Equal(t, true)
+	tests.Execute(original.Contains(objects.WrapString("c"))).Equal(t, false)
+	tests.Execute(updated.Size()).Equal(t, 2)
+	tests.Execute(updated.Contains(objects.WrapString("a"))).Equal(t, false)
+	tests.Execute(updated.Contains(objects.WrapString("c"))).Equal(t, true)
+
+	// Adding an element that is already there, or removing one that isn't, returns the same set.
+	tests.Execute(original.With(objects.WrapString("a")) == original).Equal(t, true)
+	tests.Execute(original.Without(objects.WrapString("c")) == original).Equal(t, true)
+
+	tests.Execute(original.Equals(NewImmutableSet[*objects.String](objects.WrapString("b"), objects.WrapString("a")))).Equal(t, true)
+	tests.Execute(original.Equals(updated)).Equal(t, false)
+	tests.Execute(original.ToSet().Size()).Equal(t, 2)
+}
+
+func TestImmutableSet_Keys(t *testing.T) {
+	set := NewHashSet[ImmutableSet[*objects.Int]]()
+
+	tests.ExecuteE(set.Add(NewImmutableSet[*objects.Int](objects.WrapInt(1), objects.WrapInt(2)))).NoError(t)
+	tests.ExecuteE(set.Add(NewImmutableSet[*objects.Int](objects.WrapInt(2), objects.WrapInt(1)))).ErrorCode(t, ErrorCodeAlreadyExists)
+	tests.Execute(set.Contains(NewImmutableSet[*objects.Int](objects.WrapInt(1)).With(objects.WrapInt(2)))).Equal(t, true)
+}
@@ -0,0 +1,499 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type linkedHashMap[K, V objects.Object] struct {
+	values map[uint64][]*linkedListNode[*mapEntry[K, V]]
+
+	// order holds every entry in iteration order. The nodes are shared with
+	// values, so entries can be unlinked or moved without a scan.
+	order *linkedList[*mapEntry[K, V]]
+
+	// accessOrder moves entries to the end of the iteration order whenever
+	// they are read or replaced, instead of only when they are inserted.
+	accessOrder bool
+}
+
+// NewLinkedHashMap creates a new hash map that iterates in insertion order, with the given elements.
+func NewLinkedHashMap[K, V objects.Object](entries ...MapEntry[K, V]) Map[K, V] {
+	return newLinkedHashMap[K, V](false, entries...)
+}
+
+// NewAccessOrderedLinkedHashMap creates a new hash map that iterates from the least to the most recently accessed
+// entry, with the given elements. Reading or replacing a single entry, through methods such as Get, GetOrDefault,
+// Replace, PutOrReplace and Compute, counts as an access.
+func NewAccessOrderedLinkedHashMap[K, V objects.Object](entries ...MapEntry[K, V]) Map[K, V] {
+	return newLinkedHashMap[K, V](true, entries...)
+}
+
+func newLinkedHashMap[K, V objects.Object](accessOrder bool, entries ...MapEntry[K, V]) *linkedHashMap[K, V] {
+	m := &linkedHashMap[K, V]{
+		values:      make(map[uint64][]*linkedListNode[*mapEntry[K, V]]),
+		order:       &linkedList[*mapEntry[K, V]]{},
+		accessOrder: accessOrder,
+	}
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object. Comparing maps doesn't count as an access of their entries.
+func (m *linkedHashMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *linkedHashMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *linkedHashMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *linkedHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	var entries []*mapEntry[K, V]
+	for node := m.order.first; node != nil; node = node.after {
+		entries = append(entries, node.value)
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *linkedHashMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (m *linkedHashMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &linkedHashMapIterator[K, V]{
+		order:    m.order,
+		current:  m.order.first,
+		modCount: m.order.modCount,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *linkedHashMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return objects.SequenceFrom[MapEntry[K, V]](m)
+}
+
+// Add implements Collection.
+func (m *linkedHashMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *linkedHashMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *linkedHashMap[K, V]) Remove(value MapEntry[K, V]) error {
+	if !m.Contains(value) {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", value.GetKey())
+	}
+
+	_, err := m.Delete(value.GetKey())
+	return err
+}
+
+// RemoveAll implements Collection.
+func (m *linkedHashMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *linkedHashMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	_, node := m.find(value.GetKey())
+	if node == nil {
+		return false
+	}
+	return value.GetValue().Equals(node.value.Value)
+}
+
+// ContainsAll implements Collection.
+func (m *linkedHashMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection.
+func (m *linkedHashMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	removed := 0
+	for node := m.order.first; node != nil; {
+		after := node.after
+		if condition(node.value) {
+			m.delete(node.value.Key.HashCode(), node)
+			removed = removed + 1
+		}
+		node = after
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (m *linkedHashMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	return collectionRetainAll[MapEntry[K, V]](m, values)
+}
+
+// Copy implements Collection. The copy keeps the iteration order and ordering mode of the original.
+func (m *linkedHashMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	newMap := newLinkedHashMap[K, V](m.accessOrder)
+	for node := m.order.first; node != nil; node = node.after {
+		_ = newMap.Put(node.value.Key, node.value.Value)
+	}
+	return newMap
+}
+
+// Size implements Collection.
+func (m *linkedHashMap[K, V]) Size() int {
+	return m.order.size
+}
+
+// IsEmpty implements Collection.
+func (m *linkedHashMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear implements Collection.
+func (m *linkedHashMap[K, V]) Clear() {
+	m.values = make(map[uint64][]*linkedListNode[*mapEntry[K, V]])
+	m.order.Clear()
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *linkedHashMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for iterator := m.Iterator(); iterator.HasNext(); {
+			entry := iterator.Next()
+			if !yield(entry.GetKey(), entry.GetValue()) {
+				return
+			}
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *linkedHashMap[K, V]) ContainsKey(key K) bool {
+	_, node := m.find(key)
+	return node != nil
+}
+
+// Put implements Map.
+func (m *linkedHashMap[K, V]) Put(key K, value V) error {
+	hash, node := m.find(key)
+	if node != nil {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+
+	m.insert(hash, key, value)
+	return nil
+}
+
+// Replace implements Map.
+func (m *linkedHashMap[K, V]) Replace(key K, value V) (V, error) {
+	_, node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+
+	return m.replace(node, value), nil
+}
+
+// PutOrReplace implements Map.
+func (m *linkedHashMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	hash, node := m.find(key)
+	if node != nil {
+		return m.replace(node, value), true
+	}
+
+	m.insert(hash, key, value)
+	return value, false
+}
+
+// Delete implements Map.
+func (m *linkedHashMap[K, V]) Delete(key K) (V, error) {
+	value, ok := m.DeleteIfPresent(key)
+	if !ok {
+		return value, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *linkedHashMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	hash, node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+
+	m.delete(hash, node)
+	return node.value.Value, true
+}
+
+// Get implements Map.
+func (m *linkedHashMap[K, V]) Get(key K) V {
+	value, err := m.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements Map.
+func (m *linkedHashMap[K, V]) GetSafe(key K) (V, error) {
+	_, node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+
+	m.access(node)
+	return node.value.Value, nil
+}
+
+// GetOrDefault implements Map.
+func (m *linkedHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	_, node := m.find(key)
+	if node == nil {
+		return defaultValue
+	}
+
+	m.access(node)
+	return node.value.Value
+}
+
+// PutIfAbsent implements Map.
+func (m *linkedHashMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	hash, node := m.find(key)
+	if node != nil {
+		m.access(node)
+		return node.value.Value, true
+	}
+
+	m.insert(hash, key, value)
+	return value, false
+}
+
+// ComputeIfAbsent implements Map.
+func (m *linkedHashMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	hash, node := m.find(key)
+	if node != nil {
+		m.access(node)
+		return node.value.Value
+	}
+
+	modCount := m.order.modCount
+	value := fn(key)
+	checkForComodification(modCount, m.order.modCount)
+
+	m.insert(hash, key, value)
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (m *linkedHashMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	hash, node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+
+	modCount := m.order.modCount
+	value, keep := fn(key, node.value.Value)
+	checkForComodification(modCount, m.order.modCount)
+
+	if !keep {
+		m.delete(hash, node)
+		var obj V
+		return obj, false
+	}
+	m.replace(node, value)
+	return value, true
+}
+
+// Compute implements Map.
+func (m *linkedHashMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	hash, node := m.find(key)
+
+	var current V
+	if node != nil {
+		current = node.value.Value
+	}
+
+	modCount := m.order.modCount
+	value, keep := fn(key, current, node != nil)
+	checkForComodification(modCount, m.order.modCount)
+
+	if !keep {
+		if node != nil {
+			m.delete(hash, node)
+		}
+		var obj V
+		return obj, false
+	}
+
+	if node != nil {
+		m.replace(node, value)
+	} else {
+		m.insert(hash, key, value)
+	}
+	return value, true
+}
+
+// Merge implements Map.
+func (m *linkedHashMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	hash, node := m.find(key)
+	if node == nil {
+		m.insert(hash, key, value)
+		return value, true
+	}
+
+	modCount := m.order.modCount
+	merged, keep := remapping(node.value.Value, value)
+	checkForComodification(modCount, m.order.modCount)
+
+	if !keep {
+		m.delete(hash, node)
+		var obj V
+		return obj, false
+	}
+	m.replace(node, merged)
+	return merged, true
+}
+
+// ReplaceAllValues implements Map. The iteration order is left as it is, even if the map is access ordered.
+func (m *linkedHashMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	modCount := m.order.modCount
+	for node := m.order.first; node != nil; node = node.after {
+		value := fn(node.value.Key, node.value.Value)
+		checkForComodification(modCount, m.order.modCount)
+
+		node.value = &mapEntry[K, V]{
+			Key:   node.value.Key,
+			Value: value,
+		}
+	}
+}
+
+// Keys implements Map. The keys are returned in the iteration order of the map.
+func (m *linkedHashMap[K, V]) Keys() Collection[K] {
+	set := newLinkedHashSet[K]()
+	for node := m.order.first; node != nil; node = node.after {
+		if err := set.Add(node.value.Key); err != nil {
+			panic(err)
+		}
+	}
+	return set
+}
+
+// Values implements Map. The values are returned in the iteration order of the map.
+func (m *linkedHashMap[K, V]) Values() Collection[V] {
+	list := NewArrayList[V]()
+	for node := m.order.first; node != nil; node = node.after {
+		if err := list.Add(node.value.Value); err != nil {
+			panic(err)
+		}
+	}
+	return list
+}
+
+// linked hash map
+
+// find returns the hash of the given key, and the node holding the key if it is in the map.
+func (m *linkedHashMap[K, V]) find(key K) (uint64, *linkedListNode[*mapEntry[K, V]]) {
+	hash := key.HashCode()
+	for _, node := range m.values[hash] {
+		if key.Equals(node.value.Key) {
+			return hash, node
+		}
+	}
+	return hash, nil
+}
+
+// peek returns the value for the given key without counting as an access.
+func (m *linkedHashMap[K, V]) peek(key K) (V, bool) {
+	_, node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+	return node.value.Value, true
+}
+
+// writesOnRead returns true if the map is access-ordered, as lookups then move entries to the end.
+func (m *linkedHashMap[K, V]) writesOnRead() bool {
+	return m.accessOrder
+}
+
+func (m *linkedHashMap[K, V]) insert(hash uint64, key K, value V) {
+	node := m.order.append(&mapEntry[K, V]{
+		Key:   key,
+		Value: value,
+	})
+	m.values[hash] = append(m.values[hash], node)
+}
+
+func (m *linkedHashMap[K, V]) replace(node *linkedListNode[*mapEntry[K, V]], value V) V {
+	oldValue := node.value.Value
+	node.value = &mapEntry[K, V]{
+		Key:   node.value.Key,
+		Value: value,
+	}
+	m.access(node)
+	return oldValue
+}
+
+func (m *linkedHashMap[K, V]) delete(hash uint64, node *linkedListNode[*mapEntry[K, V]]) {
+	nodes := m.values[hash]
+	for ix, contained := range nodes {
+		if contained == node {
+			nodes = append(nodes[:ix], nodes[ix+1:]...)
+			break
+		}
+	}
+
+	if len(nodes) == 0 {
+		delete(m.values, hash)
+	} else {
+		m.values[hash] = nodes
+	}
+	m.order.remove(node)
+}
+
+// access records a read or update of the given node.
+func (m *linkedHashMap[K, V]) access(node *linkedListNode[*mapEntry[K, V]]) {
+	if m.accessOrder {
+		m.order.moveToEnd(node)
+	}
+}
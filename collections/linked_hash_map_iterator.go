@@ -0,0 +1,28 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// linkedHashMapIterator is an iterator for linkedHashMap, following the iteration order of the map.
+type linkedHashMapIterator[K, V objects.Object] struct {
+	order   *linkedList[*mapEntry[K, V]]
+	current *linkedListNode[*mapEntry[K, V]]
+
+	// modCount is the modification count of the map's order when the iterator was created.
+	modCount int
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *linkedHashMapIterator[K, V]) HasNext() bool {
+	return iterator.current != nil
+}
+
+// Next implements objects.Iterator.
+func (iterator *linkedHashMapIterator[K, V]) Next() MapEntry[K, V] {
+	checkForComodification(iterator.modCount, iterator.order.modCount)
+	if iterator.current == nil {
+		panic("out of bounds")
+	}
+	current := iterator.current
+	iterator.current = current.after
+	return current.value
+}
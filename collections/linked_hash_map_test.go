@@ -0,0 +1,144 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestLinkedHashMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewLinkedHashMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestLinkedHashMap_FailFast(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runFailFastTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewLinkedHashMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestLinkedHashMap_MapFailFast(t *testing.T) {
+	runMapFailFastTests(t, func() Map[*objects.String, *objects.String] {
+		return NewLinkedHashMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestLinkedHashMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewLinkedHashMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestLinkedHashMap_InsertionOrder(t *testing.T) {
+	m := NewLinkedHashMap[*objects.String, *objects.Int]()
+	for ix, key := range []string{"c", "a", "d", "b"} {
+		tests.ExecuteE(m.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+
+	// Replacing a value keeps its position, deleting and re-adding moves it to the end.
+	tests.Execute2E(m.Replace(objects.WrapString("a"), objects.WrapInt(10))).NoError(t)
+	tests.Execute2E(m.Delete(objects.WrapString("c"))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("c"), objects.WrapInt(20))).NoError(t)
+	tests.Execute(m.Get(objects.WrapString("d"))).Equal(t, objects.WrapInt(2))
+
+	tests.Execute(m.String()).Equal(t, "{a:10,d:2,b:3,c:20}")
+
+	data, err := json.Marshal(m)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(string(data)).Equal(t, `[{"key":"a","value":10},{"key":"d","value":2},{"key":"b","value":3},{"key":"c","value":20}]`)
+
+	other := NewLinkedHashMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.String()).Equal(t, m.String())
+	tests.Execute(other.Equals(m)).Equal(t, true)
+}
+
+func TestLinkedHashMap_AccessOrder(t *testing.T) {
+	m := NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int]()
+	for ix, key := range []string{"a", "b", "c", "d"} {
+		tests.ExecuteE(m.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+
+	tests.Execute(m.Get(objects.WrapString("b"))).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(m.Replace(objects.WrapString("a"), objects.WrapInt(10))).NoError(t)
+	tests.Execute(m.ContainsKey(objects.WrapString("c"))).Equal(t, true)
+
+	tests.Execute(m.String()).Equal(t, "{c:2,d:3,b:1,a:10}")
+	tests.Execute(m.Copy().String()).Equal(t, "{c:2,d:3,b:1,a:10}")
+}
+
+func TestLinkedHashMap_AccessOrderEquals(t *testing.T) {
+	m := NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int]()
+	other := NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int]()
+	for ix, key := range []string{"a", "b", "c"} {
+		tests.ExecuteE(m.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+	for ix, key := range []string{"c", "b", "a"} {
+		tests.ExecuteE(other.Put(objects.WrapString(key), objects.WrapInt(2-ix))).NoError(t)
+	}
+
+	// Comparing isn't an access, so neither map is reordered.
+	tests.Execute(m.Equals(m)).Equal(t, true)
+	tests.Execute(m.Equals(other)).Equal(t, true)
+	tests.Execute(other.Equals(m)).Equal(t, true)
+	tests.Execute(m.String()).Equal(t, "{a:0,b:1,c:2}")
+	tests.Execute(other.String()).Equal(t, "{c:2,b:1,a:0}")
+}
+
+func TestLinkedHashMap_AccessOrderCompute(t *testing.T) {
+	m := NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int]()
+	for ix, key := range []string{"a", "b", "c", "d"} {
+		tests.ExecuteE(m.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+
+	tests.Execute(m.GetOrDefault(objects.WrapString("a"), objects.WrapInt(-1))).Equal(t, objects.WrapInt(0))
+	tests.Execute2(m.Merge(objects.WrapString("b"), objects.WrapInt(10), func(current, value *objects.Int) (*objects.Int, bool) {
+		return objects.WrapInt(current.Unwrap() + value.Unwrap()), true
+	})).Equal(t, true).Equal(t, objects.WrapInt(11))
+	tests.Execute(m.String()).Equal(t, "{c:2,d:3,a:0,b:11}")
+
+	// Replacing every value leaves the order alone.
+	m.ReplaceAllValues(func(key *objects.String, value *objects.Int) *objects.Int {
+		return objects.WrapInt(value.Unwrap() * 2)
+	})
+	tests.Execute(m.String()).Equal(t, "{c:4,d:6,a:0,b:22}")
+}
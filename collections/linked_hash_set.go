@@ -0,0 +1,242 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type linkedHashSet[O objects.Object] struct {
+	values map[uint64][]*linkedListNode[O]
+
+	// order holds every element in insertion order. The nodes are shared
+	// with values, so elements can be unlinked without a scan.
+	order *linkedList[O]
+}
+
+// NewLinkedHashSet creates a new hash set that iterates in insertion order, with the given elements.
+func NewLinkedHashSet[O objects.Object](elems ...O) Set[O] {
+	set := newLinkedHashSet[O]()
+	for _, elem := range elems {
+		_ = set.Add(elem)
+	}
+	return set
+}
+
+func newLinkedHashSet[O objects.Object]() *linkedHashSet[O] {
+	return &linkedHashSet[O]{
+		values: make(map[uint64][]*linkedListNode[O]),
+		order:  &linkedList[O]{},
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (set *linkedHashSet[O]) Equals(other any) bool {
+	return setEquals[O](set, other)
+}
+
+// HashCode implements objects.Object.
+func (set *linkedHashSet[O]) HashCode() uint64 {
+	return setHashCode[O](set)
+}
+
+// String implements objects.Object.
+func (set *linkedHashSet[O]) String() string {
+	return setString[O](set)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (set *linkedHashSet[O]) MarshalJSON() ([]byte, error) {
+	return set.order.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (set *linkedHashSet[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	set.Clear()
+	for _, value := range values {
+		if err := set.Add(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (set *linkedHashSet[O]) Iterator() objects.Iterator[O] {
+	// The order's iterator can remove nodes, which would leave them behind in values.
+	return &unmodifiableIterator[O]{
+		iterator: set.order.Iterator(),
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (set *linkedHashSet[O]) Elems() iter.Seq[O] {
+	return set.order.Elems()
+}
+
+// Contains implements Collection.
+func (set *linkedHashSet[O]) Contains(value O) bool {
+	_, node := set.find(value)
+	return node != nil
+}
+
+// ContainsAll implements Collection.
+func (set *linkedHashSet[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](set, values)
+}
+
+// RemoveIf implements Collection.
+func (set *linkedHashSet[O]) RemoveIf(condition func(value O) bool) int {
+	removed := 0
+	for node := set.order.first; node != nil; {
+		after := node.after
+		if condition(node.value) {
+			set.delete(node.value.HashCode(), node)
+			removed = removed + 1
+		}
+		node = after
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (set *linkedHashSet[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](set, values)
+}
+
+// Add implements Collection.
+func (set *linkedHashSet[O]) Add(value O) error {
+	hash, node := set.find(value)
+	if node != nil {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "value", value)
+	}
+
+	set.values[hash] = append(set.values[hash], set.order.append(value))
+	return nil
+}
+
+// AddAll implements Collection.
+func (set *linkedHashSet[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](set, values)
+}
+
+// Remove implements Collection.
+func (set *linkedHashSet[O]) Remove(value O) error {
+	hash, node := set.find(value)
+	if node == nil {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+
+	set.delete(hash, node)
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (set *linkedHashSet[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](set, values)
+}
+
+// Copy implements Collection. The copy keeps the iteration order of the original.
+func (set *linkedHashSet[O]) Copy() Collection[O] {
+	newSet := newLinkedHashSet[O]()
+	for node := set.order.first; node != nil; node = node.after {
+		_ = newSet.Add(node.value)
+	}
+	return newSet
+}
+
+// Size implements Collection.
+func (set *linkedHashSet[O]) Size() int {
+	return set.order.size
+}
+
+// IsEmpty implements Collection.
+func (set *linkedHashSet[O]) IsEmpty() bool {
+	return set.Size() == 0
+}
+
+// Clear implements Collection.
+func (set *linkedHashSet[O]) Clear() {
+	set.values = make(map[uint64][]*linkedListNode[O])
+	set.order.Clear()
+}
+
+// Set implementation
+
+// Union implements Set.
+func (set *linkedHashSet[O]) Union(other Set[O]) Set[O] {
+	return Union[O](set, other)
+}
+
+// Intersection implements Set.
+func (set *linkedHashSet[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](set, other)
+}
+
+// Difference implements Set.
+func (set *linkedHashSet[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](set, other)
+}
+
+// SymmetricDifference implements Set.
+func (set *linkedHashSet[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](set, other)
+}
+
+// IsSubsetOf implements Set.
+func (set *linkedHashSet[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](set, other)
+}
+
+// IsSupersetOf implements Set.
+func (set *linkedHashSet[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](set, other)
+}
+
+// IsDisjoint implements Set.
+func (set *linkedHashSet[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](set, other)
+}
+
+// linked hash set
+
+// find returns the hash of the given value, and the node holding the value if it is in the set.
+func (set *linkedHashSet[O]) find(value O) (uint64, *linkedListNode[O]) {
+	hash := value.HashCode()
+	for _, node := range set.values[hash] {
+		if value.Equals(node.value) {
+			return hash, node
+		}
+	}
+	return hash, nil
+}
+
+func (set *linkedHashSet[O]) delete(hash uint64, node *linkedListNode[O]) {
+	nodes := set.values[hash]
+	for ix, contained := range nodes {
+		if contained == node {
+			nodes = append(nodes[:ix], nodes[ix+1:]...)
+			break
+		}
+	}
+
+	if len(nodes) == 0 {
+		delete(set.values, hash)
+	} else {
+		set.values[hash] = nodes
+	}
+	set.order.remove(node)
+}
@@ -0,0 +1,51 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestLinkedHashSet_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewLinkedHashSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestLinkedHashSet_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewLinkedHashSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestLinkedHashSet_InsertionOrder(t *testing.T) {
+	set := NewLinkedHashSet[*objects.String](
+		objects.WrapString("c"),
+		objects.WrapString("a"),
+		objects.WrapString("d"),
+		objects.WrapString("b"))
+
+	tests.ExecuteE(set.Add(objects.WrapString("a"))).ErrorCode(t, ErrorCodeAlreadyExists)
+	tests.ExecuteE(set.Remove(objects.WrapString("c"))).NoError(t)
+	tests.ExecuteE(set.Add(objects.WrapString("c"))).NoError(t)
+
+	tests.Execute(set.String()).Equal(t, "[a,d,b,c]")
+
+	data, err := json.Marshal(set)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(string(data)).Equal(t, `["a","d","b","c"]`)
+
+	other := NewLinkedHashSet[*objects.String]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.String()).Equal(t, set.String())
+}
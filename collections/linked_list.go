@@ -14,6 +14,9 @@ type linkedList[O objects.Object] struct {
 	last  *linkedListNode[O]
 
 	size int
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
 }
 
 type linkedListNode[O objects.Object] struct {
@@ -73,9 +76,7 @@ func (list *linkedList[O]) UnmarshalJSON(bytes []byte) error {
 
 // Iterator implements objects.Iterable.
 func (list *linkedList[O]) Iterator() objects.Iterator[O] {
-	return &linkedListIterator[O]{
-		current: list.first,
-	}
+	return list.ListIterator()
 }
 
 // Collection implementation
@@ -101,6 +102,25 @@ func (list *linkedList[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](list, values)
 }
 
+// RemoveIf implements Collection.
+func (list *linkedList[O]) RemoveIf(condition func(value O) bool) int {
+	removed := 0
+	for node := list.first; node != nil; {
+		after := node.after
+		if condition(node.value) {
+			list.remove(node)
+			removed = removed + 1
+		}
+		node = after
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (list *linkedList[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](list, values)
+}
+
 // Add implements Collection.
 func (list *linkedList[O]) Add(value O) error {
 	return list.Insert(value, list.size)
@@ -150,6 +170,7 @@ func (list *linkedList[O]) Clear() {
 	list.first = nil
 	list.last = nil
 	list.size = 0
+	list.modCount = list.modCount + 1
 }
 
 // List implementation
@@ -179,6 +200,7 @@ func (list *linkedList[O]) Insert(value O, ix int) error {
 			list.first = node
 			list.last = node
 			list.size = 1
+			list.modCount = list.modCount + 1
 			return nil
 		}
 		return errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
@@ -195,27 +217,13 @@ func (list *linkedList[O]) Insert(value O, ix int) error {
 			list.last.after = node
 			list.last = node
 			list.size = list.size + 1
+			list.modCount = list.modCount + 1
 			return nil
 		}
 		return errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
 	}
 
-	node := &linkedListNode[O]{
-		before: current.before,
-		after:  current,
-		value:  value,
-	}
-
-	if current.before == nil {
-		// Then this is the first node.
-		list.first = node
-	} else {
-		// Set the before node's after to the new node.
-		current.before.after = node
-	}
-	// Set the current node's before to the new node.
-	current.before = node
-	list.size = list.size + 1
+	list.insertBefore(value, current)
 	return nil
 }
 
@@ -244,10 +252,92 @@ func (list *linkedList[O]) RemoveAt(ix int) (O, error) {
 	return node.value, nil
 }
 
+// ReplaceAll implements List.
+func (list *linkedList[O]) ReplaceAll(fn func(value O) O) {
+	for node := list.first; node != nil; node = node.after {
+		node.value = fn(node.value)
+	}
+}
+
+// ListIterator implements List.
+func (list *linkedList[O]) ListIterator() ListIterator[O] {
+	return &linkedListIterator[O]{
+		list:     list,
+		current:  list.first,
+		modCount: list.modCount,
+	}
+}
+
+// ListIteratorAt implements List.
+func (list *linkedList[O]) ListIteratorAt(ix int) (ListIterator[O], error) {
+	if ix < 0 || ix > list.size {
+		return nil, errors.Newf(nil, ErrorCodeOutOfBounds, "index %d out of bounds", ix)
+	}
+	return &linkedListIterator[O]{
+		list:     list,
+		current:  list.index(ix),
+		index:    ix,
+		modCount: list.modCount,
+	}, nil
+}
+
 // linked list
 
+// insertBefore adds the given value to the list immediately before the given node, and returns its node.
+func (list *linkedList[O]) insertBefore(value O, current *linkedListNode[O]) *linkedListNode[O] {
+	node := &linkedListNode[O]{
+		before: current.before,
+		after:  current,
+		value:  value,
+	}
+
+	if current.before == nil {
+		// Then this is the first node.
+		list.first = node
+	} else {
+		// Set the before node's after to the new node.
+		current.before.after = node
+	}
+	// Set the current node's before to the new node.
+	current.before = node
+	list.size = list.size + 1
+	list.modCount = list.modCount + 1
+	return node
+}
+
+// append adds the given value to the end of the list and returns its node.
+func (list *linkedList[O]) append(value O) *linkedListNode[O] {
+	node := &linkedListNode[O]{
+		before: list.last,
+		value:  value,
+	}
+	if list.last == nil {
+		list.first = node
+	} else {
+		list.last.after = node
+	}
+	list.last = node
+	list.size = list.size + 1
+	list.modCount = list.modCount + 1
+	return node
+}
+
+// moveToEnd moves the given node, which must already be in the list, to the end of the list.
+func (list *linkedList[O]) moveToEnd(node *linkedListNode[O]) {
+	if list.last == node {
+		return
+	}
+	list.remove(node)
+	node.before = list.last
+	node.after = nil
+	list.last.after = node
+	list.last = node
+	list.size = list.size + 1
+}
+
 func (list *linkedList[O]) remove(node *linkedListNode[O]) {
 	list.size = list.size - 1
+	list.modCount = list.modCount + 1
 
 	if node.before == nil && node.after == nil {
 		// Then there's just a single item in the list, and we're removing it.
@@ -4,7 +4,17 @@ import "github.com/pasataleo/go-objects/objects"
 
 // linkedListIterator is an iterator for a linked list.
 type linkedListIterator[O objects.Object] struct {
+	list    *linkedList[O]
 	current *linkedListNode[O]
+
+	// index is the index of current, or the size of the list if current is nil.
+	index int
+
+	// last is the node most recently returned by Next or Previous, or nil if there isn't one.
+	last *linkedListNode[O]
+
+	// modCount is the modification count of the list when the iterator was created, or last changed the list.
+	modCount int
 }
 
 // HasNext implements objects.Iterator.
@@ -14,10 +24,87 @@ func (iterator *linkedListIterator[O]) HasNext() bool {
 
 // Next implements objects.Iterator.
 func (iterator *linkedListIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
 	if iterator.current == nil {
 		panic("out of bounds")
 	}
 	current := iterator.current
 	iterator.current = current.after
+	iterator.index = iterator.index + 1
+	iterator.last = current
 	return current.value
 }
+
+// HasPrevious implements ListIterator.
+func (iterator *linkedListIterator[O]) HasPrevious() bool {
+	return iterator.index > 0
+}
+
+// Previous implements ListIterator.
+func (iterator *linkedListIterator[O]) Previous() O {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+	if iterator.index == 0 {
+		panic("out of bounds")
+	}
+	if iterator.current == nil {
+		iterator.current = iterator.list.last
+	} else {
+		iterator.current = iterator.current.before
+	}
+	iterator.index = iterator.index - 1
+	iterator.last = iterator.current
+	return iterator.current.value
+}
+
+// NextIndex implements ListIterator.
+func (iterator *linkedListIterator[O]) NextIndex() int {
+	return iterator.index
+}
+
+// PreviousIndex implements ListIterator.
+func (iterator *linkedListIterator[O]) PreviousIndex() int {
+	return iterator.index - 1
+}
+
+// Remove implements ListIterator.
+func (iterator *linkedListIterator[O]) Remove() {
+	if iterator.last == nil {
+		noCurrentElement()
+	}
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	after := iterator.last.after
+	iterator.list.remove(iterator.last)
+	if iterator.current == iterator.last {
+		// The node was returned by Previous, so the iterator sits before it.
+		iterator.current = after
+	} else {
+		iterator.index = iterator.index - 1
+	}
+	iterator.last = nil
+	iterator.modCount = iterator.list.modCount
+}
+
+// Set implements ListIterator.
+func (iterator *linkedListIterator[O]) Set(value O) {
+	if iterator.last == nil {
+		noCurrentElement()
+	}
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	iterator.last.value = value
+}
+
+// Add implements ListIterator.
+func (iterator *linkedListIterator[O]) Add(value O) {
+	checkForComodification(iterator.modCount, iterator.list.modCount)
+
+	if iterator.current == nil {
+		iterator.list.append(value)
+	} else {
+		iterator.list.insertBefore(value, iterator.current)
+	}
+	iterator.index = iterator.index + 1
+	iterator.last = nil
+	iterator.modCount = iterator.list.modCount
+}
@@ -16,6 +16,16 @@ func TestLinkedList_Collection(t *testing.T) {
 	})
 }
 
+func TestLinkedList_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewArrayList[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
 func TestLinkedList_List(t *testing.T) {
 	runListTests(t, func() List[*objects.String] {
 		return NewLinkedList[*objects.String]()
@@ -92,6 +92,16 @@ func (q *linkedQueue[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](q, values)
 }
 
+// RemoveIf implements Collection.
+func (q *linkedQueue[O]) RemoveIf(condition func(value O) bool) int {
+	return q.list.RemoveIf(condition)
+}
+
+// RetainAll implements Collection.
+func (q *linkedQueue[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](q, values)
+}
+
 // Copy implements objects.Collection.
 func (q *linkedQueue[O]) Copy() Collection[O] {
 	return &linkedQueue[O]{
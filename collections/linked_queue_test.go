@@ -15,3 +15,13 @@ func TestLinkedQueue_Collection(t *testing.T) {
 		"three": objects.WrapString("three"),
 	})
 }
+
+func TestLinkedQueue_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewQueue[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
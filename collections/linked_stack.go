@@ -95,6 +95,16 @@ func (s *linkedStack[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](s, values)
 }
 
+// RemoveIf implements Collection.
+func (s *linkedStack[O]) RemoveIf(condition func(value O) bool) int {
+	return s.list.RemoveIf(condition)
+}
+
+// RetainAll implements Collection.
+func (s *linkedStack[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](s, values)
+}
+
 // Copy implements objects.Collection.
 func (s *linkedStack[O]) Copy() Collection[O] {
 	return &linkedStack[O]{
@@ -15,3 +15,13 @@ func TestLinkedStack_Collection(t *testing.T) {
 		"three": objects.WrapString("three"),
 	})
 }
+
+func TestLinkedStack_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewStack[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
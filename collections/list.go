@@ -27,6 +27,49 @@ type List[O objects.Object] interface {
 
 	// RemoveAt removes the value at the given index.
 	RemoveAt(ix int) (O, error)
+
+	// ReplaceAll replaces every value in the list with the result of calling fn on it.
+	ReplaceAll(fn func(value O) O)
+
+	// ListIterator returns an iterator over the list, starting at the beginning of the list.
+	ListIterator() ListIterator[O]
+
+	// ListIteratorAt returns an iterator over the list, where the first call to Next returns the value at the given
+	// index. The index may be equal to the size of the list, in which case the iterator starts at the end.
+	ListIteratorAt(ix int) (ListIterator[O], error)
+}
+
+// ListIterator is an iterator over a list that can move in either direction, and can change the list as it goes
+// without invalidating itself. The iterator sits between two elements of the list: Next returns the element after it,
+// and Previous the element before it.
+type ListIterator[O objects.Object] interface {
+	MutableIterator[O]
+
+	// HasPrevious returns true if there is an element before the iterator.
+	HasPrevious() bool
+
+	// Previous returns the element before the iterator, and moves the iterator back past it.
+	Previous() O
+
+	// NextIndex returns the index of the element that would be returned by Next, or the size of the list if the
+	// iterator is at the end of the list.
+	NextIndex() int
+
+	// PreviousIndex returns the index of the element that would be returned by Previous, or -1 if the iterator is at
+	// the beginning of the list.
+	PreviousIndex() int
+
+	// Remove removes the element most recently returned by Next or Previous from the list. It panics with an
+	// ErrorCodeIllegalState error if neither has been called since the iterator was created or last changed the list.
+	Remove()
+
+	// Set replaces the element most recently returned by Next or Previous with the given value. It panics with an
+	// ErrorCodeIllegalState error under the same conditions as Remove.
+	Set(value O)
+
+	// Add inserts the given value into the list immediately before the iterator, so a following call to Next is
+	// unaffected and a following call to Previous returns the new value.
+	Add(value O)
 }
 
 func listEquals[O objects.Object](target List[O], right any) bool {
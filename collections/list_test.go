@@ -81,4 +81,152 @@ func runListTests(t *testing.T, init func() List[*objects.String], data map[stri
 		tests.Execute(list.IndexOf(data["one"])).Equal(t, 1)
 		tests.Execute(list.IndexOf(data["two"])).Equal(t, 2)
 	})
+
+	t.Run("list_replace_all", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["one"], 1)).NoError(t)
+		tests.ExecuteE(list.Insert(data["two"], 2)).NoError(t)
+
+		list.ReplaceAll(func(value *objects.String) *objects.String {
+			if value.Equals(data["one"]) {
+				return data["four"]
+			}
+			return value
+		})
+		tests.Execute(list.Size()).Equal(t, 3)
+		tests.Execute2E(list.Get(0)).NoError(t).Equal(t, data["zero"])
+		tests.Execute2E(list.Get(1)).NoError(t).Equal(t, data["four"])
+		tests.Execute2E(list.Get(2)).NoError(t).Equal(t, data["two"])
+
+		// Replacing values doesn't invalidate iterators over the list.
+		iterator := list.ListIterator()
+		list.ReplaceAll(func(value *objects.String) *objects.String {
+			return data["three"]
+		})
+		tests.Execute(iterator.Next()).Equal(t, data["three"])
+	})
+
+	t.Run("list_iterator", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["one"], 1)).NoError(t)
+		tests.ExecuteE(list.Insert(data["two"], 2)).NoError(t)
+
+		iterator := list.ListIterator()
+		tests.Execute(iterator.HasPrevious()).Equal(t, false)
+		tests.Execute(iterator.PreviousIndex()).Equal(t, -1)
+		tests.Execute(iterator.Next()).Equal(t, data["zero"])
+		tests.Execute(iterator.Next()).Equal(t, data["one"])
+		tests.Execute(iterator.Next()).Equal(t, data["two"])
+		tests.Execute(iterator.HasNext()).Equal(t, false)
+		tests.Execute(iterator.NextIndex()).Equal(t, 3)
+
+		tests.Execute(iterator.Previous()).Equal(t, data["two"])
+		tests.Execute(iterator.Previous()).Equal(t, data["one"])
+		tests.Execute(iterator.NextIndex()).Equal(t, 1)
+		tests.Execute(iterator.Next()).Equal(t, data["one"])
+	})
+
+	t.Run("list_iterator_remove", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["one"], 1)).NoError(t)
+		tests.ExecuteE(list.Insert(data["two"], 2)).NoError(t)
+		tests.ExecuteE(list.Insert(data["three"], 3)).NoError(t)
+
+		// Remove every other element in a single pass.
+		iterator := list.ListIterator()
+		for keep := true; iterator.HasNext(); keep = !keep {
+			iterator.Next()
+			if !keep {
+				iterator.Remove()
+			}
+		}
+		tests.Execute(list.Size()).Equal(t, 2)
+		tests.Execute2E(list.Get(0)).NoError(t).Equal(t, data["zero"])
+		tests.Execute2E(list.Get(1)).NoError(t).Equal(t, data["two"])
+
+		// Remove after moving backwards.
+		tests.Execute(iterator.Previous()).Equal(t, data["two"])
+		iterator.Remove()
+		tests.Execute(iterator.HasNext()).Equal(t, false)
+		tests.Execute(iterator.NextIndex()).Equal(t, 1)
+		tests.Execute(list.Size()).Equal(t, 1)
+
+		expectErrorCode(t, ErrorCodeIllegalState, func() {
+			iterator.Remove()
+		})
+	})
+
+	t.Run("list_iterator_set_add", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["two"], 1)).NoError(t)
+
+		iterator := list.ListIterator()
+		expectErrorCode(t, ErrorCodeIllegalState, func() {
+			iterator.Set(data["four"])
+		})
+
+		tests.Execute(iterator.Next()).Equal(t, data["zero"])
+		iterator.Add(data["one"])
+		tests.Execute(iterator.NextIndex()).Equal(t, 2)
+		expectErrorCode(t, ErrorCodeIllegalState, func() {
+			iterator.Set(data["four"])
+		})
+
+		tests.Execute(iterator.Next()).Equal(t, data["two"])
+		iterator.Set(data["three"])
+		iterator.Add(data["four"])
+		tests.Execute(iterator.Previous()).Equal(t, data["four"])
+
+		tests.Execute(list.Size()).Equal(t, 4)
+		tests.Execute2E(list.Get(0)).NoError(t).Equal(t, data["zero"])
+		tests.Execute2E(list.Get(1)).NoError(t).Equal(t, data["one"])
+		tests.Execute2E(list.Get(2)).NoError(t).Equal(t, data["three"])
+		tests.Execute2E(list.Get(3)).NoError(t).Equal(t, data["four"])
+	})
+
+	t.Run("list_iterator_at", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["one"], 1)).NoError(t)
+
+		tests.Execute2E(list.ListIteratorAt(3)).ErrorCode(t, ErrorCodeOutOfBounds)
+		tests.Execute2E(list.ListIteratorAt(-1)).ErrorCode(t, ErrorCodeOutOfBounds)
+
+		iterator, err := list.ListIteratorAt(1)
+		tests.ExecuteE(err).NoError(t, tests.Fatal)
+		tests.Execute(iterator.Next()).Equal(t, data["one"])
+
+		iterator, err = list.ListIteratorAt(2)
+		tests.ExecuteE(err).NoError(t, tests.Fatal)
+		tests.Execute(iterator.HasNext()).Equal(t, false)
+		tests.Execute(iterator.Previous()).Equal(t, data["one"])
+		tests.Execute(iterator.Previous()).Equal(t, data["zero"])
+		tests.Execute(iterator.HasPrevious()).Equal(t, false)
+
+		iterator.Add(data["two"])
+		tests.Execute2E(list.Get(0)).NoError(t).Equal(t, data["two"])
+	})
+
+	t.Run("list_iterator_fail_fast", func(t *testing.T) {
+		list := init()
+
+		tests.ExecuteE(list.Insert(data["zero"], 0)).NoError(t)
+		tests.ExecuteE(list.Insert(data["one"], 1)).NoError(t)
+
+		first, second := list.ListIterator(), list.ListIterator()
+		first.Next()
+		first.Remove()
+		expectConcurrentModification(t, func() {
+			second.Next()
+		})
+	})
 }
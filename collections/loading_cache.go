@@ -0,0 +1,326 @@
+package collections
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// Loader loads the value for a key that a LoadingCache doesn't hold.
+type Loader[K, V objects.Object] func(ctx context.Context, key K) (V, error)
+
+// LoadingCache is a cache that loads the values it is missing. It keeps the loaded values in a Map, so any map in this
+// package can decide how long they are kept, including a BoundedMap, an ExpiringMap or an LRUCache.
+//
+// Concurrent loads of the same key are coalesced, so the loader runs once and every caller receives its result. The
+// loader runs on its own goroutine, with a context that holds the values of the context of the call that started the
+// load but is never cancelled, so one caller giving up doesn't fail the load for the others. Each caller stops waiting,
+// and returns an ErrorCodeCancelled error, if its own context is done before the load finishes. The load still finishes
+// and its value is stored, so a loader that can block for long should apply its own timeout.
+//
+// A LoadingCache is safe for concurrent use, whichever map it is given. The map must not be used directly once it has
+// been given to the cache.
+type LoadingCache[K, V objects.Object] interface {
+	// Get returns the value associated with the given key, loading it if the cache doesn't hold it. A failed load
+	// isn't stored, and its error is returned. If the loader panics, the error has ErrorCodeIllegalState.
+	Get(ctx context.Context, key K) (V, error)
+
+	// GetIfPresent returns the value associated with the given key, and true if the cache holds it. It never loads.
+	GetIfPresent(key K) (V, bool)
+
+	// Put associates the key with the value, replacing any value the cache holds. A load of the key that is in
+	// progress still returns its result to its callers, but doesn't store it.
+	Put(key K, value V)
+
+	// Refresh loads the value for the given key even if the cache holds it, and stores it once loaded. If the load
+	// fails, the cache keeps the value it held.
+	Refresh(ctx context.Context, key K) (V, error)
+
+	// Invalidate removes the given key from the cache, along with any failed load it remembers. As with Put, a load
+	// that is in progress isn't stored.
+	Invalidate(key K)
+
+	// InvalidateAll removes every key from the cache.
+	InvalidateAll()
+
+	// Size returns the number of values the cache holds.
+	Size() int
+
+	// Stats returns the hit, miss and load counts of the cache. A lookup is a hit if it is answered without calling
+	// the loader, including by a remembered failure. Evictions by the underlying map aren't counted.
+	Stats() CacheStats
+}
+
+// loadingCache guards the underlying map with its own lock, and tracks each
+// load in progress with a loadCall that the callers of the same key wait on.
+// With a negative TTL, a failed call is kept after it finishes until it
+// expires.
+type loadingCache[K, V objects.Object] struct {
+	lock sync.Mutex
+
+	values Map[K, V]
+	loader Loader[K, V]
+
+	calls map[uint64][]*loadCall[K, V]
+
+	clock       Clock
+	negativeTTL time.Duration
+
+	stats CacheStats
+}
+
+// loadCall is a single load of a key. The value and err are set before done
+// is closed, so callers can read them once it is.
+type loadCall[K, V objects.Object] struct {
+	key  K
+	done chan struct{}
+
+	value V
+	err   error
+
+	// finished and expiresAt are guarded by the lock of the cache. expiresAt
+	// is only set for failed calls that are being remembered.
+	finished  bool
+	expiresAt time.Time
+}
+
+// NewLoadingCache creates a new cache that keeps its values in the given map, and loads missing values with the given
+// loader. WithNegativeTTL makes it remember failed loads, and WithClock sets the clock their expiry is measured
+// against.
+func NewLoadingCache[K, V objects.Object](values Map[K, V], loader Loader[K, V], opts ...Option) LoadingCache[K, V] {
+	options := newOptions(opts)
+	return &loadingCache[K, V]{
+		values:      values,
+		loader:      loader,
+		calls:       make(map[uint64][]*loadCall[K, V]),
+		clock:       options.clock,
+		negativeTTL: options.negativeTTL,
+	}
+}
+
+// LoadingCache implementation
+
+// Get implements LoadingCache.
+func (cache *loadingCache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	cache.lock.Lock()
+	if value, err := cache.values.GetSafe(key); err == nil {
+		cache.stats.Hits = cache.stats.Hits + 1
+		cache.lock.Unlock()
+		return value, nil
+	}
+
+	hash, call := cache.find(key)
+	if call != nil && call.finished {
+		cache.stats.Hits = cache.stats.Hits + 1
+		cache.lock.Unlock()
+		return call.value, call.err
+	}
+
+	cache.stats.Misses = cache.stats.Misses + 1
+	if call != nil {
+		cache.lock.Unlock()
+		return call.wait(ctx)
+	}
+
+	call = cache.start(hash, key)
+	cache.lock.Unlock()
+
+	go cache.load(context.WithoutCancel(ctx), hash, call)
+	return call.wait(ctx)
+}
+
+// GetIfPresent implements LoadingCache.
+func (cache *loadingCache[K, V]) GetIfPresent(key K) (V, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	value, err := cache.values.GetSafe(key)
+	return value, err == nil
+}
+
+// Put implements LoadingCache.
+func (cache *loadingCache[K, V]) Put(key K, value V) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.forget(cache.find(key))
+	_, _ = cache.values.PutOrReplace(key, value)
+}
+
+// Refresh implements LoadingCache. A refresh joins a load of the key that is already in progress, rather than starting
+// another.
+func (cache *loadingCache[K, V]) Refresh(ctx context.Context, key K) (V, error) {
+	cache.lock.Lock()
+	hash, call := cache.find(key)
+	if call != nil && !call.finished {
+		cache.lock.Unlock()
+		return call.wait(ctx)
+	}
+
+	cache.forget(hash, call)
+	call = cache.start(hash, key)
+	cache.lock.Unlock()
+
+	go cache.load(context.WithoutCancel(ctx), hash, call)
+	return call.wait(ctx)
+}
+
+// Invalidate implements LoadingCache.
+func (cache *loadingCache[K, V]) Invalidate(key K) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.forget(cache.find(key))
+	_, _ = cache.values.DeleteIfPresent(key)
+}
+
+// InvalidateAll implements LoadingCache.
+func (cache *loadingCache[K, V]) InvalidateAll() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.calls = make(map[uint64][]*loadCall[K, V])
+	cache.values.Clear()
+}
+
+// Size implements LoadingCache.
+func (cache *loadingCache[K, V]) Size() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.values.Size()
+}
+
+// Stats implements LoadingCache.
+func (cache *loadingCache[K, V]) Stats() CacheStats {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.stats
+}
+
+// loading cache
+
+// find returns the hash of the key, and the call for the key, or nil if there isn't one. Remembered failures that have
+// expired are removed as they are found. It must be called with the lock held.
+func (cache *loadingCache[K, V]) find(key K) (uint64, *loadCall[K, V]) {
+	hash := key.HashCode()
+	for _, call := range cache.calls[hash] {
+		if !key.Equals(call.key) {
+			continue
+		}
+		if call.finished && !cache.clock.Now().Before(call.expiresAt) {
+			cache.forget(hash, call)
+			return hash, nil
+		}
+		return hash, call
+	}
+	return hash, nil
+}
+
+// start creates a call for the key and records it as the current call. It must be called with the lock held.
+func (cache *loadingCache[K, V]) start(hash uint64, key K) *loadCall[K, V] {
+	call := &loadCall[K, V]{
+		key:  key,
+		done: make(chan struct{}),
+	}
+	cache.calls[hash] = append(cache.calls[hash], call)
+	return call
+}
+
+// forget removes the call, if it isn't nil, so its result won't be stored or returned to later callers. It must be
+// called with the lock held.
+func (cache *loadingCache[K, V]) forget(hash uint64, call *loadCall[K, V]) {
+	if call == nil {
+		return
+	}
+
+	calls := cache.calls[hash]
+	for ix, contained := range calls {
+		if contained == call {
+			calls = append(calls[:ix], calls[ix+1:]...)
+			break
+		}
+	}
+
+	if len(calls) == 0 {
+		delete(cache.calls, hash)
+	} else {
+		cache.calls[hash] = calls
+	}
+}
+
+// current returns true if the call is still the current call for its key.
+func (cache *loadingCache[K, V]) current(hash uint64, call *loadCall[K, V]) bool {
+	for _, contained := range cache.calls[hash] {
+		if contained == call {
+			return true
+		}
+	}
+	return false
+}
+
+// load runs the loader for the call, and stores its result if the call is still current. It runs on its own
+// goroutine, so a panic in the loader is recovered and returned to the callers as an ErrorCodeIllegalState error
+// rather than crashing the program.
+func (cache *loadingCache[K, V]) load(ctx context.Context, hash uint64, call *loadCall[K, V]) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			var obj V
+			call.value = obj
+			call.err = errors.Embed(errors.Embed(errors.New(nil, ErrorCodeIllegalState, "loader panicked"), "key", call.key), "panic", recovered)
+		}
+		cache.finish(hash, call)
+	}()
+
+	call.value, call.err = cache.loader(ctx, call.key)
+}
+
+// finish records the result of the call and releases the callers waiting on it.
+func (cache *loadingCache[K, V]) finish(hash uint64, call *loadCall[K, V]) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	defer close(call.done)
+
+	call.finished = true
+	cache.stats.Loads = cache.stats.Loads + 1
+	if call.err != nil {
+		cache.stats.LoadFailures = cache.stats.LoadFailures + 1
+	}
+
+	if !cache.current(hash, call) {
+		return
+	}
+
+	// A context error says nothing about the key, so it is never remembered
+	// and the next caller loads again.
+	if call.err == nil {
+		_, _ = cache.values.PutOrReplace(call.key, call.value)
+	} else if cache.negativeTTL > 0 && !isContextError(call.err) {
+		call.expiresAt = cache.clock.Now().Add(cache.negativeTTL)
+		return
+	}
+	cache.forget(hash, call)
+}
+
+// wait returns the result of the call once it finishes, or an ErrorCodeCancelled error if the context is done first.
+func (call *loadCall[K, V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var obj V
+		return obj, errors.New(ctx.Err(), ErrorCodeCancelled, "cancelled")
+	}
+}
+
+// isContextError returns true if the error is, or wraps, the error of a context that was cancelled or timed out.
+func isContextError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			return true
+		}
+	}
+	return false
+}
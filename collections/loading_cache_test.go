@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestLoadingCache_Get(t *testing.T) {
+	var loads atomic.Int64
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		loads.Add(1)
+		return objects.WrapInt(len(key.String())), nil
+	})
+
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("abc"))).Equal(t, false)
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("abc"))).NoError(t).Equal(t, objects.WrapInt(3))
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("abc"))).NoError(t).Equal(t, objects.WrapInt(3))
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("abc"))).Equal(t, true).Equal(t, objects.WrapInt(3))
+	tests.Execute(loads.Load()).Equal(t, int64(1))
+
+	cache.Put(objects.WrapString("de"), objects.WrapInt(5))
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("de"))).NoError(t).Equal(t, objects.WrapInt(5))
+
+	// Refresh loads again even though the value is there.
+	tests.Execute2E(cache.Refresh(context.Background(), objects.WrapString("de"))).NoError(t).Equal(t, objects.WrapInt(2))
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("de"))).Equal(t, true).Equal(t, objects.WrapInt(2))
+
+	cache.Invalidate(objects.WrapString("abc"))
+	tests.Execute(cache.Size()).Equal(t, 1)
+	cache.InvalidateAll()
+	tests.Execute(cache.Size()).Equal(t, 0)
+
+	tests.Execute(cache.Stats()).Equal(t, CacheStats{Hits: 2, Misses: 1, Loads: 2})
+}
+
+func TestLoadingCache_Coalesce(t *testing.T) {
+	var loads atomic.Int64
+	release := make(chan struct{})
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		loads.Add(1)
+		<-release
+		return objects.WrapInt(42), nil
+	})
+
+	const callers = 8
+	var wait sync.WaitGroup
+	results := make([]*objects.Int, callers)
+	for ix := range callers {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			value, err := cache.Get(context.Background(), objects.WrapString("key"))
+			tests.ExecuteE(err).NoError(t)
+			results[ix] = value
+		}()
+	}
+
+	// Wait until every caller is either loading or waiting on the load.
+	for cache.Stats().Misses < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wait.Wait()
+
+	tests.Execute(loads.Load()).Equal(t, int64(1))
+	for _, result := range results {
+		tests.Execute(result).Equal(t, objects.WrapInt(42))
+	}
+}
+
+func TestLoadingCache_Cancelled(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		close(started)
+		<-release
+		return objects.WrapInt(1), nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cache.Get(context.Background(), objects.WrapString("key"))
+	}()
+	<-started
+
+	// A caller waiting on the load gives up when its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests.Execute2E(cache.Get(ctx, objects.WrapString("key"))).ErrorCode(t, ErrorCodeCancelled)
+
+	// Invalidating the key means the load isn't stored once it finishes.
+	cache.Invalidate(objects.WrapString("key"))
+	close(release)
+	<-done
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("key"))).Equal(t, false)
+}
+
+func TestLoadingCache_CancelledStarter(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var loads atomic.Int64
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		loads.Add(1)
+		close(started)
+		select {
+		case <-release:
+			return objects.WrapInt(1), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	// The caller that started the load gives up, but the load carries on for
+	// the caller still waiting on it.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := cache.Get(ctx, objects.WrapString("key"))
+		first <- err
+	}()
+	<-started
+
+	second := make(chan *objects.Int)
+	go func() {
+		value, err := cache.Get(context.Background(), objects.WrapString("key"))
+		tests.ExecuteE(err).NoError(t)
+		second <- value
+	}()
+	for cache.Stats().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	tests.ExecuteE(<-first).ErrorCode(t, ErrorCodeCancelled)
+	close(release)
+	tests.Execute(<-second).Equal(t, objects.WrapInt(1))
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("key"))).Equal(t, true).Equal(t, objects.WrapInt(1))
+	tests.Execute(loads.Load()).Equal(t, int64(1))
+}
+
+func TestLoadingCache_NegativeTTLContextError(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	var loads atomic.Int64
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		switch loads.Add(1) {
+		case 1:
+			return nil, fmt.Errorf("failed to load %s: %w", key, context.DeadlineExceeded)
+		case 2:
+			return nil, context.Canceled
+		default:
+			return objects.WrapInt(1), nil
+		}
+	}, WithClock(clock), WithNegativeTTL(time.Minute))
+
+	// Context errors aren't remembered, so each Get loads again.
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("key"))).Error(t)
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("key"))).Error(t)
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("key"))).NoError(t).Equal(t, objects.WrapInt(1))
+	tests.Execute(loads.Load()).Equal(t, int64(3))
+}
+
+func TestLoadingCache_NegativeTTL(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	fail := true
+	loads := 0
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		loads = loads + 1
+		if fail {
+			return nil, fmt.Errorf("failed to load %s", key)
+		}
+		return objects.WrapInt(loads), nil
+	}, WithClock(clock), WithNegativeTTL(time.Minute))
+
+	_, err := cache.Get(context.Background(), objects.WrapString("key"))
+	tests.Execute(err.Error()).Equal(t, "failed to load key")
+
+	// The failure is remembered until the negative TTL runs out.
+	fail = false
+	clock.Advance(time.Minute - time.Second)
+	_, err = cache.Get(context.Background(), objects.WrapString("key"))
+	tests.Execute(err.Error()).Equal(t, "failed to load key")
+	tests.Execute(loads).Equal(t, 1)
+
+	clock.Advance(time.Second)
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("key"))).NoError(t).Equal(t, objects.WrapInt(2))
+
+	// A failed refresh keeps the value that was held.
+	fail = true
+	_, err = cache.Refresh(context.Background(), objects.WrapString("key"))
+	tests.Execute(err.Error()).Equal(t, "failed to load key")
+	tests.Execute2E(cache.Get(context.Background(), objects.WrapString("key"))).NoError(t).Equal(t, objects.WrapInt(2))
+
+	tests.Execute(cache.Stats()).Equal(t, CacheStats{Hits: 2, Misses: 2, Loads: 3, LoadFailures: 2})
+}
+
+func TestLoadingCache_Panic(t *testing.T) {
+	cache := NewLoadingCache[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int](), func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		panic("boom")
+	})
+
+	_, err := cache.Get(context.Background(), objects.WrapString("key"))
+	tests.ExecuteE(err).ErrorCode(t, ErrorCodeIllegalState)
+	tests.Execute(errors.GetEmbeddedDataUnsafe(err, "panic")).Equal(t, "boom")
+
+	tests.Execute2(cache.GetIfPresent(objects.WrapString("key"))).Equal(t, false)
+	tests.Execute(cache.Stats().LoadFailures).Equal(t, 1)
+}
+
+func TestLoadingCache_Backends(t *testing.T) {
+	loader := func(ctx context.Context, key *objects.String) (*objects.Int, error) {
+		return objects.WrapInt(len(key.String())), nil
+	}
+
+	t.Run("bounded", func(t *testing.T) {
+		cache := NewLoadingCache[*objects.String, *objects.Int](NewBoundedMap[*objects.String, *objects.Int](NewLRUPolicy[*objects.String](), 2), loader)
+		for _, key := range []string{"a", "bb", "ccc"} {
+			tests.Execute2E(cache.Get(context.Background(), objects.WrapString(key))).NoError(t)
+		}
+		tests.Execute(cache.Size()).Equal(t, 2)
+		tests.Execute2(cache.GetIfPresent(objects.WrapString("a"))).Equal(t, false)
+	})
+
+	t.Run("expiring", func(t *testing.T) {
+		clock := NewManualClock(time.Unix(0, 0))
+		cache := NewLoadingCache[*objects.String, *objects.Int](NewExpiringMap[*objects.String, *objects.Int](time.Minute, WithClock(clock)), loader)
+		tests.Execute2E(cache.Get(context.Background(), objects.WrapString("a"))).NoError(t)
+		clock.Advance(time.Minute)
+		tests.Execute2(cache.GetIfPresent(objects.WrapString("a"))).Equal(t, false)
+		tests.Execute2E(cache.Get(context.Background(), objects.WrapString("a"))).NoError(t).Equal(t, objects.WrapInt(1))
+		tests.Execute(cache.Stats().Loads).Equal(t, 2)
+	})
+}
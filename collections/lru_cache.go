@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"encoding/json"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// LRUCache is a map that holds a limited number of entries, evicting the least recently used entry whenever an
+// insertion takes it over the limit.
+//
+// Reading or replacing an entry, through methods such as Get, GetOrDefault, Replace and Compute, marks it as the most
+// recently used. Iteration runs from the least to the most recently used entry.
+type LRUCache[K, V objects.Object] interface {
+	Map[K, V]
+
+	// Peek returns the value associated with the given key, and true if the key is in the cache. Unlike Get, it
+	// doesn't mark the entry as used, and doesn't count towards the statistics.
+	Peek(key K) (V, bool)
+
+	// Capacity returns the maximum number of entries the cache holds.
+	Capacity() int
+
+	// OnEvict sets a function to call with each entry the cache evicts, after it has been removed. Entries removed
+	// by Delete, Clear and the other explicit removals aren't passed to it.
+	OnEvict(fn func(key K, value V))
+
+	// Stats returns the hit, miss and eviction counts of the cache. Get, GetSafe, GetOrDefault and ComputeIfAbsent
+	// count as lookups.
+	Stats() CacheStats
+}
+
+// lruCache is an access-ordered linkedHashMap that trims its oldest entries
+// after every insertion. The hash buckets and the order share their nodes, so
+// refreshing and evicting an entry are both constant time.
+type lruCache[K, V objects.Object] struct {
+	*linkedHashMap[K, V]
+
+	capacity int
+	onEvict  func(key K, value V)
+	stats    CacheStats
+}
+
+// NewLRUCache creates a new cache that holds at most capacity entries, with the given elements.
+func NewLRUCache[K, V objects.Object](capacity int, entries ...MapEntry[K, V]) LRUCache[K, V] {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+
+	cache := &lruCache[K, V]{
+		linkedHashMap: newLinkedHashMap[K, V](true),
+		capacity:      capacity,
+	}
+	for _, entry := range entries {
+		_ = cache.Put(entry.GetKey(), entry.GetValue())
+	}
+	return cache
+}
+
+// Object implementation
+
+// Equals implements objects.Object. Comparing caches looks their entries up with Peek, so it doesn't mark any entry as
+// used or count towards the statistics of either cache.
+func (cache *lruCache[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](cache, other)
+}
+
+// HashCode implements objects.Object. Like Equals, it doesn't mark any entry as used.
+func (cache *lruCache[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](cache)
+}
+
+// String implements objects.Object. Like Equals, it doesn't mark any entry as used.
+func (cache *lruCache[K, V]) String() string {
+	return mapString[K, V](cache)
+}
+
+// UnmarshalJSON implements objects.Object. Entries beyond the capacity of the cache are evicted as they are read.
+func (cache *lruCache[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	cache.Clear()
+	for _, entry := range entries {
+		if err := cache.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collection implementation
+
+// Add implements Collection.
+func (cache *lruCache[K, V]) Add(value MapEntry[K, V]) error {
+	return cache.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (cache *lruCache[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](cache, values)
+}
+
+// Copy implements Collection. The copy has the same capacity and entries, but its own statistics and no eviction
+// function.
+func (cache *lruCache[K, V]) Copy() Collection[MapEntry[K, V]] {
+	return &lruCache[K, V]{
+		linkedHashMap: cache.linkedHashMap.Copy().(*linkedHashMap[K, V]),
+		capacity:      cache.capacity,
+	}
+}
+
+// Map implementation
+
+// Put implements Map.
+func (cache *lruCache[K, V]) Put(key K, value V) error {
+	if err := cache.linkedHashMap.Put(key, value); err != nil {
+		return err
+	}
+	cache.evict()
+	return nil
+}
+
+// PutOrReplace implements Map.
+func (cache *lruCache[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	value, replaced := cache.linkedHashMap.PutOrReplace(key, value)
+	cache.evict()
+	return value, replaced
+}
+
+// Get implements Map.
+func (cache *lruCache[K, V]) Get(key K) V {
+	value, err := cache.GetSafe(key)
+	if err != nil {
+		panic("not found")
+	}
+	return value
+}
+
+// GetSafe implements Map.
+func (cache *lruCache[K, V]) GetSafe(key K) (V, error) {
+	value, err := cache.linkedHashMap.GetSafe(key)
+	cache.record(err == nil)
+	return value, err
+}
+
+// GetOrDefault implements Map.
+func (cache *lruCache[K, V]) GetOrDefault(key K, defaultValue V) V {
+	value, err := cache.GetSafe(key)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// PutIfAbsent implements Map.
+func (cache *lruCache[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	value, present := cache.linkedHashMap.PutIfAbsent(key, value)
+	cache.evict()
+	return value, present
+}
+
+// ComputeIfAbsent implements Map.
+func (cache *lruCache[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	cache.record(cache.ContainsKey(key))
+	value := cache.linkedHashMap.ComputeIfAbsent(key, fn)
+	cache.evict()
+	return value
+}
+
+// Compute implements Map.
+func (cache *lruCache[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	value, present := cache.linkedHashMap.Compute(key, fn)
+	cache.evict()
+	return value, present
+}
+
+// Merge implements Map.
+func (cache *lruCache[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	value, present := cache.linkedHashMap.Merge(key, value, remapping)
+	cache.evict()
+	return value, present
+}
+
+// LRUCache implementation
+
+// Peek implements LRUCache.
+func (cache *lruCache[K, V]) Peek(key K) (V, bool) {
+	_, node := cache.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+	return node.value.Value, true
+}
+
+// Capacity implements LRUCache.
+func (cache *lruCache[K, V]) Capacity() int {
+	return cache.capacity
+}
+
+// OnEvict implements LRUCache.
+func (cache *lruCache[K, V]) OnEvict(fn func(key K, value V)) {
+	cache.onEvict = fn
+}
+
+// Stats implements LRUCache.
+func (cache *lruCache[K, V]) Stats() CacheStats {
+	return cache.stats
+}
+
+// lru cache
+
+// peek returns the value for the given key, without marking it as used or counting towards the statistics.
+func (cache *lruCache[K, V]) peek(key K) (V, bool) {
+	return cache.Peek(key)
+}
+
+// record counts a lookup as a hit or a miss.
+func (cache *lruCache[K, V]) record(hit bool) {
+	if hit {
+		cache.stats.Hits = cache.stats.Hits + 1
+	} else {
+		cache.stats.Misses = cache.stats.Misses + 1
+	}
+}
+
+// evict removes the least recently used entries until the cache is back within its capacity.
+func (cache *lruCache[K, V]) evict() {
+	for cache.Size() > cache.capacity {
+		node := cache.order.first
+		cache.delete(node.value.Key.HashCode(), node)
+		cache.stats.Evictions = cache.stats.Evictions + 1
+
+		if cache.onEvict != nil {
+			cache.onEvict(node.value.Key, node.value.Value)
+		}
+	}
+}
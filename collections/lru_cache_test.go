@@ -0,0 +1,125 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestLRUCache_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewLRUCache[*objects.String, *objects.String](16)
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestLRUCache_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewLRUCache[*objects.String, *objects.String](16)
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestLRUCache_Eviction(t *testing.T) {
+	cache := NewLRUCache[*objects.String, *objects.Int](3)
+
+	var evicted []string
+	cache.OnEvict(func(key *objects.String, value *objects.Int) {
+		tests.Execute(cache.ContainsKey(key)).Equal(t, false)
+		evicted = append(evicted, key.String())
+	})
+
+	for ix, key := range []string{"a", "b", "c"} {
+		tests.ExecuteE(cache.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+
+	// Reading a refreshes it, so b is the oldest when d arrives.
+	tests.Execute(cache.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(0))
+	tests.ExecuteE(cache.Put(objects.WrapString("d"), objects.WrapInt(3))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"b"})
+	tests.Execute(cache.String()).Equal(t, "{c:2,a:0,d:3}")
+
+	// Peeking doesn't, so c is evicted next even though it was just peeked at.
+	tests.Execute2(cache.Peek(objects.WrapString("c"))).Equal(t, true).Equal(t, objects.WrapInt(2))
+	tests.Execute2(cache.Peek(objects.WrapString("b"))).Equal(t, false)
+	_, _ = cache.PutOrReplace(objects.WrapString("e"), objects.WrapInt(4))
+	tests.Execute(evicted).Equal(t, []string{"b", "c"})
+
+	// Explicit removals aren't evictions.
+	tests.Execute2E(cache.Delete(objects.WrapString("a"))).NoError(t)
+	tests.Execute(evicted).Equal(t, []string{"b", "c"})
+	tests.Execute(cache.Size()).Equal(t, 2)
+	tests.Execute(cache.Capacity()).Equal(t, 3)
+}
+
+func TestLRUCache_Stats(t *testing.T) {
+	cache := NewLRUCache[*objects.String, *objects.Int](2)
+
+	compute := func(key *objects.String) *objects.Int {
+		return objects.WrapInt(len(key.String()))
+	}
+
+	tests.Execute(cache.ComputeIfAbsent(objects.WrapString("a"), compute)).Equal(t, objects.WrapInt(1))
+	tests.Execute(cache.ComputeIfAbsent(objects.WrapString("a"), compute)).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(cache.GetSafe(objects.WrapString("b"))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute(cache.GetOrDefault(objects.WrapString("a"), nil)).Equal(t, objects.WrapInt(1))
+	_, _ = cache.Peek(objects.WrapString("a"))
+
+	tests.ExecuteE(cache.AddAll(NewArrayList[MapEntry[*objects.String, *objects.Int]](
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("bb"), Value: objects.WrapInt(2)},
+		&mapEntry[*objects.String, *objects.Int]{Key: objects.WrapString("ccc"), Value: objects.WrapInt(3)}))).NoError(t)
+
+	tests.Execute(cache.Stats()).Equal(t, CacheStats{Hits: 2, Misses: 2, Evictions: 1})
+	tests.Execute(cache.Stats().HitRate()).Equal(t, 0.5)
+	tests.Execute(cache.Size()).Equal(t, 2)
+}
+
+func TestLRUCache_Equals(t *testing.T) {
+	one := NewLRUCache[*objects.String, *objects.Int](4)
+	two := NewLRUCache[*objects.String, *objects.Int](4)
+	for ix, key := range []string{"a", "b", "c"} {
+		tests.ExecuteE(one.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+		tests.ExecuteE(two.Put(objects.WrapString(key), objects.WrapInt(ix))).NoError(t)
+	}
+	tests.Execute(two.Get(objects.WrapString("a"))).Equal(t, objects.WrapInt(0))
+
+	// Comparing caches is neither a lookup nor a use of their entries.
+	tests.Execute(one.Equals(one)).Equal(t, true)
+	tests.Execute(one.Equals(two)).Equal(t, true)
+	tests.Execute(two.Equals(one)).Equal(t, true)
+	tests.Execute(one.HashCode()).Equal(t, two.HashCode())
+
+	tests.Execute(one.Stats()).Equal(t, CacheStats{})
+	tests.Execute(two.Stats()).Equal(t, CacheStats{Hits: 1})
+	tests.Execute(one.String()).Equal(t, "{a:0,b:1,c:2}")
+	tests.Execute(two.String()).Equal(t, "{b:1,c:2,a:0}")
+}
+
+func TestLRUCache_JSON(t *testing.T) {
+	cache := NewLRUCache[*objects.String, *objects.Int](2)
+	tests.ExecuteE(json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]`), cache)).NoError(t)
+	tests.Execute(cache.String()).Equal(t, "{b:2,c:3}")
+
+	copied := cache.Copy().(LRUCache[*objects.String, *objects.Int])
+	tests.ExecuteE(copied.Put(objects.WrapString("d"), objects.WrapInt(4))).NoError(t)
+	tests.Execute(copied.String()).Equal(t, "{c:3,d:4}")
+	tests.Execute(cache.String()).Equal(t, "{b:2,c:3}")
+}
@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"iter"
@@ -17,6 +18,9 @@ type MapEntry[K, V objects.Object] interface {
 }
 
 // Map represents a collection of key-value pairs.
+//
+// The functions passed to ComputeIfAbsent, ComputeIfPresent, Compute, Merge and ReplaceAllValues must not modify the
+// map. Implementations that detect this panic with an ErrorCodeConcurrentModification error.
 type Map[K, V objects.Object] interface {
 	Collection[MapEntry[K, V]]
 
@@ -32,7 +36,8 @@ type Map[K, V objects.Object] interface {
 	// Replace replaces the value associated with the given key.
 	Replace(key K, value V) (V, error)
 
-	// PutOrReplace inserts or replaces a key-value pair in the map.
+	// PutOrReplace inserts or replaces a key-value pair in the map. It returns the value that was replaced and true, or
+	// the given value and false if the key was inserted.
 	PutOrReplace(key K, value V) (V, bool)
 
 	// Delete removes the key-value pair associated with the given key.
@@ -47,6 +52,35 @@ type Map[K, V objects.Object] interface {
 	// GetSafe returns the value associated with the given key or an error if the key does not exist.
 	GetSafe(key K) (V, error)
 
+	// GetOrDefault returns the value associated with the given key, or defaultValue if the key does not exist.
+	GetOrDefault(key K, defaultValue V) V
+
+	// PutIfAbsent inserts the key-value pair if the key isn't already in the map. It returns the value now associated
+	// with the key, and true if that value was already present.
+	PutIfAbsent(key K, value V) (V, bool)
+
+	// ComputeIfAbsent returns the value associated with the given key, calling fn to create and insert the value if
+	// the key isn't in the map.
+	ComputeIfAbsent(key K, fn func(key K) V) V
+
+	// ComputeIfPresent calls fn with the current value of the given key, if the key is in the map. The value is
+	// replaced with the value fn returns, or the pair is removed if fn returns false. It returns the new value, and true
+	// if the key is still in the map.
+	ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool)
+
+	// Compute calls fn with the current value of the given key, and whether the key is in the map. The key is then
+	// associated with the value fn returns, or the pair is removed if fn returns false. It returns the new value, and
+	// true if the key is now in the map.
+	Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool)
+
+	// Merge inserts the key-value pair if the key isn't in the map. Otherwise, it calls remapping with the current and
+	// given values, and replaces the value with the result, or removes the pair if remapping returns false. It returns
+	// the new value, and true if the key is now in the map.
+	Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool)
+
+	// ReplaceAllValues replaces the value of every key-value pair with the value fn returns for it.
+	ReplaceAllValues(fn func(key K, value V) V)
+
 	// Keys returns a collection of the keys in the map.
 	Keys() Collection[K]
 
@@ -54,17 +88,116 @@ type Map[K, V objects.Object] interface {
 	Values() Collection[V]
 }
 
+// mapPeeker is implemented by maps whose lookups have side effects, such as
+// reordering their entries. peek looks a key up without them, so comparing
+// maps leaves both as they were.
+type mapPeeker[K, V objects.Object] interface {
+	peek(key K) (V, bool)
+}
+
+// mapWriter is implemented by maps whose lookups can change them, such as by
+// reordering their entries or counting the lookup. writesOnRead returns true if
+// they do, so wrappers know to guard lookups with a write lock.
+type mapWriter interface {
+	writesOnRead() bool
+}
+
+func mapEquals[K, V objects.Object](target Map[K, V], right any) bool {
+	other, ok := right.(Map[K, V])
+	if !ok {
+		return false
+	}
+
+	if target == other {
+		return true
+	}
+
+	if target.Size() != other.Size() {
+		return false
+	}
+
+	for key, value := range target.Entries() {
+		contained, ok := mapPeek(other, key)
+		if !ok || !value.Equals(contained) {
+			return false
+		}
+	}
+	return true
+}
+
+// mapPeek returns the value associated with the given key, and true if the map contains it, without counting as an
+// access of the entry.
+func mapPeek[K, V objects.Object](m Map[K, V], key K) (V, bool) {
+	if peeker, ok := m.(mapPeeker[K, V]); ok {
+		return peeker.peek(key)
+	}
+
+	value, err := m.GetSafe(key)
+	return value, err == nil
+}
+
+// mapWritesOnRead returns true if looking a key up in the map can change it.
+func mapWritesOnRead(m any) bool {
+	if writer, ok := m.(mapWriter); ok {
+		return writer.writesOnRead()
+	}
+	return false
+}
+
+func mapHashCode[K, V objects.Object](target Map[K, V]) uint64 {
+	hash := uint64(13001)
+	for iterator := target.Iterator(); iterator.HasNext(); {
+		hash = hash * iterator.Next().HashCode()
+	}
+	return hash
+}
+
+func mapString[K, V objects.Object](target Map[K, V]) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+
+	first := true
+	for iterator := target.Iterator(); iterator.HasNext(); {
+		entry := iterator.Next()
+		if first {
+			buffer.WriteString(entry.String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", entry))
+		}
+		first = false
+	}
+	buffer.WriteString("}")
+	return buffer.String()
+}
+
 type mapEntry[K, V objects.Object] struct {
 	Key   K `json:"key"`
 	Value V `json:"value"`
 }
 
 func (m *mapEntry[K, V]) MarshalJSON() ([]byte, error) {
-	return json.Marshal(m)
+	// Marshal through an anonymous struct, as marshalling m directly would
+	// recurse back into this method.
+	return json.Marshal(struct {
+		Key   K `json:"key"`
+		Value V `json:"value"`
+	}{
+		Key:   m.Key,
+		Value: m.Value,
+	})
 }
 
 func (m *mapEntry[K, V]) UnmarshalJSON(bytes []byte) error {
-	return json.Unmarshal(bytes, m)
+	var entry struct {
+		Key   K `json:"key"`
+		Value V `json:"value"`
+	}
+	if err := json.Unmarshal(bytes, &entry); err != nil {
+		return err
+	}
+	m.Key = entry.Key
+	m.Value = entry.Value
+	return nil
 }
 
 func (m *mapEntry[K, V]) Equals(other any) bool {
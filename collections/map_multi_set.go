@@ -0,0 +1,363 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+	"slices"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// mapMultiSet keeps a count for each distinct element in a map. Elements are
+// removed from the map along with their last occurrence, so every count in
+// the map is positive.
+type mapMultiSet[O objects.Object] struct {
+	counts Map[O, *objects.Int]
+
+	// size counts the occurrences across every element, so Size doesn't have to.
+	size int
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
+}
+
+// NewHashMultiSet creates a new multiset that keeps its counts in a hash map, with the given elements.
+func NewHashMultiSet[O objects.Object](elems ...O) MultiSet[O] {
+	return newMapMultiSet[O](NewHashMap[O, *objects.Int](), elems...)
+}
+
+// NewTreeMultiSet creates a new multiset ordered by the natural ordering of the elements, with the given elements.
+func NewTreeMultiSet[O objects.ComparableObject[O]](elems ...O) MultiSet[O] {
+	return NewTreeMultiSetT[O](objects.ComparableComparator[O](), elems...)
+}
+
+// NewTreeMultiSetT creates a new multiset ordered by the given comparator, with the given elements. Iteration, and
+// the sets returned by ElementSet and EntrySet, follow the order of the elements.
+func NewTreeMultiSetT[O objects.Object](comparator objects.Comparator[O], elems ...O) MultiSet[O] {
+	return newMapMultiSet[O](NewTreeMapT[O, *objects.Int](comparator), elems...)
+}
+
+func newMapMultiSet[O objects.Object](counts Map[O, *objects.Int], elems ...O) *mapMultiSet[O] {
+	set := &mapMultiSet[O]{
+		counts: counts,
+	}
+	for _, elem := range elems {
+		_ = set.Add(elem)
+	}
+	return set
+}
+
+// Object implementation
+
+// Equals implements objects.Object. Multisets are equal if they hold the same elements with the same counts.
+func (set *mapMultiSet[O]) Equals(other any) bool {
+	multiSet, ok := other.(MultiSet[O])
+	if !ok {
+		return false
+	}
+
+	if set.Size() != multiSet.Size() {
+		return false
+	}
+
+	for value, count := range set.counts.Entries() {
+		if multiSet.Count(value) != count.Unwrap() {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCode implements objects.Object.
+func (set *mapMultiSet[O]) HashCode() uint64 {
+	return set.counts.HashCode()
+}
+
+// String implements objects.Object. Each element is written once, with its count.
+func (set *mapMultiSet[O]) String() string {
+	return set.counts.String()
+}
+
+// MarshalJSON implements objects.Object. The multiset is written as a list of objects, each holding an element and
+// its count.
+func (set *mapMultiSet[O]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.entries())
+}
+
+// UnmarshalJSON implements objects.Object.
+func (set *mapMultiSet[O]) UnmarshalJSON(bytes []byte) error {
+	var entries []*multiSetEntry[O]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	set.Clear()
+	for _, entry := range entries {
+		if err := set.AddN(entry.Element, entry.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (set *mapMultiSet[O]) Iterator() objects.Iterator[O] {
+	return &mapMultiSetIterator[O]{
+		set:      set,
+		entries:  set.counts.Iterator(),
+		modCount: set.modCount,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (set *mapMultiSet[O]) Elems() iter.Seq[O] {
+	return func(yield func(O) bool) {
+		modCount := set.modCount
+		for value, count := range set.counts.Entries() {
+			for range count.Unwrap() {
+				if !yield(value) {
+					return
+				}
+				checkForComodification(modCount, set.modCount)
+			}
+		}
+	}
+}
+
+// Add implements Collection. It adds a single occurrence of the element.
+func (set *mapMultiSet[O]) Add(value O) error {
+	return set.AddN(value, 1)
+}
+
+// AddAll implements Collection.
+func (set *mapMultiSet[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](set, values)
+}
+
+// Remove implements Collection. It removes a single occurrence of the element.
+func (set *mapMultiSet[O]) Remove(value O) error {
+	if removed, _ := set.RemoveN(value, 1); removed == 0 {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (set *mapMultiSet[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](set, values)
+}
+
+// Contains implements Collection.
+func (set *mapMultiSet[O]) Contains(value O) bool {
+	return set.counts.ContainsKey(value)
+}
+
+// ContainsAll implements Collection.
+func (set *mapMultiSet[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](set, values)
+}
+
+// RemoveIf implements Collection. The condition is called once for each distinct element, and every occurrence of a
+// matching element is removed. It returns the number of occurrences removed.
+func (set *mapMultiSet[O]) RemoveIf(condition func(value O) bool) int {
+	removed := 0
+	set.counts.RemoveIf(func(entry MapEntry[O, *objects.Int]) bool {
+		if !condition(entry.GetKey()) {
+			return false
+		}
+		removed = removed + entry.GetValue().Unwrap()
+		return true
+	})
+
+	if removed > 0 {
+		set.size = set.size - removed
+		set.modCount = set.modCount + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (set *mapMultiSet[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](set, values)
+}
+
+// Copy implements Collection. The copy keeps its counts in the same kind of map as the original.
+func (set *mapMultiSet[O]) Copy() Collection[O] {
+	return &mapMultiSet[O]{
+		counts: set.counts.Copy().(Map[O, *objects.Int]),
+		size:   set.size,
+	}
+}
+
+// Size implements Collection. It counts every occurrence of every element.
+func (set *mapMultiSet[O]) Size() int {
+	return set.size
+}
+
+// IsEmpty implements Collection.
+func (set *mapMultiSet[O]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// Clear implements Collection.
+func (set *mapMultiSet[O]) Clear() {
+	set.counts.Clear()
+	set.size = 0
+	set.modCount = set.modCount + 1
+}
+
+// MultiSet implementation
+
+// Count implements MultiSet.
+func (set *mapMultiSet[O]) Count(value O) int {
+	if count := set.counts.GetOrDefault(value, nil); count != nil {
+		return count.Unwrap()
+	}
+	return 0
+}
+
+// AddN implements MultiSet.
+func (set *mapMultiSet[O]) AddN(value O, n int) error {
+	if n < 0 {
+		return countOutOfRange(n)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	set.counts.Merge(value, objects.WrapInt(n), func(current, added *objects.Int) (*objects.Int, bool) {
+		return objects.WrapInt(current.Unwrap() + added.Unwrap()), true
+	})
+	set.size = set.size + n
+	set.modCount = set.modCount + 1
+	return nil
+}
+
+// RemoveN implements MultiSet.
+func (set *mapMultiSet[O]) RemoveN(value O, n int) (int, error) {
+	if n < 0 {
+		return 0, countOutOfRange(n)
+	}
+
+	removed := 0
+	set.counts.ComputeIfPresent(value, func(_ O, current *objects.Int) (*objects.Int, bool) {
+		removed = min(current.Unwrap(), n)
+		remaining := current.Unwrap() - removed
+		return objects.WrapInt(remaining), remaining > 0
+	})
+
+	if removed > 0 {
+		set.size = set.size - removed
+		set.modCount = set.modCount + 1
+	}
+	return removed, nil
+}
+
+// SetCount implements MultiSet.
+func (set *mapMultiSet[O]) SetCount(value O, count int) (int, error) {
+	if count < 0 {
+		return 0, countOutOfRange(count)
+	}
+
+	previous := 0
+	set.counts.Compute(value, func(value O, current *objects.Int, present bool) (*objects.Int, bool) {
+		if present {
+			previous = current.Unwrap()
+		}
+		return objects.WrapInt(count), count > 0
+	})
+
+	if count != previous {
+		set.size = set.size + count - previous
+		set.modCount = set.modCount + 1
+	}
+	return previous, nil
+}
+
+// ElementSet implements MultiSet. A sorted multiset returns a sorted set using the same comparator.
+func (set *mapMultiSet[O]) ElementSet() Set[O] {
+	return set.counts.Keys().(Set[O])
+}
+
+// EntrySet implements MultiSet.
+func (set *mapMultiSet[O]) EntrySet() Set[MultiSetEntry[O]] {
+	entries := newLinkedHashSet[MultiSetEntry[O]]()
+	for _, entry := range set.entries() {
+		_ = entries.Add(entry)
+	}
+	return entries
+}
+
+// MostCommon implements MultiSet.
+func (set *mapMultiSet[O]) MostCommon(k int) List[MultiSetEntry[O]] {
+	return set.ranked(k, func(left, right MultiSetEntry[O]) int {
+		return right.GetCount() - left.GetCount()
+	})
+}
+
+// LeastCommon implements MultiSet.
+func (set *mapMultiSet[O]) LeastCommon(k int) List[MultiSetEntry[O]] {
+	return set.ranked(k, func(left, right MultiSetEntry[O]) int {
+		return left.GetCount() - right.GetCount()
+	})
+}
+
+// map multiset
+
+// entries returns each distinct element with its count, in iteration order.
+func (set *mapMultiSet[O]) entries() []MultiSetEntry[O] {
+	entries := make([]MultiSetEntry[O], 0, set.counts.Size())
+	for value, count := range set.counts.Entries() {
+		entries = append(entries, &multiSetEntry[O]{
+			Element: value,
+			Count:   count.Unwrap(),
+		})
+	}
+	return entries
+}
+
+// ranked returns the first k entries once sorted by the given comparison. The sort is stable, so entries that compare
+// equal stay in iteration order.
+func (set *mapMultiSet[O]) ranked(k int, compare func(left, right MultiSetEntry[O]) int) List[MultiSetEntry[O]] {
+	entries := set.entries()
+	slices.SortStableFunc(entries, compare)
+	return NewArrayList[MultiSetEntry[O]](entries[:min(max(k, 0), len(entries))]...)
+}
+
+func countOutOfRange(count int) error {
+	return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "count out of range"), "count", count)
+}
+
+type mapMultiSetIterator[O objects.Object] struct {
+	set     *mapMultiSet[O]
+	entries objects.Iterator[MapEntry[O, *objects.Int]]
+
+	// current is the element being returned, and remaining the number of
+	// occurrences of it still to return.
+	current   O
+	remaining int
+
+	modCount int
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *mapMultiSetIterator[O]) HasNext() bool {
+	return iterator.remaining > 0 || iterator.entries.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *mapMultiSetIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.set.modCount)
+	if iterator.remaining == 0 {
+		entry := iterator.entries.Next()
+		iterator.current = entry.GetKey()
+		iterator.remaining = entry.GetValue().Unwrap()
+	}
+	iterator.remaining = iterator.remaining - 1
+	return iterator.current
+}
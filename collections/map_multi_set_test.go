@@ -0,0 +1,130 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestHashMultiSet_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewHashMultiSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestHashMultiSet_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewHashMultiSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestTreeMultiSet_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewTreeMultiSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestTreeMultiSet_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewTreeMultiSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestHashMultiSet_Counts(t *testing.T) {
+	set := NewHashMultiSet[*objects.Int](ints(1, 2, 2, 3, 3, 3)...)
+
+	tests.Execute(set.Size()).Equal(t, 6)
+	tests.Execute(set.Count(objects.WrapInt(3))).Equal(t, 3)
+	tests.Execute(set.Count(objects.WrapInt(4))).Equal(t, 0)
+
+	tests.ExecuteE(set.AddN(objects.WrapInt(4), 2)).NoError(t)
+	tests.ExecuteE(set.AddN(objects.WrapInt(4), -1)).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute(set.Count(objects.WrapInt(4))).Equal(t, 2)
+	tests.Execute(set.Size()).Equal(t, 8)
+
+	// Removing more than are there removes them all, and the element with them.
+	tests.Execute2E(set.RemoveN(objects.WrapInt(3), 2)).NoError(t).Equal(t, 2)
+	tests.Execute2E(set.RemoveN(objects.WrapInt(3), 5)).NoError(t).Equal(t, 1)
+	tests.Execute2E(set.RemoveN(objects.WrapInt(3), 1)).NoError(t).Equal(t, 0)
+	tests.Execute(set.Contains(objects.WrapInt(3))).Equal(t, false)
+	tests.ExecuteE(set.Remove(objects.WrapInt(3))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute(set.Size()).Equal(t, 5)
+
+	tests.Execute2E(set.SetCount(objects.WrapInt(1), 4)).NoError(t).Equal(t, 1)
+	tests.Execute2E(set.SetCount(objects.WrapInt(2), 0)).NoError(t).Equal(t, 2)
+	tests.Execute2E(set.SetCount(objects.WrapInt(2), -1)).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute(set.Contains(objects.WrapInt(2))).Equal(t, false)
+	tests.Execute(set.Size()).Equal(t, 6)
+
+	tests.Execute(set.ElementSet().Equals(hashSetOf(1, 4))).Equal(t, true)
+	tests.Execute(set.Equals(NewTreeMultiSetT[*objects.Int](ascendingInts(), ints(4, 1, 1, 4, 1, 1)...))).Equal(t, true)
+	tests.Execute(set.HashCode()).Equal(t, NewHashMultiSet[*objects.Int](ints(4, 1, 1, 4, 1, 1)...).HashCode())
+
+	// RemoveIf removes every occurrence of a matching element.
+	tests.Execute(set.RemoveIf(func(value *objects.Int) bool {
+		return value.Unwrap() == 1
+	})).Equal(t, 4)
+	tests.Execute(set.Size()).Equal(t, 2)
+}
+
+func TestTreeMultiSet_Order(t *testing.T) {
+	set := NewTreeMultiSetT[*objects.Int](ascendingInts(), ints(3, 1, 2, 1, 3, 3)...)
+
+	var values []int
+	for value := range set.Elems() {
+		values = append(values, value.Unwrap())
+	}
+	tests.Execute(values).Equal(t, []int{1, 1, 2, 3, 3, 3})
+
+	values = nil
+	for iterator := set.Iterator(); iterator.HasNext(); {
+		values = append(values, iterator.Next().Unwrap())
+	}
+	tests.Execute(values).Equal(t, []int{1, 1, 2, 3, 3, 3})
+
+	tests.Execute(set.EntrySet().String()).Equal(t, "[1:2,2:1,3:3]")
+	_, sorted := set.ElementSet().(SortedSet[*objects.Int])
+	tests.Execute(sorted).Equal(t, true)
+}
+
+func TestTreeMultiSet_Ranking(t *testing.T) {
+	set := NewTreeMultiSetT[*objects.Int](ascendingInts(), ints(1, 2, 2, 3, 3, 3, 4, 4)...)
+
+	tests.Execute(set.MostCommon(2).String()).Equal(t, "[3:3,2:2]")
+	tests.Execute(set.MostCommon(10).String()).Equal(t, "[3:3,2:2,4:2,1:1]")
+	tests.Execute(set.LeastCommon(2).String()).Equal(t, "[1:1,2:2]")
+	tests.Execute(set.MostCommon(0).IsEmpty()).Equal(t, true)
+}
+
+func TestTreeMultiSet_JSON(t *testing.T) {
+	set := NewTreeMultiSetT[*objects.Int](ascendingInts(), ints(1, 2, 2)...)
+
+	data, err := json.Marshal(set)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(string(data)).Equal(t, `[{"element":1,"count":1},{"element":2,"count":2}]`)
+
+	decoded := NewHashMultiSet[*objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, decoded)).NoError(t)
+	tests.Execute(decoded.Equals(set)).Equal(t, true)
+
+	err = json.Unmarshal([]byte(`[{"element":1,"count":-1}]`), decoded)
+	tests.ExecuteE(err).ErrorCode(t, ErrorCodeOutOfBounds)
+}
@@ -48,7 +48,7 @@ func runMapTests(t *testing.T, init func() Map[*objects.String, *objects.String]
 
 		tests.Execute2(collection.PutOrReplace(data["zero"], data["four"])).Equal(t, true).Equal(t, data["three"])
 		tests.Execute2(collection.PutOrReplace(data["two"], data["four"])).Equal(t, true).Equal(t, data["five"])
-		tests.Execute2(collection.PutOrReplace(data["four"], data["four"])).Equal(t, false)
+		tests.Execute2(collection.PutOrReplace(data["four"], data["four"])).Equal(t, false).Equal(t, data["four"])
 
 		tests.Execute(collection.Get(data["zero"])).Equal(t, data["four"])
 		tests.Execute(collection.Get(data["one"])).Equal(t, data["four"])
@@ -109,6 +109,128 @@ func runMapTests(t *testing.T, init func() Map[*objects.String, *objects.String]
 		tests.Execute2E(collection.GetSafe(data["two"])).NoError(t).Equal(t, data["five"])
 		tests.Execute2E(collection.GetSafe(data["three"])).ErrorCode(t, ErrorCodeNotFound)
 	})
+	t.Run("map_get_or_default", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Put(data["zero"], data["three"])).NoError(t)
+
+		tests.Execute(collection.GetOrDefault(data["zero"], data["five"])).Equal(t, data["three"])
+		tests.Execute(collection.GetOrDefault(data["one"], data["five"])).Equal(t, data["five"])
+		tests.Execute(collection.ContainsKey(data["one"])).Equal(t, false)
+	})
+	t.Run("map_put_if_absent", func(t *testing.T) {
+		collection := init()
+
+		tests.Execute2(collection.PutIfAbsent(data["zero"], data["three"])).Equal(t, false).Equal(t, data["three"])
+		tests.Execute2(collection.PutIfAbsent(data["zero"], data["four"])).Equal(t, true).Equal(t, data["three"])
+
+		tests.Execute(collection.Get(data["zero"])).Equal(t, data["three"])
+		tests.Execute(collection.Size()).Equal(t, 1)
+	})
+	t.Run("map_compute_if_absent", func(t *testing.T) {
+		collection := init()
+
+		calls := 0
+		compute := func(key *objects.String) *objects.String {
+			calls = calls + 1
+			return data["three"]
+		}
+
+		tests.Execute(collection.ComputeIfAbsent(data["zero"], compute)).Equal(t, data["three"])
+		tests.Execute(collection.ComputeIfAbsent(data["zero"], compute)).Equal(t, data["three"])
+		tests.Execute(calls).Equal(t, 1)
+		tests.Execute(collection.Get(data["zero"])).Equal(t, data["three"])
+	})
+	t.Run("map_compute_if_present", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Put(data["zero"], data["three"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["one"], data["four"])).NoError(t)
+
+		tests.Execute2(collection.ComputeIfPresent(data["zero"], func(key, current *objects.String) (*objects.String, bool) {
+			tests.Execute(current).Equal(t, data["three"])
+			return data["five"], true
+		})).Equal(t, true).Equal(t, data["five"])
+		tests.Execute2(collection.ComputeIfPresent(data["one"], func(key, current *objects.String) (*objects.String, bool) {
+			return nil, false
+		})).Equal(t, false)
+		tests.Execute2(collection.ComputeIfPresent(data["two"], func(key, current *objects.String) (*objects.String, bool) {
+			t.Fatal("called for a missing key")
+			return nil, false
+		})).Equal(t, false)
+
+		tests.Execute(collection.Get(data["zero"])).Equal(t, data["five"])
+		tests.Execute(collection.ContainsKey(data["one"])).Equal(t, false)
+		tests.Execute(collection.ContainsKey(data["two"])).Equal(t, false)
+		tests.Execute(collection.Size()).Equal(t, 1)
+	})
+	t.Run("map_compute", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Put(data["zero"], data["three"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["one"], data["four"])).NoError(t)
+
+		replace := func(key, current *objects.String, present bool) (*objects.String, bool) {
+			if present {
+				return current, true
+			}
+			return data["five"], true
+		}
+		remove := func(key, current *objects.String, present bool) (*objects.String, bool) {
+			return nil, false
+		}
+
+		tests.Execute2(collection.Compute(data["zero"], replace)).Equal(t, true).Equal(t, data["three"])
+		tests.Execute2(collection.Compute(data["two"], replace)).Equal(t, true).Equal(t, data["five"])
+		tests.Execute2(collection.Compute(data["one"], remove)).Equal(t, false)
+		tests.Execute2(collection.Compute(data["four"], remove)).Equal(t, false)
+
+		tests.Execute(collection.Get(data["zero"])).Equal(t, data["three"])
+		tests.Execute(collection.Get(data["two"])).Equal(t, data["five"])
+		tests.Execute(collection.ContainsKey(data["one"])).Equal(t, false)
+		tests.Execute(collection.ContainsKey(data["four"])).Equal(t, false)
+		tests.Execute(collection.Size()).Equal(t, 2)
+	})
+	t.Run("map_merge", func(t *testing.T) {
+		collection := init()
+
+		// Keep whichever value is greater, and remove the pair if they match.
+		remapping := func(current, value *objects.String) (*objects.String, bool) {
+			if current.Equals(value) {
+				return nil, false
+			}
+			if current.CompareTo(value) < 0 {
+				return value, true
+			}
+			return current, true
+		}
+
+		tests.Execute2(collection.Merge(data["zero"], data["four"], remapping)).Equal(t, true).Equal(t, data["four"])
+		tests.Execute2(collection.Merge(data["zero"], data["five"], remapping)).Equal(t, true)
+		tests.Execute2(collection.Merge(data["one"], data["three"], remapping)).Equal(t, true).Equal(t, data["three"])
+		tests.Execute2(collection.Merge(data["one"], data["three"], remapping)).Equal(t, false)
+
+		tests.Execute(collection.ContainsKey(data["zero"])).Equal(t, true)
+		tests.Execute(collection.ContainsKey(data["one"])).Equal(t, false)
+		tests.Execute(collection.Size()).Equal(t, 1)
+	})
+	t.Run("map_replace_all_values", func(t *testing.T) {
+		collection := init()
+
+		tests.ExecuteE(collection.Put(data["zero"], data["three"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["one"], data["four"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["two"], data["five"])).NoError(t)
+
+		// Swap each value for its key.
+		collection.ReplaceAllValues(func(key, value *objects.String) *objects.String {
+			return key
+		})
+
+		tests.Execute(collection.Get(data["zero"])).Equal(t, data["zero"])
+		tests.Execute(collection.Get(data["one"])).Equal(t, data["one"])
+		tests.Execute(collection.Get(data["two"])).Equal(t, data["two"])
+		tests.Execute(collection.Size()).Equal(t, 3)
+	})
 	t.Run("map_keys", func(t *testing.T) {
 		collection := init()
 
@@ -136,3 +258,51 @@ func runMapTests(t *testing.T, init func() Map[*objects.String, *objects.String]
 		tests.Execute(values.Contains(data["five"])).Equal(t, true)
 	})
 }
+
+// runMapFailFastTests checks that the Entries sequence of a map panics once the map is structurally modified, but not
+// when only values are replaced.
+func runMapFailFastTests(t *testing.T, init func() Map[*objects.String, *objects.String], data map[string]*objects.String) {
+	fill := func() Map[*objects.String, *objects.String] {
+		collection := init()
+		tests.ExecuteE(collection.Put(data["zero"], data["three"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["one"], data["four"])).NoError(t)
+		tests.ExecuteE(collection.Put(data["two"], data["five"])).NoError(t)
+		return collection
+	}
+
+	t.Run("map_fail_fast_entries", func(t *testing.T) {
+		collection := fill()
+
+		expectConcurrentModification(t, func() {
+			for key := range collection.Entries() {
+				_, _ = collection.Delete(key)
+			}
+		})
+	})
+
+	t.Run("map_fail_fast_compute", func(t *testing.T) {
+		collection := fill()
+
+		expectConcurrentModification(t, func() {
+			collection.ComputeIfAbsent(data["four"], func(key *objects.String) *objects.String {
+				_, _ = collection.Delete(data["zero"])
+				return data["four"]
+			})
+		})
+		expectConcurrentModification(t, func() {
+			collection.ReplaceAllValues(func(key, value *objects.String) *objects.String {
+				_, _ = collection.DeleteIfPresent(key)
+				return value
+			})
+		})
+	})
+
+	t.Run("map_fail_fast_replace", func(t *testing.T) {
+		collection := fill()
+
+		for key := range collection.Entries() {
+			tests.Execute2E(collection.Replace(key, data["zero"])).NoError(t)
+		}
+		tests.Execute(collection.Get(data["one"])).Equal(t, data["zero"])
+	})
+}
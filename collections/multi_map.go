@@ -0,0 +1,62 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// MultiMap represents a collection of keys that can each be associated with several values.
+//
+// A key is only present while it has at least one value, so there is no need to create or clean up the collection of
+// values for each key.
+type MultiMap[K, V objects.Object] interface {
+	objects.Object
+
+	// Entries returns an iterator over every key-value pair in the multimap. A key with several values is returned
+	// once for each of them.
+	Entries() iter.Seq2[K, V]
+
+	// ContainsKey returns true if the multimap has at least one value for the given key.
+	ContainsKey(key K) bool
+
+	// ContainsEntry returns true if the given value is associated with the given key.
+	ContainsEntry(key K, value V) bool
+
+	// Put associates the given value with the given key, in addition to any values already associated with it. It
+	// returns an error if the values for the key can't hold the value.
+	Put(key K, value V) error
+
+	// PutAll associates every one of the given values with the given key.
+	PutAll(key K, values Collection[V]) error
+
+	// Get returns a view of the values associated with the given key. The view reflects later changes to the
+	// multimap, and changes made through the view are made to the multimap, even if the key has no values yet. The
+	// view is only equal to other views, so compare a Copy of it with a list or set.
+	Get(key K) Collection[V]
+
+	// RemoveValue removes the given value from the values associated with the given key. The key is removed once it
+	// has no values left.
+	RemoveValue(key K, value V) error
+
+	// RemoveKey removes the given key and returns the values that were associated with it.
+	RemoveKey(key K) (Collection[V], error)
+
+	// Keys returns a collection of the keys in the multimap.
+	Keys() Collection[K]
+
+	// KeyCount returns the number of distinct keys in the multimap.
+	KeyCount() int
+
+	// ValueCount returns the number of key-value pairs in the multimap.
+	ValueCount() int
+
+	// IsEmpty returns true if the multimap has no key-value pairs.
+	IsEmpty() bool
+
+	// Clear removes every key-value pair from the multimap.
+	Clear()
+
+	// AsMap returns a map from each key to a copy of its values. Changes to the map aren't reflected in the multimap.
+	AsMap() Map[K, Collection[V]]
+}
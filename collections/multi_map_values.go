@@ -0,0 +1,157 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// multiMapValues is the view of the values for a single key returned by
+// MultiMap.Get. It holds no values of its own, and looks the key up on every
+// call, so it keeps working as the key is removed and added again.
+type multiMapValues[K, V objects.Object] struct {
+	m   *hashMultiMap[K, V]
+	key K
+}
+
+// Object implementation
+
+// Equals implements objects.Object. The view is only equal to another view whose values are equal to its own. Lists
+// and sets are never equal to a view, so the view isn't equal to them either, which keeps equality symmetric.
+func (view *multiMapValues[K, V]) Equals(other any) bool {
+	if other, ok := other.(*multiMapValues[K, V]); ok {
+		return view.current().Equals(other.current())
+	}
+	return false
+}
+
+// HashCode implements objects.Object.
+func (view *multiMapValues[K, V]) HashCode() uint64 {
+	return view.current().HashCode()
+}
+
+// String implements objects.Object.
+func (view *multiMapValues[K, V]) String() string {
+	return view.current().String()
+}
+
+// MarshalJSON implements objects.Object.
+func (view *multiMapValues[K, V]) MarshalJSON() ([]byte, error) {
+	return view.current().MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object. The values for the key are replaced with the given values.
+func (view *multiMapValues[K, V]) UnmarshalJSON(bytes []byte) error {
+	var values []V
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	view.Clear()
+	for _, value := range values {
+		if err := view.Add(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot of the values taken when it is created.
+func (view *multiMapValues[K, V]) Iterator() objects.Iterator[V] {
+	return objects.NewSliceIterator(objects.SliceFrom[V](view.current()))
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (view *multiMapValues[K, V]) Elems() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		values := view.m.values.GetOrDefault(view.key, nil)
+		if values == nil {
+			return
+		}
+		for value := range values.Elems() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Add implements Collection.
+func (view *multiMapValues[K, V]) Add(value V) error {
+	return view.m.Put(view.key, value)
+}
+
+// AddAll implements Collection.
+func (view *multiMapValues[K, V]) AddAll(values Collection[V]) error {
+	return view.m.PutAll(view.key, values)
+}
+
+// Remove implements Collection.
+func (view *multiMapValues[K, V]) Remove(value V) error {
+	return view.m.RemoveValue(view.key, value)
+}
+
+// RemoveAll implements Collection.
+func (view *multiMapValues[K, V]) RemoveAll(values Collection[V]) error {
+	return collectionRemoveAll[V](view, values)
+}
+
+// Contains implements Collection.
+func (view *multiMapValues[K, V]) Contains(value V) bool {
+	return view.m.ContainsEntry(view.key, value)
+}
+
+// ContainsAll implements Collection.
+func (view *multiMapValues[K, V]) ContainsAll(values Collection[V]) bool {
+	return collectionContainsAll[V](view, values)
+}
+
+// RemoveIf implements Collection.
+func (view *multiMapValues[K, V]) RemoveIf(condition func(value V) bool) int {
+	return view.m.removeIf(view.key, condition)
+}
+
+// RetainAll implements Collection.
+func (view *multiMapValues[K, V]) RetainAll(values Collection[V]) int {
+	return collectionRetainAll[V](view, values)
+}
+
+// Copy implements Collection. The copy holds the current values for the key, and is independent of the multimap.
+func (view *multiMapValues[K, V]) Copy() Collection[V] {
+	return view.current().Copy()
+}
+
+// Size implements Collection.
+func (view *multiMapValues[K, V]) Size() int {
+	values := view.m.values.GetOrDefault(view.key, nil)
+	if values == nil {
+		return 0
+	}
+	return values.Size()
+}
+
+// IsEmpty implements Collection.
+func (view *multiMapValues[K, V]) IsEmpty() bool {
+	return !view.m.ContainsKey(view.key)
+}
+
+// Clear implements Collection. It removes the key from the multimap.
+func (view *multiMapValues[K, V]) Clear() {
+	_, _ = view.m.RemoveKey(view.key)
+}
+
+// multimap values
+
+// current returns the values for the key, or a new empty collection of the same kind if the key isn't present.
+func (view *multiMapValues[K, V]) current() Collection[V] {
+	values := view.m.values.GetOrDefault(view.key, nil)
+	if values == nil {
+		return view.m.newValues()
+	}
+	return values
+}
@@ -0,0 +1,106 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// MultiSet represents a collection that can hold the same element several times, keeping a count of each element
+// rather than the copies themselves.
+//
+// The Collection methods work on single occurrences: Add adds one, Remove removes one, Size counts every occurrence
+// and iteration returns each element as many times as it occurs. Counts can never be negative, and the methods that
+// take a count return an ErrorCodeOutOfBounds error when given one that is.
+type MultiSet[O objects.Object] interface {
+	Collection[O]
+
+	// Count returns the number of occurrences of the given element.
+	Count(value O) int
+
+	// AddN adds n occurrences of the given element.
+	AddN(value O, n int) error
+
+	// RemoveN removes up to n occurrences of the given element, and returns the number actually removed.
+	RemoveN(value O, n int) (int, error)
+
+	// SetCount sets the number of occurrences of the given element, and returns the previous number. Setting the count
+	// to zero removes the element.
+	SetCount(value O, count int) (int, error)
+
+	// ElementSet returns a set of the distinct elements in the multiset.
+	ElementSet() Set[O]
+
+	// EntrySet returns a set holding each distinct element with its count, in the iteration order of the multiset.
+	EntrySet() Set[MultiSetEntry[O]]
+
+	// MostCommon returns the k elements with the highest counts, highest first. Elements with the same count are kept
+	// in the iteration order of the multiset.
+	MostCommon(k int) List[MultiSetEntry[O]]
+
+	// LeastCommon returns the k elements with the lowest counts, lowest first. Elements with the same count are kept
+	// in the iteration order of the multiset.
+	LeastCommon(k int) List[MultiSetEntry[O]]
+}
+
+// MultiSetEntry represents an element of a multiset together with its count.
+type MultiSetEntry[O objects.Object] interface {
+	objects.Object
+
+	GetElement() O
+	GetCount() int
+}
+
+type multiSetEntry[O objects.Object] struct {
+	Element O   `json:"element"`
+	Count   int `json:"count"`
+}
+
+func (m *multiSetEntry[O]) MarshalJSON() ([]byte, error) {
+	// Marshal through an anonymous struct, as marshalling m directly would
+	// recurse back into this method.
+	return json.Marshal(struct {
+		Element O   `json:"element"`
+		Count   int `json:"count"`
+	}{
+		Element: m.Element,
+		Count:   m.Count,
+	})
+}
+
+func (m *multiSetEntry[O]) UnmarshalJSON(bytes []byte) error {
+	var entry struct {
+		Element O   `json:"element"`
+		Count   int `json:"count"`
+	}
+	if err := json.Unmarshal(bytes, &entry); err != nil {
+		return err
+	}
+	m.Element = entry.Element
+	m.Count = entry.Count
+	return nil
+}
+
+func (m *multiSetEntry[O]) Equals(other any) bool {
+	if mOther, ok := other.(MultiSetEntry[O]); ok {
+		return m.Element.Equals(mOther.GetElement()) && m.Count == mOther.GetCount()
+	}
+	return false
+}
+
+func (m *multiSetEntry[O]) HashCode() uint64 {
+	return 37 * m.Element.HashCode() * uint64(m.Count)
+}
+
+func (m *multiSetEntry[O]) String() string {
+	return fmt.Sprintf("%s:%d", m.Element, m.Count)
+}
+
+func (m *multiSetEntry[O]) GetElement() O {
+	return m.Element
+}
+
+func (m *multiSetEntry[O]) GetCount() int {
+	return m.Count
+}
@@ -0,0 +1,103 @@
+package collections
+
+import (
+	"slices"
+	"time"
+)
+
+// Option configures a collection when it is created, through the constructors ending in With, such as
+// NewArrayListWith and NewHashMapWith, and constructors that take options, such as NewExpiringMap and
+// NewLoadingCache. Options that don't apply to a collection are ignored.
+type Option func(options *options)
+
+type options struct {
+	capacity   int
+	loadFactor float64
+
+	clock         Clock
+	sweepInterval time.Duration
+	negativeTTL   time.Duration
+}
+
+// WithCapacity sizes the backing storage to hold at least the given number of elements before it has to grow.
+func WithCapacity(capacity int) Option {
+	return func(options *options) {
+		options.capacity = max(capacity, 0)
+	}
+}
+
+// WithLoadFactor sets how full the table of a hash map may get before it grows, as a fraction between 0 and 1. Lower
+// load factors trade memory for faster lookups. It panics if the load factor is out of range.
+func WithLoadFactor(loadFactor float64) Option {
+	if loadFactor <= 0 || loadFactor >= 1 {
+		panic("load factor must be between 0 and 1")
+	}
+	return func(options *options) {
+		options.loadFactor = loadFactor
+	}
+}
+
+// WithClock sets the clock used by collections that track time, such as ExpiringMap. The system clock is used by
+// default.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+// WithSweepInterval starts a background goroutine that removes expired entries at the given interval, in collections
+// whose entries expire, such as ExpiringMap. Without it, expired entries are only removed as they are found.
+func WithSweepInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.sweepInterval = max(interval, 0)
+	}
+}
+
+// WithNegativeTTL makes a LoadingCache remember failed loads for the given duration, returning the same error instead
+// of calling the loader again. Failed loads aren't remembered by default.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(options *options) {
+		options.negativeTTL = max(ttl, 0)
+	}
+}
+
+func newOptions(opts []Option) options {
+	options := options{
+		loadFactor: hashMapDefaultLoadFactor,
+		clock:      SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// Resizable is implemented by collections whose backing storage can be sized explicitly, such as the array list and
+// the hash map.
+type Resizable interface {
+	// EnsureCapacity grows the backing storage, if needed, so it can hold at least the given number of elements
+	// without growing again.
+	EnsureCapacity(capacity int)
+
+	// TrimToSize shrinks the backing storage to the smallest size that holds the current elements.
+	TrimToSize()
+}
+
+// growSlice returns values with room for at least capacity elements.
+func growSlice[O any](values []O, capacity int) []O {
+	if capacity <= cap(values) {
+		return values
+	}
+	return slices.Grow(values, capacity-len(values))
+}
+
+// trimSlice returns values with no spare capacity, or nil if it is empty.
+func trimSlice[O any](values []O) []O {
+	if len(values) == 0 {
+		return nil
+	}
+	if len(values) == cap(values) {
+		return values
+	}
+	return slices.Clone(values)
+}
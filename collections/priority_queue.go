@@ -14,6 +14,9 @@ type heap[O objects.Object] struct {
 	items []O
 
 	comparator objects.Comparator[O]
+
+	// modCount counts structural modifications, so iterators can detect them.
+	modCount int
 }
 
 // NewPriorityQueue creates a new priority queue with the default comparator.
@@ -29,6 +32,22 @@ func NewPriorityQueueO[O objects.Object](comparator objects.Comparator[O]) Queue
 	}
 }
 
+// NewPriorityQueueWith creates a new priority queue with the default comparator, with its backing storage
+// configured by the given options.
+func NewPriorityQueueWith[O objects.ComparableObject[O]](opts ...Option) Queue[O] {
+	return NewPriorityQueueOWith[O](objects.ComparableComparator[O](), opts...)
+}
+
+// NewPriorityQueueOWith creates a new priority queue with the given comparator, with its backing storage configured
+// by the given options.
+func NewPriorityQueueOWith[O objects.Object](comparator objects.Comparator[O], opts ...Option) Queue[O] {
+	options := newOptions(opts)
+	return &heap[O]{
+		items:      make([]O, 0, options.capacity),
+		comparator: comparator,
+	}
+}
+
 // Object implementation
 
 // Equals implements objects.Object.
@@ -63,7 +82,11 @@ func (h *heap[O]) String() string {
 // Iterable implementation
 
 type heapIterator[O objects.Object] struct {
+	heap *heap[O]
 	safe *heap[O]
+
+	// modCount is the modification count of the heap when the iterator was created.
+	modCount int
 }
 
 func (h *heapIterator[O]) HasNext() bool {
@@ -71,6 +94,7 @@ func (h *heapIterator[O]) HasNext() bool {
 }
 
 func (h *heapIterator[O]) Next() O {
+	checkForComodification(h.modCount, h.heap.modCount)
 	value, err := h.safe.Pop()
 	if err != nil {
 		panic(err)
@@ -81,7 +105,9 @@ func (h *heapIterator[O]) Next() O {
 // Iterator implements objects.Iterable.
 func (h *heap[O]) Iterator() objects.Iterator[O] {
 	return &heapIterator[O]{
-		safe: h.Copy().(*heap[O]),
+		heap:     h,
+		safe:     h.Copy().(*heap[O]),
+		modCount: h.modCount,
 	}
 }
 
@@ -92,6 +118,7 @@ func (h *heap[O]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (h *heap[O]) UnmarshalJSON(bytes []byte) error {
+	h.modCount = h.modCount + 1
 	return json.Unmarshal(bytes, &h.items)
 }
 
@@ -143,6 +170,33 @@ func (h *heap[O]) ContainsAll(values Collection[O]) bool {
 	return collectionContainsAll[O](h, values)
 }
 
+// RemoveIf implements Collection. The remaining elements are compacted in place, and then the heap is rebuilt.
+func (h *heap[O]) RemoveIf(condition func(value O) bool) int {
+	kept := 0
+	for _, item := range h.items {
+		if !condition(item) {
+			h.items[kept] = item
+			kept = kept + 1
+		}
+	}
+
+	removed := len(h.items) - kept
+	if removed > 0 {
+		clear(h.items[kept:])
+		h.items = h.items[:kept]
+		for ix := kept/2 - 1; ix >= 0; ix-- {
+			h.down(ix)
+		}
+		h.modCount = h.modCount + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (h *heap[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](h, values)
+}
+
 // Copy implements Collection.
 func (h *heap[O]) Copy() Collection[O] {
 	var contents []O
@@ -168,6 +222,19 @@ func (h *heap[O]) IsEmpty() bool {
 // Clear implements Collection.
 func (h *heap[O]) Clear() {
 	h.items = nil
+	h.modCount = h.modCount + 1
+}
+
+// Resizable implementation
+
+// EnsureCapacity implements Resizable.
+func (h *heap[O]) EnsureCapacity(capacity int) {
+	h.items = growSlice(h.items, capacity)
+}
+
+// TrimToSize implements Resizable.
+func (h *heap[O]) TrimToSize() {
+	h.items = trimSlice(h.items)
 }
 
 // Queue implementation
@@ -177,6 +244,7 @@ func (h *heap[O]) Offer(value O) error {
 	ix := len(h.items)
 	h.items = append(h.items, value)
 	h.up(ix)
+	h.modCount = h.modCount + 1
 	return nil
 }
 
@@ -207,6 +275,7 @@ func (h *heap[O]) remove(ix int) (O, error) {
 	h.items[0] = h.items[len(h.items)-1]
 	h.items = h.items[:len(h.items)-1]
 	h.down(ix)
+	h.modCount = h.modCount + 1
 	return value, nil
 
 }
@@ -17,6 +17,16 @@ func TestHeap_Collection(t *testing.T) {
 	})
 }
 
+func TestHeap_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewPriorityQueue[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
 func TestHeap(t *testing.T) {
 	heap := NewPriorityQueue[*objects.String]()
 
@@ -37,3 +47,32 @@ func TestHeap(t *testing.T) {
 	tests.Execute2E(heap.Pop()).NoError(t).Equal(t, objects.WrapString("c"))
 	tests.Execute(heap.Size()).Equal(t, 0)
 }
+
+func TestHeap_Capacity(t *testing.T) {
+	queue := NewPriorityQueueOWith[*objects.Int](ascendingInts(), WithCapacity(10))
+	tests.Execute(cap(queue.(*heap[*objects.Int]).items)).Equal(t, 10)
+
+	tests.ExecuteE(queue.Offer(objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(queue.Offer(objects.WrapInt(1))).NoError(t)
+	queue.(Resizable).TrimToSize()
+	tests.Execute(cap(queue.(*heap[*objects.Int]).items)).Equal(t, 2)
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapInt(1))
+}
+
+func TestHeap_RemoveIf(t *testing.T) {
+	heap := NewPriorityQueueO[*objects.Int](ascendingInts())
+	for ix := 99; ix >= 0; ix-- {
+		tests.ExecuteE(heap.Offer(objects.WrapInt(ix))).NoError(t)
+	}
+
+	removed := heap.RemoveIf(func(value *objects.Int) bool {
+		return value.Unwrap()%2 == 1
+	})
+	tests.Execute(removed).Equal(t, 50)
+
+	// The heap is rebuilt, so the remaining values still come out in order.
+	for ix := 0; ix < 100; ix += 2 {
+		tests.Execute2E(heap.Pop()).NoError(t).Equal(t, objects.WrapInt(ix))
+	}
+	tests.Execute(heap.IsEmpty()).Equal(t, true)
+}
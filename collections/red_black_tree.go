@@ -0,0 +1,461 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// redBlackTree is a self-balancing binary search tree ordered by a comparator.
+// It is the shared backing store for the sorted collections in this package.
+type redBlackTree[K objects.Object, V any] struct {
+	root *redBlackNode[K, V]
+	size int
+
+	comparator objects.Comparator[K]
+
+	// modCount counts structural modifications, so iterators can detect them.
+	// It is shared by every view over the tree.
+	modCount int
+}
+
+type redBlackNode[K objects.Object, V any] struct {
+	parent *redBlackNode[K, V]
+	left   *redBlackNode[K, V]
+	right  *redBlackNode[K, V]
+
+	key   K
+	value V
+	red   bool
+}
+
+func newRedBlackTree[K objects.Object, V any](comparator objects.Comparator[K]) *redBlackTree[K, V] {
+	return &redBlackTree[K, V]{
+		comparator: comparator,
+	}
+}
+
+// find returns the node with the given key, or nil if there isn't one.
+func (tree *redBlackTree[K, V]) find(key K) *redBlackNode[K, V] {
+	for current := tree.root; current != nil; {
+		cmp := tree.comparator.Compare(key, current.key)
+		switch {
+		case cmp < 0:
+			current = current.left
+		case cmp > 0:
+			current = current.right
+		default:
+			return current
+		}
+	}
+	return nil
+}
+
+// insert adds a node for the given key, or returns the existing node and false
+// if the key is already present. Existing values are never overwritten.
+func (tree *redBlackTree[K, V]) insert(key K, value V) (*redBlackNode[K, V], bool) {
+	if tree.root == nil {
+		tree.root = &redBlackNode[K, V]{
+			key:   key,
+			value: value,
+		}
+		tree.size = 1
+		tree.modCount = tree.modCount + 1
+		return tree.root, true
+	}
+
+	var parent *redBlackNode[K, V]
+	var cmp int
+	for current := tree.root; current != nil; {
+		parent = current
+		cmp = tree.comparator.Compare(key, current.key)
+		switch {
+		case cmp < 0:
+			current = current.left
+		case cmp > 0:
+			current = current.right
+		default:
+			return current, false
+		}
+	}
+
+	node := &redBlackNode[K, V]{
+		parent: parent,
+		key:    key,
+		value:  value,
+		red:    true,
+	}
+	if cmp < 0 {
+		parent.left = node
+	} else {
+		parent.right = node
+	}
+	tree.fixAfterInsert(node)
+	tree.size = tree.size + 1
+	tree.modCount = tree.modCount + 1
+	return node, true
+}
+
+// delete removes the given node from the tree.
+func (tree *redBlackTree[K, V]) delete(node *redBlackNode[K, V]) {
+	tree.size = tree.size - 1
+	tree.modCount = tree.modCount + 1
+
+	if node.left != nil && node.right != nil {
+		// Swap the contents with the successor, then remove the successor
+		// which has at most one child.
+		successor := node.successor()
+		node.key, successor.key = successor.key, node.key
+		node.value, successor.value = successor.value, node.value
+		node = successor
+	}
+
+	replacement := node.left
+	if replacement == nil {
+		replacement = node.right
+	}
+
+	if replacement != nil {
+		replacement.parent = node.parent
+		tree.replaceChild(node, replacement)
+		node.left, node.right, node.parent = nil, nil, nil
+		if !node.red {
+			tree.fixAfterDelete(replacement)
+		}
+		return
+	}
+
+	if node.parent == nil {
+		// Then this was the only node in the tree.
+		tree.root = nil
+		return
+	}
+
+	// Use the node itself as a phantom leaf while rebalancing, then unlink it.
+	if !node.red {
+		tree.fixAfterDelete(node)
+	}
+	tree.replaceChild(node, nil)
+	node.parent = nil
+}
+
+// deleteAndSuccessor removes the given node from the tree and returns the node
+// that now holds the next key in order.
+func (tree *redBlackTree[K, V]) deleteAndSuccessor(node *redBlackNode[K, V]) *redBlackNode[K, V] {
+	if node.left != nil && node.right != nil {
+		// The successor's contents get moved into this node.
+		tree.delete(node)
+		return node
+	}
+
+	next := node.successor()
+	tree.delete(node)
+	return next
+}
+
+// clear removes every node from the tree.
+func (tree *redBlackTree[K, V]) clear() {
+	tree.root = nil
+	tree.size = 0
+	tree.modCount = tree.modCount + 1
+}
+
+// first returns the smallest node in the tree.
+func (tree *redBlackTree[K, V]) first() *redBlackNode[K, V] {
+	if tree.root == nil {
+		return nil
+	}
+	return tree.root.minimum()
+}
+
+// last returns the largest node in the tree.
+func (tree *redBlackTree[K, V]) last() *redBlackNode[K, V] {
+	if tree.root == nil {
+		return nil
+	}
+	return tree.root.maximum()
+}
+
+// ceiling returns the smallest node with a key greater than (or equal to, if
+// inclusive) the given key.
+func (tree *redBlackTree[K, V]) ceiling(key K, inclusive bool) *redBlackNode[K, V] {
+	var best *redBlackNode[K, V]
+	for current := tree.root; current != nil; {
+		cmp := tree.comparator.Compare(key, current.key)
+		if cmp < 0 || (cmp == 0 && inclusive) {
+			if cmp == 0 {
+				return current
+			}
+			best = current
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return best
+}
+
+// floor returns the largest node with a key less than (or equal to, if
+// inclusive) the given key.
+func (tree *redBlackTree[K, V]) floor(key K, inclusive bool) *redBlackNode[K, V] {
+	var best *redBlackNode[K, V]
+	for current := tree.root; current != nil; {
+		cmp := tree.comparator.Compare(key, current.key)
+		if cmp > 0 || (cmp == 0 && inclusive) {
+			if cmp == 0 {
+				return current
+			}
+			best = current
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+	return best
+}
+
+// red black tree balancing
+
+func (tree *redBlackTree[K, V]) fixAfterInsert(node *redBlackNode[K, V]) {
+	for node != tree.root && isRed(node.parent) {
+		parent := node.parent
+		grandparent := parent.parent
+
+		if parent == grandparent.left {
+			uncle := grandparent.right
+			if isRed(uncle) {
+				parent.red = false
+				uncle.red = false
+				grandparent.red = true
+				node = grandparent
+				continue
+			}
+
+			if node == parent.right {
+				node = parent
+				tree.rotateLeft(node)
+				parent = node.parent
+			}
+			parent.red = false
+			grandparent.red = true
+			tree.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+			if isRed(uncle) {
+				parent.red = false
+				uncle.red = false
+				grandparent.red = true
+				node = grandparent
+				continue
+			}
+
+			if node == parent.left {
+				node = parent
+				tree.rotateRight(node)
+				parent = node.parent
+			}
+			parent.red = false
+			grandparent.red = true
+			tree.rotateLeft(grandparent)
+		}
+	}
+	tree.root.red = false
+}
+
+func (tree *redBlackTree[K, V]) fixAfterDelete(node *redBlackNode[K, V]) {
+	for node != tree.root && !isRed(node) {
+		parent := node.parent
+
+		if node == parent.left {
+			sibling := parent.right
+			if isRed(sibling) {
+				sibling.red = false
+				parent.red = true
+				tree.rotateLeft(parent)
+				sibling = parent.right
+			}
+
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node = parent
+				continue
+			}
+
+			if !isRed(sibling.right) {
+				sibling.left.red = false
+				sibling.red = true
+				tree.rotateRight(sibling)
+				sibling = parent.right
+			}
+			sibling.red = parent.red
+			parent.red = false
+			sibling.right.red = false
+			tree.rotateLeft(parent)
+			node = tree.root
+		} else {
+			sibling := parent.left
+			if isRed(sibling) {
+				sibling.red = false
+				parent.red = true
+				tree.rotateRight(parent)
+				sibling = parent.left
+			}
+
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node = parent
+				continue
+			}
+
+			if !isRed(sibling.left) {
+				sibling.right.red = false
+				sibling.red = true
+				tree.rotateLeft(sibling)
+				sibling = parent.left
+			}
+			sibling.red = parent.red
+			parent.red = false
+			sibling.left.red = false
+			tree.rotateRight(parent)
+			node = tree.root
+		}
+	}
+	node.red = false
+}
+
+func (tree *redBlackTree[K, V]) rotateLeft(node *redBlackNode[K, V]) {
+	right := node.right
+	node.right = right.left
+	if right.left != nil {
+		right.left.parent = node
+	}
+	right.parent = node.parent
+	tree.replaceChild(node, right)
+	right.left = node
+	node.parent = right
+}
+
+func (tree *redBlackTree[K, V]) rotateRight(node *redBlackNode[K, V]) {
+	left := node.left
+	node.left = left.right
+	if left.right != nil {
+		left.right.parent = node
+	}
+	left.parent = node.parent
+	tree.replaceChild(node, left)
+	left.right = node
+	node.parent = left
+}
+
+// replaceChild points the parent of node at replacement instead. The parent
+// pointer of replacement is left for the caller to manage.
+func (tree *redBlackTree[K, V]) replaceChild(node, replacement *redBlackNode[K, V]) {
+	switch {
+	case node.parent == nil:
+		tree.root = replacement
+	case node == node.parent.left:
+		node.parent.left = replacement
+	default:
+		node.parent.right = replacement
+	}
+}
+
+func isRed[K objects.Object, V any](node *redBlackNode[K, V]) bool {
+	return node != nil && node.red
+}
+
+// red black node
+
+func (node *redBlackNode[K, V]) minimum() *redBlackNode[K, V] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+func (node *redBlackNode[K, V]) maximum() *redBlackNode[K, V] {
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+func (node *redBlackNode[K, V]) successor() *redBlackNode[K, V] {
+	if node.right != nil {
+		return node.right.minimum()
+	}
+
+	current := node
+	parent := node.parent
+	for parent != nil && current == parent.right {
+		current = parent
+		parent = parent.parent
+	}
+	return parent
+}
+
+func (node *redBlackNode[K, V]) predecessor() *redBlackNode[K, V] {
+	if node.left != nil {
+		return node.left.maximum()
+	}
+
+	current := node
+	parent := node.parent
+	for parent != nil && current == parent.left {
+		current = parent
+		parent = parent.parent
+	}
+	return parent
+}
+
+// bounded navigation
+
+// boundedFirst returns the smallest node within the given bounds.
+func (tree *redBlackTree[K, V]) boundedFirst(bounds sortedBounds[K]) *redBlackNode[K, V] {
+	var node *redBlackNode[K, V]
+	if bounds.hasFrom {
+		node = tree.ceiling(bounds.from, bounds.fromInclusive)
+	} else {
+		node = tree.first()
+	}
+	if node == nil || bounds.tooHigh(tree.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedLast returns the largest node within the given bounds.
+func (tree *redBlackTree[K, V]) boundedLast(bounds sortedBounds[K]) *redBlackNode[K, V] {
+	var node *redBlackNode[K, V]
+	if bounds.hasTo {
+		node = tree.floor(bounds.to, bounds.toInclusive)
+	} else {
+		node = tree.last()
+	}
+	if node == nil || bounds.tooLow(tree.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedCeiling returns the result of ceiling restricted to the given bounds.
+func (tree *redBlackTree[K, V]) boundedCeiling(bounds sortedBounds[K], key K, inclusive bool) *redBlackNode[K, V] {
+	if bounds.tooLow(tree.comparator, key) {
+		// Everything in range is above the key.
+		return tree.boundedFirst(bounds)
+	}
+	node := tree.ceiling(key, inclusive)
+	if node == nil || bounds.tooHigh(tree.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedFloor returns the result of floor restricted to the given bounds.
+func (tree *redBlackTree[K, V]) boundedFloor(bounds sortedBounds[K], key K, inclusive bool) *redBlackNode[K, V] {
+	if bounds.tooHigh(tree.comparator, key) {
+		// Everything in range is below the key.
+		return tree.boundedLast(bounds)
+	}
+	node := tree.floor(key, inclusive)
+	if node == nil || bounds.tooLow(tree.comparator, node.key) {
+		return nil
+	}
+	return node
+}
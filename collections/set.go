@@ -1,8 +1,79 @@
 package collections
 
-import "github.com/pasataleo/go-objects/objects"
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pasataleo/go-objects/objects"
+)
 
 // Set is a collection of unique objects.
 type Set[O objects.Object] interface {
 	Collection[O]
+
+	// Union returns a new set holding every element that is in either this set or the given set.
+	Union(other Set[O]) Set[O]
+
+	// Intersection returns a new set holding every element that is in both this set and the given set.
+	Intersection(other Set[O]) Set[O]
+
+	// Difference returns a new set holding every element of this set that isn't in the given set.
+	Difference(other Set[O]) Set[O]
+
+	// SymmetricDifference returns a new set holding every element that is in exactly one of this set and the given
+	// set.
+	SymmetricDifference(other Set[O]) Set[O]
+
+	// IsSubsetOf returns true if every element of this set is also in the given set.
+	IsSubsetOf(other Set[O]) bool
+
+	// IsSupersetOf returns true if every element of the given set is also in this set.
+	IsSupersetOf(other Set[O]) bool
+
+	// IsDisjoint returns true if this set and the given set have no elements in common.
+	IsDisjoint(other Set[O]) bool
+}
+
+func setEquals[O objects.Object](target Set[O], right any) bool {
+	other, ok := right.(Set[O])
+	if !ok {
+		return false
+	}
+
+	if target.Size() != other.Size() {
+		return false
+	}
+
+	for iterator := target.Iterator(); iterator.HasNext(); {
+		if !other.Contains(iterator.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+func setHashCode[O objects.Object](target Set[O]) uint64 {
+	hash := uint64(13001)
+	for iterator := target.Iterator(); iterator.HasNext(); {
+		hash = hash * iterator.Next().HashCode()
+	}
+	return hash
+}
+
+func setString[O objects.Object](target Set[O]) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+
+	first := true
+	for iterator := target.Iterator(); iterator.HasNext(); {
+		value := iterator.Next()
+		if first {
+			buffer.WriteString(value.String())
+		} else {
+			buffer.WriteString(fmt.Sprintf(",%s", value))
+		}
+		first = false
+	}
+	buffer.WriteString("]")
+	return buffer.String()
 }
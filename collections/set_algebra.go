@@ -0,0 +1,113 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// Union returns a new set holding every element that is in either of the given sets.
+//
+// The result has the same kind of implementation as left: a sorted set with the same comparator if left is sorted, a
+// linked hash set if left is one, and a hash set otherwise. The same applies to Intersection, Difference and
+// SymmetricDifference.
+func Union[O objects.Object](left, right Set[O]) Set[O] {
+	result := newSetLike(left)
+	for value := range left.Elems() {
+		_ = result.Add(value)
+	}
+	for value := range right.Elems() {
+		_ = result.Add(value)
+	}
+	return result
+}
+
+// Intersection returns a new set holding every element that is in both of the given sets. Only the smaller of the
+// two sets is iterated.
+func Intersection[O objects.Object](left, right Set[O]) Set[O] {
+	result := newSetLike(left)
+	smaller, larger := bySize(left, right)
+	for value := range smaller.Elems() {
+		if larger.Contains(value) {
+			_ = result.Add(value)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set holding every element of left that isn't in right.
+func Difference[O objects.Object](left, right Set[O]) Set[O] {
+	result := newSetLike(left)
+	for value := range left.Elems() {
+		if !right.Contains(value) {
+			_ = result.Add(value)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set holding every element that is in exactly one of the given sets.
+func SymmetricDifference[O objects.Object](left, right Set[O]) Set[O] {
+	result := newSetLike(left)
+	for value := range left.Elems() {
+		if !right.Contains(value) {
+			_ = result.Add(value)
+		}
+	}
+	for value := range right.Elems() {
+		if !left.Contains(value) {
+			_ = result.Add(value)
+		}
+	}
+	return result
+}
+
+// IsSubset returns true if every element of subset is also in superset.
+func IsSubset[O objects.Object](subset, superset Set[O]) bool {
+	if subset.Size() > superset.Size() {
+		return false
+	}
+	for value := range subset.Elems() {
+		if !superset.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every element of subset is also in superset.
+func IsSuperset[O objects.Object](superset, subset Set[O]) bool {
+	return IsSubset(subset, superset)
+}
+
+// IsDisjoint returns true if the given sets have no elements in common. Only the smaller of the two sets is iterated.
+func IsDisjoint[O objects.Object](left, right Set[O]) bool {
+	smaller, larger := bySize(left, right)
+	for value := range smaller.Elems() {
+		if larger.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// newSetLike returns a new, empty, set of the same kind as the given set. Wrappers are looked through, so the result
+// is always modifiable.
+func newSetLike[O objects.Object](set Set[O]) Set[O] {
+	switch set := set.(type) {
+	case SortedSet[O]:
+		return NewTreeSetT[O](set.Comparator())
+	case *linkedHashSet[O]:
+		return newLinkedHashSet[O]()
+	case *unmodifiableSet[O]:
+		return newSetLike(set.collection.(Set[O]))
+	case *synchronizedSet[O]:
+		return newSetLike(set.collection.(Set[O]))
+	default:
+		return NewHashSet[O]()
+	}
+}
+
+// bySize returns the given sets with the smaller one first.
+func bySize[O objects.Object](left, right Set[O]) (Set[O], Set[O]) {
+	if right.Size() < left.Size() {
+		return right, left
+	}
+	return left, right
+}
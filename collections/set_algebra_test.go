@@ -0,0 +1,102 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func ints(values ...int) []*objects.Int {
+	var objs []*objects.Int
+	for _, value := range values {
+		objs = append(objs, objects.WrapInt(value))
+	}
+	return objs
+}
+
+func hashSetOf(values ...int) Set[*objects.Int] {
+	set := NewHashSet[*objects.Int]()
+	for _, value := range ints(values...) {
+		_ = set.Add(value)
+	}
+	return set
+}
+
+func TestSetAlgebra(t *testing.T) {
+	left := hashSetOf(1, 2, 3, 4)
+	right := hashSetOf(3, 4, 5)
+
+	tests.Execute(Union(left, right).Equals(hashSetOf(1, 2, 3, 4, 5))).Equal(t, true)
+	tests.Execute(Intersection(left, right).Equals(hashSetOf(3, 4))).Equal(t, true)
+	tests.Execute(Difference(left, right).Equals(hashSetOf(1, 2))).Equal(t, true)
+	tests.Execute(Difference(right, left).Equals(hashSetOf(5))).Equal(t, true)
+	tests.Execute(SymmetricDifference(left, right).Equals(hashSetOf(1, 2, 5))).Equal(t, true)
+
+	// The operands are left untouched.
+	tests.Execute(left.Equals(hashSetOf(1, 2, 3, 4))).Equal(t, true)
+	tests.Execute(right.Equals(hashSetOf(3, 4, 5))).Equal(t, true)
+
+	tests.Execute(IsSubset(hashSetOf(3, 4), left)).Equal(t, true)
+	tests.Execute(IsSubset(right, left)).Equal(t, false)
+	tests.Execute(IsSubset(hashSetOf(), left)).Equal(t, true)
+	tests.Execute(IsSuperset(left, hashSetOf(1, 4))).Equal(t, true)
+	tests.Execute(IsSuperset(left, right)).Equal(t, false)
+	tests.Execute(IsDisjoint(left, right)).Equal(t, false)
+	tests.Execute(IsDisjoint(left, hashSetOf(5, 6))).Equal(t, true)
+	tests.Execute(IsDisjoint(left, hashSetOf())).Equal(t, true)
+}
+
+func TestSetAlgebra_Methods(t *testing.T) {
+	sets := map[string]func(values ...int) Set[*objects.Int]{
+		"hash": hashSetOf,
+		"linked": func(values ...int) Set[*objects.Int] {
+			return NewLinkedHashSet[*objects.Int](ints(values...)...)
+		},
+		"tree": func(values ...int) Set[*objects.Int] {
+			return NewTreeSetT[*objects.Int](ascendingInts(), ints(values...)...)
+		},
+		"synchronized": func(values ...int) Set[*objects.Int] {
+			return SynchronizedSet[*objects.Int](hashSetOf(values...))
+		},
+		"unmodifiable": func(values ...int) Set[*objects.Int] {
+			return UnmodifiableSet[*objects.Int](hashSetOf(values...))
+		},
+	}
+
+	for name, init := range sets {
+		t.Run(name, func(t *testing.T) {
+			left := init(1, 2, 3)
+			right := hashSetOf(2, 3, 4)
+
+			tests.Execute(left.Union(right).Equals(hashSetOf(1, 2, 3, 4))).Equal(t, true)
+			tests.Execute(left.Intersection(right).Equals(hashSetOf(2, 3))).Equal(t, true)
+			tests.Execute(left.Difference(right).Equals(hashSetOf(1))).Equal(t, true)
+			tests.Execute(left.SymmetricDifference(right).Equals(hashSetOf(1, 4))).Equal(t, true)
+			tests.Execute(left.IsSubsetOf(hashSetOf(1, 2, 3, 4))).Equal(t, true)
+			tests.Execute(left.IsSupersetOf(hashSetOf(1, 3))).Equal(t, true)
+			tests.Execute(left.IsDisjoint(hashSetOf(4, 5))).Equal(t, true)
+
+			// Results are always new, modifiable, sets.
+			tests.ExecuteE(left.Union(right).Add(objects.WrapInt(5))).NoError(t)
+		})
+	}
+}
+
+func TestSetAlgebra_ResultType(t *testing.T) {
+	tree := NewTreeSetT[*objects.Int](ascendingInts(), ints(5, 1, 3)...)
+
+	// A sorted left operand gives a sorted result, with the same ordering.
+	union, ok := Union[*objects.Int](tree, hashSetOf(4, 2)).(SortedSet[*objects.Int])
+	tests.Execute(ok).Equal(t, true, tests.Fatal)
+	tests.Execute(union.String()).Equal(t, "[1,2,3,4,5]")
+
+	// A linked left operand keeps its iteration order.
+	linked := NewLinkedHashSet[*objects.Int](ints(3, 1, 2)...)
+	tests.Execute(Difference(linked, hashSetOf(1)).String()).Equal(t, "[3,2]")
+
+	// Wrappers are looked through.
+	wrapped := UnmodifiableSet[*objects.Int](tree)
+	_, ok = Intersection(wrapped, hashSetOf(1, 3)).(SortedSet[*objects.Int])
+	tests.Execute(ok).Equal(t, true)
+}
@@ -0,0 +1,257 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// setView is a read-only set whose contents are computed from other sets whenever they are read, so it always
+// reflects the current contents of those sets.
+type setView[O objects.Object] struct {
+	contains func(value O) bool
+	elems    iter.Seq[O]
+}
+
+// UnionView returns a read-only view of the elements that are in either of the given sets.
+//
+// The view holds no elements of its own. Contains checks the underlying sets directly, while Size and iteration walk
+// them, so the view always reflects their current contents. The same applies to IntersectionView, DifferenceView and
+// SymmetricDifferenceView.
+func UnionView[O objects.Object](left, right Set[O]) Set[O] {
+	return &setView[O]{
+		contains: func(value O) bool {
+			return left.Contains(value) || right.Contains(value)
+		},
+		elems: func(yield func(O) bool) {
+			for value := range left.Elems() {
+				if !yield(value) {
+					return
+				}
+			}
+			for value := range right.Elems() {
+				if !left.Contains(value) && !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// IntersectionView returns a read-only view of the elements that are in both of the given sets. Iteration walks
+// whichever set is smaller at the time.
+func IntersectionView[O objects.Object](left, right Set[O]) Set[O] {
+	return &setView[O]{
+		contains: func(value O) bool {
+			return left.Contains(value) && right.Contains(value)
+		},
+		elems: func(yield func(O) bool) {
+			smaller, larger := bySize(left, right)
+			for value := range smaller.Elems() {
+				if larger.Contains(value) && !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// DifferenceView returns a read-only view of the elements of left that aren't in right.
+func DifferenceView[O objects.Object](left, right Set[O]) Set[O] {
+	return &setView[O]{
+		contains: func(value O) bool {
+			return left.Contains(value) && !right.Contains(value)
+		},
+		elems: func(yield func(O) bool) {
+			for value := range left.Elems() {
+				if !right.Contains(value) && !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// SymmetricDifferenceView returns a read-only view of the elements that are in exactly one of the given sets.
+func SymmetricDifferenceView[O objects.Object](left, right Set[O]) Set[O] {
+	return &setView[O]{
+		contains: func(value O) bool {
+			return left.Contains(value) != right.Contains(value)
+		},
+		elems: func(yield func(O) bool) {
+			for value := range left.Elems() {
+				if !right.Contains(value) && !yield(value) {
+					return
+				}
+			}
+			for value := range right.Elems() {
+				if !left.Contains(value) && !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (view *setView[O]) Equals(other any) bool {
+	return setEquals[O](view, other)
+}
+
+// HashCode implements objects.Object.
+func (view *setView[O]) HashCode() uint64 {
+	return setHashCode[O](view)
+}
+
+// String implements objects.Object.
+func (view *setView[O]) String() string {
+	return setString[O](view)
+}
+
+// MarshalJSON implements objects.Object.
+func (view *setView[O]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(view.slice())
+}
+
+// UnmarshalJSON implements objects.Object. It always returns an ErrorCodeUnsupported error.
+func (view *setView[O]) UnmarshalJSON(bytes []byte) error {
+	return unsupported()
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot of the view taken when it is created.
+func (view *setView[O]) Iterator() objects.Iterator[O] {
+	return objects.NewSliceIterator(view.slice())
+}
+
+// Collection implementation
+
+// Elems implements Collection. The sequence reads the underlying sets as it goes.
+func (view *setView[O]) Elems() iter.Seq[O] {
+	return view.elems
+}
+
+// Add implements Collection. It always returns an ErrorCodeUnsupported error.
+func (view *setView[O]) Add(value O) error {
+	return unsupported()
+}
+
+// AddAll implements Collection. It always returns an ErrorCodeUnsupported error.
+func (view *setView[O]) AddAll(values Collection[O]) error {
+	return unsupported()
+}
+
+// Remove implements Collection. It always returns an ErrorCodeUnsupported error.
+func (view *setView[O]) Remove(value O) error {
+	return unsupported()
+}
+
+// RemoveAll implements Collection. It always returns an ErrorCodeUnsupported error.
+func (view *setView[O]) RemoveAll(values Collection[O]) error {
+	return unsupported()
+}
+
+// Contains implements Collection.
+func (view *setView[O]) Contains(value O) bool {
+	return view.contains(value)
+}
+
+// ContainsAll implements Collection.
+func (view *setView[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](view, values)
+}
+
+// RemoveIf implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (view *setView[O]) RemoveIf(condition func(value O) bool) int {
+	panic(unsupported())
+}
+
+// RetainAll implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (view *setView[O]) RetainAll(values Collection[O]) int {
+	panic(unsupported())
+}
+
+// Copy implements Collection. The copy is an unmodifiable hash set holding the current contents of the view.
+func (view *setView[O]) Copy() Collection[O] {
+	set := NewHashSet[O]()
+	for value := range view.elems {
+		_ = set.Add(value)
+	}
+	return UnmodifiableSet[O](set)
+}
+
+// Size implements Collection. The size is counted by walking the view.
+func (view *setView[O]) Size() int {
+	size := 0
+	for range view.elems {
+		size = size + 1
+	}
+	return size
+}
+
+// IsEmpty implements Collection.
+func (view *setView[O]) IsEmpty() bool {
+	for range view.elems {
+		return false
+	}
+	return true
+}
+
+// Clear implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (view *setView[O]) Clear() {
+	panic(unsupported())
+}
+
+// Set implementation
+
+// Union implements Set.
+func (view *setView[O]) Union(other Set[O]) Set[O] {
+	return Union[O](view, other)
+}
+
+// Intersection implements Set.
+func (view *setView[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](view, other)
+}
+
+// Difference implements Set.
+func (view *setView[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](view, other)
+}
+
+// SymmetricDifference implements Set.
+func (view *setView[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](view, other)
+}
+
+// IsSubsetOf implements Set.
+func (view *setView[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](view, other)
+}
+
+// IsSupersetOf implements Set.
+func (view *setView[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](view, other)
+}
+
+// IsDisjoint implements Set.
+func (view *setView[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](view, other)
+}
+
+// set view
+
+func (view *setView[O]) readOnly() {}
+
+// slice returns the current contents of the view.
+func (view *setView[O]) slice() []O {
+	var values []O
+	for value := range view.elems {
+		values = append(values, value)
+	}
+	return values
+}
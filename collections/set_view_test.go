@@ -0,0 +1,71 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSetView(t *testing.T) {
+	left := hashSetOf(1, 2, 3, 4)
+	right := hashSetOf(3, 4, 5)
+
+	union := UnionView(left, right)
+	intersection := IntersectionView(left, right)
+	difference := DifferenceView(left, right)
+	symmetric := SymmetricDifferenceView(left, right)
+
+	tests.Execute(union.Equals(hashSetOf(1, 2, 3, 4, 5))).Equal(t, true)
+	tests.Execute(intersection.Equals(hashSetOf(3, 4))).Equal(t, true)
+	tests.Execute(difference.Equals(hashSetOf(1, 2))).Equal(t, true)
+	tests.Execute(symmetric.Equals(hashSetOf(1, 2, 5))).Equal(t, true)
+
+	tests.Execute(union.Size()).Equal(t, 5)
+	tests.Execute(intersection.Contains(objects.WrapInt(3))).Equal(t, true)
+	tests.Execute(difference.Contains(objects.WrapInt(3))).Equal(t, false)
+	tests.Execute(symmetric.Contains(objects.WrapInt(5))).Equal(t, true)
+
+	// The views follow changes to the underlying sets.
+	tests.ExecuteE(left.Add(objects.WrapInt(5))).NoError(t)
+	tests.ExecuteE(right.Remove(objects.WrapInt(3))).NoError(t)
+	tests.Execute(union.Size()).Equal(t, 5)
+	tests.Execute(intersection.Equals(hashSetOf(4, 5))).Equal(t, true)
+	tests.Execute(difference.Equals(hashSetOf(1, 2, 3))).Equal(t, true)
+	tests.Execute(symmetric.Equals(hashSetOf(1, 2, 3))).Equal(t, true)
+
+	tests.ExecuteE(right.Add(objects.WrapInt(1))).NoError(t)
+	tests.ExecuteE(right.Add(objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(right.Add(objects.WrapInt(3))).NoError(t)
+	tests.Execute(difference.IsEmpty()).Equal(t, true)
+	tests.Execute(symmetric.IsEmpty()).Equal(t, true)
+}
+
+func TestSetView_ReadOnly(t *testing.T) {
+	view := UnionView(hashSetOf(1), hashSetOf(2))
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.ExecuteE(view.Add(objects.WrapInt(3))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.Remove(objects.WrapInt(1))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.UnmarshalJSON([]byte(`[3]`))).ErrorCode(t, ErrorCodeUnsupported)
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.Clear()
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.RemoveIf(func(value *objects.Int) bool { return true })
+	})
+
+	// Copies hold the contents at the time, and stay read-only.
+	copied := view.Copy()
+	tests.Execute(IsReadOnly(copied)).Equal(t, true)
+	tests.Execute(copied.Equals(hashSetOf(1, 2))).Equal(t, true)
+
+	data, err := json.Marshal(IntersectionView(hashSetOf(1, 2), hashSetOf(2, 3)))
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(string(data)).Equal(t, "[2]")
+
+	// Set algebra works on views too.
+	tests.Execute(view.Union(hashSetOf(3)).Equals(hashSetOf(1, 2, 3))).Equal(t, true)
+	tests.Execute(view.IsSupersetOf(hashSetOf(2))).Equal(t, true)
+}
@@ -0,0 +1,395 @@
+package collections
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// skipListMaxLevel is the number of levels in a skip list, which comfortably
+// covers any number of nodes that fits in memory.
+const skipListMaxLevel = 32
+
+// skipList is a lazy concurrent skip list. Readers never lock: they follow the
+// atomic next pointers and ignore nodes that are not yet fully linked or have
+// been marked for removal. Writers lock only the nodes whose pointers they
+// change, and check those nodes haven't changed since they were found before
+// going ahead.
+//
+// Locks are always taken in decreasing key order, a node before its
+// predecessors and a lower level's predecessor before a higher level's, so
+// writers can't deadlock.
+type skipList[K, V objects.Object] struct {
+	comparator objects.Comparator[K]
+
+	// head is a sentinel that sorts before every key, and has a pointer at
+	// every level.
+	head *skipListNode[K, V]
+	size atomic.Int64
+}
+
+type skipListNode[K, V objects.Object] struct {
+	key   K
+	value atomic.Pointer[V]
+
+	// next has an entry for each level the node is linked into.
+	next []atomic.Pointer[skipListNode[K, V]]
+
+	lock sync.Mutex
+
+	// linked is set once the node is linked into every level, and marked once
+	// it is being removed. A node is only visible while it is linked and not
+	// marked.
+	linked atomic.Bool
+	marked atomic.Bool
+}
+
+func newSkipList[K, V objects.Object](comparator objects.Comparator[K]) *skipList[K, V] {
+	return &skipList[K, V]{
+		comparator: comparator,
+		head: &skipListNode[K, V]{
+			next: make([]atomic.Pointer[skipListNode[K, V]], skipListMaxLevel),
+		},
+	}
+}
+
+// find returns the visible node for the given key, or nil if there isn't one.
+func (list *skipList[K, V]) find(key K) *skipListNode[K, V] {
+	pred := list.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		for curr := pred.next[level].Load(); curr != nil; curr = pred.next[level].Load() {
+			cmp := list.comparator.Compare(curr.key, key)
+			if cmp > 0 {
+				break
+			}
+			if cmp == 0 {
+				if curr.visible() {
+					return curr
+				}
+				return nil
+			}
+			pred = curr
+		}
+	}
+	return nil
+}
+
+// update calls fn exactly once with the current value for the key, and true if the key is present, and then stores
+// the value fn returns or, if fn returns false, removes the key. It happens atomically with respect to every other
+// update of the same key. fn is called with locks held, so it must not access the list.
+func (list *skipList[K, V]) update(key K, fn func(current V, present bool) (V, bool)) {
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	levels := 0
+	for {
+		found := list.search(key, &preds, &succs)
+		if found >= 0 {
+			node := succs[found]
+			if !node.linked.Load() || node.marked.Load() {
+				// Another writer is still linking or unlinking the node, so
+				// wait for it to finish.
+				continue
+			}
+
+			node.lock.Lock()
+			if node.marked.Load() {
+				node.lock.Unlock()
+				continue
+			}
+
+			value, keep := fn(node.load(), true)
+			if keep {
+				node.store(value)
+			} else {
+				node.marked.Store(true)
+				list.unlink(node)
+			}
+			node.lock.Unlock()
+			return
+		}
+
+		if levels == 0 {
+			levels = randomSkipListLevels()
+		}
+		if list.link(key, levels, &preds, &succs, fn) {
+			return
+		}
+	}
+}
+
+// search fills preds and succs with the nodes either side of the key at every level, and returns the highest level
+// the key was found at, or -1 if it wasn't found at all.
+func (list *skipList[K, V]) search(key K, preds, succs *[skipListMaxLevel]*skipListNode[K, V]) int {
+	found := -1
+	pred := list.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && list.comparator.Compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found < 0 && curr != nil && list.comparator.Compare(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// link calls fn for a key that isn't in the list, and inserts a node with the given number of levels if fn returns
+// true. It returns false, without calling fn, if the list changed around the key since it was searched for.
+func (list *skipList[K, V]) link(key K, levels int, preds, succs *[skipListMaxLevel]*skipListNode[K, V], fn func(current V, present bool) (V, bool)) bool {
+	locked := list.lock(levels, preds, func(level int) bool {
+		succ := succs[level]
+		return preds[level].next[level].Load() == succ && (succ == nil || !succ.marked.Load())
+	})
+	defer list.unlock(locked, preds)
+	if locked < levels {
+		return false
+	}
+
+	var obj V
+	value, keep := fn(obj, false)
+	if !keep {
+		return true
+	}
+
+	node := &skipListNode[K, V]{
+		key:  key,
+		next: make([]atomic.Pointer[skipListNode[K, V]], levels),
+	}
+	node.store(value)
+	for level := range levels {
+		node.next[level].Store(succs[level])
+	}
+	for level := range levels {
+		preds[level].next[level].Store(node)
+	}
+	node.linked.Store(true)
+	list.size.Add(1)
+	return true
+}
+
+// unlink removes a node that the caller has locked and marked from every level.
+func (list *skipList[K, V]) unlink(node *skipListNode[K, V]) {
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	levels := len(node.next)
+	for {
+		list.search(node.key, &preds, &succs)
+		locked := list.lock(levels, &preds, func(level int) bool {
+			return preds[level].next[level].Load() == node
+		})
+		if locked == levels {
+			for level := levels - 1; level >= 0; level-- {
+				preds[level].next[level].Store(node.next[level].Load())
+			}
+			list.unlock(locked, &preds)
+			list.size.Add(-1)
+			return
+		}
+		list.unlock(locked, &preds)
+	}
+}
+
+// lock locks the predecessors from the bottom level up, stopping at the first level where the predecessor is marked
+// or valid returns false. It returns the number of levels it checked successfully. Predecessors shared by several
+// levels are only locked once.
+func (list *skipList[K, V]) lock(levels int, preds *[skipListMaxLevel]*skipListNode[K, V], valid func(level int) bool) int {
+	for level := range levels {
+		pred := preds[level]
+		if level == 0 || pred != preds[level-1] {
+			pred.lock.Lock()
+		}
+		if pred.marked.Load() || !valid(level) {
+			// Release this level now, so the caller only has to release the
+			// levels below it.
+			list.unlockLevel(level, preds)
+			return level
+		}
+	}
+	return levels
+}
+
+// unlock releases the locks taken on the given number of levels of predecessors.
+func (list *skipList[K, V]) unlock(levels int, preds *[skipListMaxLevel]*skipListNode[K, V]) {
+	for level := range levels {
+		list.unlockLevel(level, preds)
+	}
+}
+
+// unlockLevel releases the lock taken on the given level, unless it is shared with the level below and so is released
+// with that level.
+func (list *skipList[K, V]) unlockLevel(level int, preds *[skipListMaxLevel]*skipListNode[K, V]) {
+	if level == 0 || preds[level] != preds[level-1] {
+		preds[level].lock.Unlock()
+	}
+}
+
+// clear removes every node.
+func (list *skipList[K, V]) clear() {
+	for node := list.first(); node != nil; node = list.first() {
+		list.update(node.key, func(current V, present bool) (V, bool) {
+			return current, false
+		})
+	}
+}
+
+// navigation
+
+// first returns the visible node with the smallest key.
+func (list *skipList[K, V]) first() *skipListNode[K, V] {
+	return visibleFrom(list.head.next[0].Load())
+}
+
+// last returns the visible node with the largest key.
+func (list *skipList[K, V]) last() *skipListNode[K, V] {
+	pred := list.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		for curr := pred.next[level].Load(); curr != nil; curr = pred.next[level].Load() {
+			pred = curr
+		}
+	}
+	if pred == list.head {
+		return nil
+	}
+	if pred.visible() {
+		return pred
+	}
+	return list.floor(pred.key, false)
+}
+
+// ceiling returns the visible node with the smallest key greater than (or equal to, if inclusive) the given key.
+func (list *skipList[K, V]) ceiling(key K, inclusive bool) *skipListNode[K, V] {
+	pred := list.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		for curr := pred.next[level].Load(); curr != nil; curr = pred.next[level].Load() {
+			cmp := list.comparator.Compare(curr.key, key)
+			if cmp > 0 || (cmp == 0 && inclusive) {
+				break
+			}
+			pred = curr
+		}
+	}
+	return visibleFrom(pred.next[0].Load())
+}
+
+// floor returns the visible node with the largest key less than (or equal to, if inclusive) the given key.
+func (list *skipList[K, V]) floor(key K, inclusive bool) *skipListNode[K, V] {
+	for {
+		pred := list.head
+		for level := skipListMaxLevel - 1; level >= 0; level-- {
+			for curr := pred.next[level].Load(); curr != nil; curr = pred.next[level].Load() {
+				cmp := list.comparator.Compare(curr.key, key)
+				if cmp > 0 || (cmp == 0 && !inclusive) {
+					break
+				}
+				pred = curr
+			}
+		}
+		if pred == list.head {
+			return nil
+		}
+		if pred.visible() {
+			return pred
+		}
+
+		// The node is being inserted or removed, so look below it instead.
+		key, inclusive = pred.key, false
+	}
+}
+
+// successor returns the visible node following the given node.
+func (list *skipList[K, V]) successor(node *skipListNode[K, V]) *skipListNode[K, V] {
+	return visibleFrom(node.next[0].Load())
+}
+
+// bounded navigation
+
+// boundedFirst returns the smallest visible node within the given bounds.
+func (list *skipList[K, V]) boundedFirst(bounds sortedBounds[K]) *skipListNode[K, V] {
+	var node *skipListNode[K, V]
+	if bounds.hasFrom {
+		node = list.ceiling(bounds.from, bounds.fromInclusive)
+	} else {
+		node = list.first()
+	}
+	if node == nil || bounds.tooHigh(list.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedLast returns the largest visible node within the given bounds.
+func (list *skipList[K, V]) boundedLast(bounds sortedBounds[K]) *skipListNode[K, V] {
+	var node *skipListNode[K, V]
+	if bounds.hasTo {
+		node = list.floor(bounds.to, bounds.toInclusive)
+	} else {
+		node = list.last()
+	}
+	if node == nil || bounds.tooLow(list.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedCeiling returns the result of ceiling restricted to the given bounds.
+func (list *skipList[K, V]) boundedCeiling(bounds sortedBounds[K], key K, inclusive bool) *skipListNode[K, V] {
+	if bounds.tooLow(list.comparator, key) {
+		// Everything in range is above the key.
+		return list.boundedFirst(bounds)
+	}
+	node := list.ceiling(key, inclusive)
+	if node == nil || bounds.tooHigh(list.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// boundedFloor returns the result of floor restricted to the given bounds.
+func (list *skipList[K, V]) boundedFloor(bounds sortedBounds[K], key K, inclusive bool) *skipListNode[K, V] {
+	if bounds.tooHigh(list.comparator, key) {
+		// Everything in range is below the key.
+		return list.boundedLast(bounds)
+	}
+	node := list.floor(key, inclusive)
+	if node == nil || bounds.tooLow(list.comparator, node.key) {
+		return nil
+	}
+	return node
+}
+
+// skip list node
+
+func (node *skipListNode[K, V]) visible() bool {
+	return node.linked.Load() && !node.marked.Load()
+}
+
+func (node *skipListNode[K, V]) load() V {
+	return *node.value.Load()
+}
+
+func (node *skipListNode[K, V]) store(value V) {
+	node.value.Store(&value)
+}
+
+// visibleFrom returns the first visible node at or after the given node on the bottom level. Nodes that are being
+// removed still point forward, so the walk can carry on past them.
+func visibleFrom[K, V objects.Object](node *skipListNode[K, V]) *skipListNode[K, V] {
+	for node != nil && !node.visible() {
+		node = node.next[0].Load()
+	}
+	return node
+}
+
+// randomSkipListLevels returns the number of levels for a new node. Each level holds about a quarter of the nodes of
+// the level below it.
+func randomSkipListLevels() int {
+	levels := 1
+	for bits := rand.Uint64(); levels < skipListMaxLevel && bits&3 == 0; bits >>= 2 {
+		levels++
+	}
+	return levels
+}
@@ -0,0 +1,596 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type skipListMap[K, V objects.Object] struct {
+	list *skipList[K, V]
+
+	// bounds restricts the keys visible through this map, which is how the
+	// HeadMap, TailMap and SubMap views share the list of their parent.
+	bounds sortedBounds[K]
+}
+
+// NewSkipListMap creates a new skip list map ordered by the natural ordering of the keys, with the given elements.
+func NewSkipListMap[K objects.ComparableObject[K], V objects.Object](entries ...MapEntry[K, V]) ConcurrentSortedMap[K, V] {
+	return NewSkipListMapT[K, V](objects.ComparableComparator[K](), entries...)
+}
+
+// NewSkipListMapT creates a new skip list map ordered by the given comparator, with the given elements.
+//
+// The map is safe for concurrent use. Reads, including lookups, navigation and iteration, never block. Writes lock
+// only the few nodes around the key they change, so writes to different parts of the map proceed in parallel.
+// Operations on a single key are atomic. Iteration is weakly consistent: it never fails because of concurrent
+// modifications, and reflects some, all or none of the changes made after it started. Equals and Copy are built on
+// iteration, and are weakly consistent in the same way. Size reads a counter for the whole map, which is exact at the
+// moment it is read, but counts the entries of a HeadMap, TailMap or SubMap view by iterating over them.
+func NewSkipListMapT[K, V objects.Object](comparator objects.Comparator[K], entries ...MapEntry[K, V]) ConcurrentSortedMap[K, V] {
+	m := &skipListMap[K, V]{
+		list: newSkipList[K, V](comparator),
+	}
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *skipListMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *skipListMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *skipListMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *skipListMap[K, V]) MarshalJSON() ([]byte, error) {
+	var entries []*mapEntry[K, V]
+	for key, value := range m.Entries() {
+		entries = append(entries, &mapEntry[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *skipListMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator is weakly consistent, so it is never affected by concurrent
+// modifications.
+func (m *skipListMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &skipListMapIterator[K, V]{
+		m:    m,
+		next: m.first(),
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *skipListMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return objects.SequenceFrom[MapEntry[K, V]](m)
+}
+
+// Add implements Collection.
+func (m *skipListMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *skipListMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *skipListMap[K, V]) Remove(value MapEntry[K, V]) error {
+	_, ok := m.DeleteIf(value.GetKey(), func(current V) bool {
+		return value.GetValue().Equals(current)
+	})
+	if !ok {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", value.GetKey())
+	}
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (m *skipListMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *skipListMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	node := m.find(value.GetKey())
+	if node == nil {
+		return false
+	}
+	return value.GetValue().Equals(node.load())
+}
+
+// ContainsAll implements Collection.
+func (m *skipListMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection. The condition is checked and the entry removed atomically, one entry at a time.
+// Calling RemoveIf on a view only considers the entries within the view.
+func (m *skipListMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	removed := 0
+	for node := m.first(); node != nil; node = m.successor(node) {
+		_, ok := m.DeleteIf(node.key, func(current V) bool {
+			return condition(&mapEntry[K, V]{Key: node.key, Value: current})
+		})
+		if ok {
+			removed = removed + 1
+		}
+	}
+	return removed
+}
+
+// RetainAll implements Collection. The given values are copied first, so no locks are held while reading them.
+func (m *skipListMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	if Collection[MapEntry[K, V]](m) == values {
+		return 0
+	}
+	return collectionRetainAll[MapEntry[K, V]](m, setSnapshot(values))
+}
+
+// Copy implements Collection. Copying a view returns a new map holding only the entries within the view.
+func (m *skipListMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	newMap := &skipListMap[K, V]{
+		list: newSkipList[K, V](m.list.comparator),
+	}
+	for key, value := range m.Entries() {
+		_, _ = newMap.PutOrReplace(key, value)
+	}
+	return newMap
+}
+
+// Size implements Collection.
+func (m *skipListMap[K, V]) Size() int {
+	if m.bounds.unbounded() {
+		return int(m.list.size.Load())
+	}
+
+	size := 0
+	for node := m.first(); node != nil; node = m.successor(node) {
+		size++
+	}
+	return size
+}
+
+// IsEmpty implements Collection.
+func (m *skipListMap[K, V]) IsEmpty() bool {
+	return m.first() == nil
+}
+
+// Clear implements Collection. Clearing a view only removes the entries within the view.
+func (m *skipListMap[K, V]) Clear() {
+	if m.bounds.unbounded() {
+		m.list.clear()
+		return
+	}
+
+	for node := m.first(); node != nil; node = m.successor(node) {
+		_, _ = m.DeleteIfPresent(node.key)
+	}
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *skipListMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := m.first(); node != nil; node = m.successor(node) {
+			if !yield(node.key, node.load()) {
+				return
+			}
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *skipListMap[K, V]) ContainsKey(key K) bool {
+	return m.find(key) != nil
+}
+
+// Put implements Map.
+func (m *skipListMap[K, V]) Put(key K, value V) error {
+	if !m.bounds.contains(m.list.comparator, key) {
+		return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "key out of range"), "key", key)
+	}
+
+	if _, present := m.PutIfAbsent(key, value); present {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+	return nil
+}
+
+// Replace implements Map.
+func (m *skipListMap[K, V]) Replace(key K, value V) (V, error) {
+	current, replaced := m.ReplaceIf(key, value, func(V) bool {
+		return true
+	})
+	if !replaced {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return current, nil
+}
+
+// PutOrReplace implements Map. Inserting a key outside the range of a view panics with an ErrorCodeOutOfBounds error,
+// as it does for PutIfAbsent.
+func (m *skipListMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	m.checkBounds(key)
+
+	previous, replaced := value, false
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if present {
+			previous, replaced = current, true
+		}
+		return value, true
+	})
+	return previous, replaced
+}
+
+// Delete implements Map.
+func (m *skipListMap[K, V]) Delete(key K) (V, error) {
+	value, ok := m.DeleteIfPresent(key)
+	if !ok {
+		return value, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *skipListMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	return m.DeleteIf(key, func(V) bool {
+		return true
+	})
+}
+
+// Get implements Map.
+func (m *skipListMap[K, V]) Get(key K) V {
+	node := m.find(key)
+	if node == nil {
+		panic("not found")
+	}
+	return node.load()
+}
+
+// GetSafe implements Map.
+func (m *skipListMap[K, V]) GetSafe(key K) (V, error) {
+	node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return node.load(), nil
+}
+
+// GetOrDefault implements Map.
+func (m *skipListMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	node := m.find(key)
+	if node == nil {
+		return defaultValue
+	}
+	return node.load()
+}
+
+// PutIfAbsent implements Map. Inserting a key outside the range of a view panics with an ErrorCodeOutOfBounds error,
+// as do PutOrReplace, ComputeIfAbsent, Compute and Merge.
+func (m *skipListMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	m.checkBounds(key)
+
+	result, present := value, false
+	m.list.update(key, func(current V, found bool) (V, bool) {
+		if found {
+			result, present = current, true
+			return current, true
+		}
+		return value, true
+	})
+	return result, present
+}
+
+// ComputeIfAbsent implements Map.
+func (m *skipListMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	if node := m.find(key); node != nil {
+		return node.load()
+	}
+	m.checkBounds(key)
+
+	var result V
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if present {
+			result = current
+		} else {
+			result = fn(key)
+		}
+		return result, true
+	})
+	return result
+}
+
+// ComputeIfPresent implements Map.
+func (m *skipListMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	var result V
+	var kept bool
+	if !m.bounds.contains(m.list.comparator, key) {
+		return result, kept
+	}
+
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if !present {
+			return current, false
+		}
+		result, kept = fn(key, current)
+		return result, kept
+	})
+	if !kept {
+		var obj V
+		return obj, false
+	}
+	return result, true
+}
+
+// Compute implements Map.
+func (m *skipListMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	if !m.bounds.contains(m.list.comparator, key) {
+		var obj V
+		if _, keep := fn(key, obj, false); keep {
+			m.checkBounds(key)
+		}
+		return obj, false
+	}
+
+	var result V
+	var kept bool
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		result, kept = fn(key, current, present)
+		return result, kept
+	})
+	if !kept {
+		var obj V
+		return obj, false
+	}
+	return result, true
+}
+
+// Merge implements Map.
+func (m *skipListMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	m.checkBounds(key)
+
+	result, kept := value, true
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if present {
+			result, kept = remapping(current, value)
+		}
+		return result, kept
+	})
+	if !kept {
+		var obj V
+		return obj, false
+	}
+	return result, true
+}
+
+// ReplaceAllValues implements Map. Each value is replaced atomically, one entry at a time. Calling ReplaceAllValues on
+// a view only replaces the values within the view.
+func (m *skipListMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	for node := m.first(); node != nil; node = m.successor(node) {
+		m.list.update(node.key, func(current V, present bool) (V, bool) {
+			if !present {
+				return current, false
+			}
+			return fn(node.key, current), true
+		})
+	}
+}
+
+// Keys implements Map. The keys are returned as a sorted set using the same comparator as the map.
+func (m *skipListMap[K, V]) Keys() Collection[K] {
+	set := &treeSet[K]{
+		tree: newRedBlackTree[K, struct{}](m.list.comparator),
+	}
+	for node := m.first(); node != nil; node = m.successor(node) {
+		set.tree.insert(node.key, struct{}{})
+	}
+	return set
+}
+
+// Values implements Map. The values are returned in the ascending order of their keys.
+func (m *skipListMap[K, V]) Values() Collection[V] {
+	list := NewArrayList[V]()
+	for _, value := range m.Entries() {
+		if err := list.Add(value); err != nil {
+			panic(err)
+		}
+	}
+	return list
+}
+
+// ConcurrentMap implementation
+
+// ReplaceIf implements ConcurrentMap.
+func (m *skipListMap[K, V]) ReplaceIf(key K, value V, condition func(current V) bool) (V, bool) {
+	var previous V
+	var replaced bool
+	if !m.bounds.contains(m.list.comparator, key) {
+		return previous, replaced
+	}
+
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if !present {
+			return current, false
+		}
+		previous = current
+		if !condition(current) {
+			return current, true
+		}
+		replaced = true
+		return value, true
+	})
+	return previous, replaced
+}
+
+// DeleteIf implements ConcurrentMap.
+func (m *skipListMap[K, V]) DeleteIf(key K, condition func(current V) bool) (V, bool) {
+	var previous V
+	var deleted bool
+	if !m.bounds.contains(m.list.comparator, key) {
+		return previous, deleted
+	}
+
+	m.list.update(key, func(current V, present bool) (V, bool) {
+		if !present {
+			return current, false
+		}
+		previous = current
+		deleted = condition(current)
+		return current, !deleted
+	})
+	return previous, deleted
+}
+
+// SortedMap implementation
+
+// Comparator implements SortedMap.
+func (m *skipListMap[K, V]) Comparator() objects.Comparator[K] {
+	return m.list.comparator
+}
+
+// FirstKey implements SortedMap.
+func (m *skipListMap[K, V]) FirstKey() (K, error) {
+	return skipListNodeKey(m.first())
+}
+
+// LastKey implements SortedMap.
+func (m *skipListMap[K, V]) LastKey() (K, error) {
+	return skipListNodeKey(m.list.boundedLast(m.bounds))
+}
+
+// Floor implements SortedMap.
+func (m *skipListMap[K, V]) Floor(key K) (MapEntry[K, V], error) {
+	return skipListNodeEntry(m.list.boundedFloor(m.bounds, key, true), key)
+}
+
+// Ceiling implements SortedMap.
+func (m *skipListMap[K, V]) Ceiling(key K) (MapEntry[K, V], error) {
+	return skipListNodeEntry(m.list.boundedCeiling(m.bounds, key, true), key)
+}
+
+// Lower implements SortedMap.
+func (m *skipListMap[K, V]) Lower(key K) (MapEntry[K, V], error) {
+	return skipListNodeEntry(m.list.boundedFloor(m.bounds, key, false), key)
+}
+
+// Higher implements SortedMap.
+func (m *skipListMap[K, V]) Higher(key K) (MapEntry[K, V], error) {
+	return skipListNodeEntry(m.list.boundedCeiling(m.bounds, key, false), key)
+}
+
+// HeadMap implements SortedMap. The view is a ConcurrentSortedMap as well.
+func (m *skipListMap[K, V]) HeadMap(to K, inclusive bool) SortedMap[K, V] {
+	return &skipListMap[K, V]{
+		list:   m.list,
+		bounds: m.bounds.withTo(m.list.comparator, to, inclusive),
+	}
+}
+
+// TailMap implements SortedMap. The view is a ConcurrentSortedMap as well.
+func (m *skipListMap[K, V]) TailMap(from K, inclusive bool) SortedMap[K, V] {
+	return &skipListMap[K, V]{
+		list:   m.list,
+		bounds: m.bounds.withFrom(m.list.comparator, from, inclusive),
+	}
+}
+
+// SubMap implements SortedMap. The view is a ConcurrentSortedMap as well.
+func (m *skipListMap[K, V]) SubMap(from K, fromInclusive bool, to K, toInclusive bool) SortedMap[K, V] {
+	return &skipListMap[K, V]{
+		list: m.list,
+		bounds: m.bounds.
+			withFrom(m.list.comparator, from, fromInclusive).
+			withTo(m.list.comparator, to, toInclusive),
+	}
+}
+
+// skip list map
+
+// find returns the node for the given key if it is within the bounds of the map.
+func (m *skipListMap[K, V]) find(key K) *skipListNode[K, V] {
+	if !m.bounds.contains(m.list.comparator, key) {
+		return nil
+	}
+	return m.list.find(key)
+}
+
+// checkBounds panics if the given key is outside the bounds of the map.
+func (m *skipListMap[K, V]) checkBounds(key K) {
+	if !m.bounds.contains(m.list.comparator, key) {
+		panic(errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "key out of range"), "key", key))
+	}
+}
+
+// first returns the smallest node within the bounds of the map.
+func (m *skipListMap[K, V]) first() *skipListNode[K, V] {
+	return m.list.boundedFirst(m.bounds)
+}
+
+// successor returns the node following the given node, if it is within the bounds of the map.
+func (m *skipListMap[K, V]) successor(node *skipListNode[K, V]) *skipListNode[K, V] {
+	next := m.list.successor(node)
+	if next == nil || m.bounds.tooHigh(m.list.comparator, next.key) {
+		return nil
+	}
+	return next
+}
+
+func skipListNodeKey[K, V objects.Object](node *skipListNode[K, V]) (K, error) {
+	if node == nil {
+		var obj K
+		return obj, errors.New(nil, ErrorCodeNotFound, "not found")
+	}
+	return node.key, nil
+}
+
+func skipListNodeEntry[K, V objects.Object](node *skipListNode[K, V], key K) (MapEntry[K, V], error) {
+	if node == nil {
+		return nil, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return &mapEntry[K, V]{
+		Key:   node.key,
+		Value: node.load(),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// skipListMapIterator is an iterator for skipListMap, returning entries in ascending key order. It follows the bottom
+// level of the list without locking, so it never fails because of concurrent modifications.
+type skipListMapIterator[K, V objects.Object] struct {
+	m    *skipListMap[K, V]
+	next *skipListNode[K, V]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *skipListMapIterator[K, V]) HasNext() bool {
+	return iterator.next != nil
+}
+
+// Next implements objects.Iterator.
+func (iterator *skipListMapIterator[K, V]) Next() MapEntry[K, V] {
+	if iterator.next == nil {
+		panic("out of bounds")
+	}
+
+	current := iterator.next
+	iterator.next = iterator.m.successor(current)
+	return &mapEntry[K, V]{
+		Key:   current.key,
+		Value: current.load(),
+	}
+}
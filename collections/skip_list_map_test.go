@@ -0,0 +1,249 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSkipListMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewSkipListMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestSkipListMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewSkipListMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestSkipListMap_Ordering(t *testing.T) {
+	m := NewSkipListMapT[*objects.Int, *objects.String](ascendingInts())
+
+	var keys []int
+	for _, key := range rand.New(rand.NewSource(0)).Perm(500) {
+		keys = append(keys, key)
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	for ix := 0; ix < len(keys); ix += 3 {
+		tests.Execute2E(m.Delete(objects.WrapInt(keys[ix]))).NoError(t)
+	}
+
+	var expected []int
+	for ix, key := range keys {
+		if ix%3 != 0 {
+			expected = append(expected, key)
+		}
+	}
+	sort.Ints(expected)
+
+	var actual []int
+	for key := range m.Entries() {
+		actual = append(actual, key.Unwrap())
+	}
+	tests.Execute(actual).Equal(t, expected)
+	tests.Execute(m.Size()).Equal(t, len(expected))
+}
+
+func TestSkipListMap_Navigation(t *testing.T) {
+	m := NewSkipListMapT[*objects.Int, *objects.String](ascendingInts())
+	for _, key := range []int{10, 20, 30, 40} {
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	tests.Execute2E(m.FirstKey()).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(m.LastKey()).NoError(t).Equal(t, objects.WrapInt(40))
+
+	key := func(entry MapEntry[*objects.Int, *objects.String], err error) (*objects.Int, error) {
+		if err != nil {
+			return nil, err
+		}
+		return entry.GetKey(), nil
+	}
+
+	tests.Execute2E(key(m.Floor(objects.WrapInt(25)))).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(key(m.Floor(objects.WrapInt(20)))).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(key(m.Floor(objects.WrapInt(5)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(25)))).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(30)))).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(45)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Lower(objects.WrapInt(20)))).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(key(m.Lower(objects.WrapInt(10)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Higher(objects.WrapInt(30)))).NoError(t).Equal(t, objects.WrapInt(40))
+	tests.Execute2E(key(m.Higher(objects.WrapInt(40)))).ErrorCode(t, ErrorCodeNotFound)
+
+	empty := NewSkipListMapT[*objects.Int, *objects.String](ascendingInts())
+	tests.Execute2E(empty.FirstKey()).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute2E(empty.LastKey()).ErrorCode(t, ErrorCodeNotFound)
+}
+
+func TestSkipListMap_Views(t *testing.T) {
+	m := NewSkipListMapT[*objects.Int, *objects.String](ascendingInts())
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	keys := func(m SortedMap[*objects.Int, *objects.String]) []int {
+		var keys []int
+		for key := range m.Entries() {
+			keys = append(keys, key.Unwrap())
+		}
+		return keys
+	}
+
+	tests.Execute(keys(m.HeadMap(objects.WrapInt(30), false))).Equal(t, []int{10, 20})
+	tests.Execute(keys(m.TailMap(objects.WrapInt(30), true))).Equal(t, []int{30, 40, 50})
+	tests.Execute(keys(m.SubMap(objects.WrapInt(15), true, objects.WrapInt(40), true))).Equal(t, []int{20, 30, 40})
+
+	view := m.SubMap(objects.WrapInt(20), true, objects.WrapInt(40), false)
+	tests.Execute(view.Size()).Equal(t, 2)
+	tests.Execute(view.ContainsKey(objects.WrapInt(10))).Equal(t, false)
+	tests.Execute2E(view.FirstKey()).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(view.LastKey()).NoError(t).Equal(t, objects.WrapInt(30))
+
+	// Writes through the view are visible in the map and vice versa.
+	tests.ExecuteE(view.Put(objects.WrapInt(25), objects.WrapString("value"))).NoError(t)
+	tests.ExecuteE(view.Put(objects.WrapInt(45), objects.WrapString("value"))).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute(m.ContainsKey(objects.WrapInt(25))).Equal(t, true)
+	tests.ExecuteE(m.Put(objects.WrapInt(35), objects.WrapString("value"))).NoError(t)
+	tests.Execute(keys(view)).Equal(t, []int{20, 25, 30, 35})
+
+	// Inserting outside the view panics, while looking up outside it finds nothing.
+	tests.Execute(view.GetOrDefault(objects.WrapInt(10), objects.WrapString("default"))).Equal(t, objects.WrapString("default"))
+	expectErrorCode(t, ErrorCodeOutOfBounds, func() {
+		view.PutIfAbsent(objects.WrapInt(45), objects.WrapString("value"))
+	})
+	expectErrorCode(t, ErrorCodeOutOfBounds, func() {
+		view.PutOrReplace(objects.WrapInt(10), objects.WrapString("other"))
+	})
+	tests.Execute(m.Get(objects.WrapInt(10))).Equal(t, objects.WrapString("value"))
+	tests.Execute2(view.(ConcurrentSortedMap[*objects.Int, *objects.String]).DeleteIf(objects.WrapInt(10), func(*objects.String) bool {
+		return true
+	})).Equal(t, false)
+	tests.Execute(m.ContainsKey(objects.WrapInt(10))).Equal(t, true)
+
+	view.Clear()
+	tests.Execute(view.IsEmpty()).Equal(t, true)
+	tests.Execute(keys(m)).Equal(t, []int{10, 40, 50})
+}
+
+func TestSkipListMap_CompoundOperations(t *testing.T) {
+	m := NewSkipListMap[*objects.String, *objects.Int]()
+
+	tests.Execute2(m.PutIfAbsent(objects.WrapString("a"), objects.WrapInt(1))).Equal(t, false).Equal(t, objects.WrapInt(1))
+	tests.Execute2(m.PutIfAbsent(objects.WrapString("a"), objects.WrapInt(2))).Equal(t, true).Equal(t, objects.WrapInt(1))
+
+	isOne := func(current *objects.Int) bool {
+		return current.Unwrap() == 1
+	}
+	tests.Execute2(m.ReplaceIf(objects.WrapString("a"), objects.WrapInt(4), isOne)).Equal(t, true).Equal(t, objects.WrapInt(1))
+	tests.Execute2(m.ReplaceIf(objects.WrapString("a"), objects.WrapInt(5), isOne)).Equal(t, false)
+	tests.Execute2(m.ReplaceIf(objects.WrapString("c"), objects.WrapInt(4), isOne)).Equal(t, false)
+	tests.Execute(m.ContainsKey(objects.WrapString("c"))).Equal(t, false)
+
+	isFour := func(current *objects.Int) bool {
+		return current.Unwrap() == 4
+	}
+	tests.Execute2(m.DeleteIf(objects.WrapString("a"), isOne)).Equal(t, false)
+	tests.Execute2(m.DeleteIf(objects.WrapString("a"), isFour)).Equal(t, true).Equal(t, objects.WrapInt(4))
+	tests.Execute(m.IsEmpty()).Equal(t, true)
+}
+
+func TestSkipListMap_Parallel(t *testing.T) {
+	m := NewSkipListMapT[*objects.Int, *objects.Int](ascendingInts())
+
+	const goroutines = 16
+	const keys = 200
+
+	var computed atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for ix := 0; ix < keys; ix++ {
+				key := objects.WrapInt(ix * 2)
+
+				// Every goroutine races to create the same keys, only one should win each.
+				m.ComputeIfAbsent(key, func(key *objects.Int) *objects.Int {
+					computed.Add(1)
+					return objects.WrapInt(0)
+				})
+				m.Merge(key, objects.WrapInt(1), func(current, value *objects.Int) (*objects.Int, bool) {
+					return objects.WrapInt(current.Unwrap() + value.Unwrap()), true
+				})
+
+				// Mix in navigation, iteration and writes to private keys
+				// between the shared ones.
+				own := objects.WrapInt(keys*2 + g*keys + ix)
+				_ = m.Put(own, objects.WrapInt(g))
+				_, _ = m.Ceiling(key)
+				_, _ = m.LastKey()
+				if ix%10 == 0 {
+					previous := -1
+					for key := range m.Entries() {
+						if key.Unwrap() <= previous {
+							panic(fmt.Sprintf("out of order: %d after %d", key.Unwrap(), previous))
+						}
+						previous = key.Unwrap()
+					}
+				}
+				_, _ = m.Delete(own)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	tests.Execute(computed.Load()).Equal(t, int64(keys))
+	tests.Execute(m.Size()).Equal(t, keys)
+	for key, value := range m.Entries() {
+		tests.Execute(value.Unwrap()).Equal(t, goroutines)
+		tests.Execute(key.Unwrap()%2).Equal(t, 0)
+	}
+}
+
+func TestSkipListMap_JSON(t *testing.T) {
+	m := NewSkipListMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+
+	data, err := json.Marshal(m)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(string(data)).Equal(t, `[{"key":"a","value":1},{"key":"b","value":2}]`)
+
+	other := NewSkipListMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.Equals(m)).Equal(t, true)
+	tests.Execute(other.String()).Equal(t, "{a:1,b:2}")
+	tests.Execute(other.Copy().Equals(m)).Equal(t, true)
+}
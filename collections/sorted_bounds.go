@@ -0,0 +1,73 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// sortedBounds describes the range of keys visible through a view of a sorted
+// collection. A bound that isn't set leaves that end of the range open.
+type sortedBounds[K objects.Object] struct {
+	hasFrom       bool
+	from          K
+	fromInclusive bool
+
+	hasTo       bool
+	to          K
+	toInclusive bool
+}
+
+// tooLow returns true if the key falls below the lower bound.
+func (bounds sortedBounds[K]) tooLow(comparator objects.Comparator[K], key K) bool {
+	if !bounds.hasFrom {
+		return false
+	}
+	cmp := comparator.Compare(key, bounds.from)
+	return cmp < 0 || (cmp == 0 && !bounds.fromInclusive)
+}
+
+// tooHigh returns true if the key falls above the upper bound.
+func (bounds sortedBounds[K]) tooHigh(comparator objects.Comparator[K], key K) bool {
+	if !bounds.hasTo {
+		return false
+	}
+	cmp := comparator.Compare(key, bounds.to)
+	return cmp > 0 || (cmp == 0 && !bounds.toInclusive)
+}
+
+// contains returns true if the key falls within both bounds.
+func (bounds sortedBounds[K]) contains(comparator objects.Comparator[K], key K) bool {
+	return !bounds.tooLow(comparator, key) && !bounds.tooHigh(comparator, key)
+}
+
+// unbounded returns true if neither end of the range is set.
+func (bounds sortedBounds[K]) unbounded() bool {
+	return !bounds.hasFrom && !bounds.hasTo
+}
+
+// withFrom returns a copy of the bounds with the lower bound narrowed to the
+// given key. A bound that is already tighter is kept.
+func (bounds sortedBounds[K]) withFrom(comparator objects.Comparator[K], from K, inclusive bool) sortedBounds[K] {
+	if bounds.hasFrom {
+		cmp := comparator.Compare(from, bounds.from)
+		if cmp < 0 || (cmp == 0 && !bounds.fromInclusive) {
+			return bounds
+		}
+	}
+	bounds.hasFrom = true
+	bounds.from = from
+	bounds.fromInclusive = inclusive
+	return bounds
+}
+
+// withTo returns a copy of the bounds with the upper bound narrowed to the
+// given key. A bound that is already tighter is kept.
+func (bounds sortedBounds[K]) withTo(comparator objects.Comparator[K], to K, inclusive bool) sortedBounds[K] {
+	if bounds.hasTo {
+		cmp := comparator.Compare(to, bounds.to)
+		if cmp > 0 || (cmp == 0 && !bounds.toInclusive) {
+			return bounds
+		}
+	}
+	bounds.hasTo = true
+	bounds.to = to
+	bounds.toInclusive = inclusive
+	return bounds
+}
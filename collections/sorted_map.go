@@ -0,0 +1,41 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// SortedMap is a map that keeps its keys ordered by a comparator.
+type SortedMap[K, V objects.Object] interface {
+	Map[K, V]
+
+	// Comparator returns the comparator used to order the keys.
+	Comparator() objects.Comparator[K]
+
+	// FirstKey returns the smallest key in the map.
+	FirstKey() (K, error)
+
+	// LastKey returns the largest key in the map.
+	LastKey() (K, error)
+
+	// Floor returns the entry with the largest key less than or equal to the given key.
+	Floor(key K) (MapEntry[K, V], error)
+
+	// Ceiling returns the entry with the smallest key greater than or equal to the given key.
+	Ceiling(key K) (MapEntry[K, V], error)
+
+	// Lower returns the entry with the largest key strictly less than the given key.
+	Lower(key K) (MapEntry[K, V], error)
+
+	// Higher returns the entry with the smallest key strictly greater than the given key.
+	Higher(key K) (MapEntry[K, V], error)
+
+	// HeadMap returns a view of the portion of the map with keys less than (or equal to, if inclusive) the given key.
+	// Changes to the view are reflected in the map and vice versa.
+	HeadMap(to K, inclusive bool) SortedMap[K, V]
+
+	// TailMap returns a view of the portion of the map with keys greater than (or equal to, if inclusive) the given
+	// key. Changes to the view are reflected in the map and vice versa.
+	TailMap(from K, inclusive bool) SortedMap[K, V]
+
+	// SubMap returns a view of the portion of the map with keys between from and to. Changes to the view are reflected
+	// in the map and vice versa.
+	SubMap(from K, fromInclusive bool, to K, toInclusive bool) SortedMap[K, V]
+}
@@ -0,0 +1,47 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// SortedSet is a set that keeps its elements ordered by a comparator.
+type SortedSet[O objects.Object] interface {
+	Set[O]
+
+	// Comparator returns the comparator used to order the elements.
+	Comparator() objects.Comparator[O]
+
+	// First returns the smallest element in the set.
+	First() (O, error)
+
+	// Last returns the largest element in the set.
+	Last() (O, error)
+
+	// Floor returns the largest element less than or equal to the given value.
+	Floor(value O) (O, error)
+
+	// Ceiling returns the smallest element greater than or equal to the given value.
+	Ceiling(value O) (O, error)
+
+	// Lower returns the largest element strictly less than the given value.
+	Lower(value O) (O, error)
+
+	// Higher returns the smallest element strictly greater than the given value.
+	Higher(value O) (O, error)
+
+	// PollFirst removes and returns the smallest element in the set.
+	PollFirst() (O, error)
+
+	// PollLast removes and returns the largest element in the set.
+	PollLast() (O, error)
+
+	// HeadSet returns a view of the portion of the set with elements less than (or equal to, if inclusive) the given
+	// value. Changes to the view are reflected in the set and vice versa.
+	HeadSet(to O, inclusive bool) SortedSet[O]
+
+	// TailSet returns a view of the portion of the set with elements greater than (or equal to, if inclusive) the
+	// given value. Changes to the view are reflected in the set and vice versa.
+	TailSet(from O, inclusive bool) SortedSet[O]
+
+	// SubSet returns a view of the portion of the set with elements between from and to. Changes to the view are
+	// reflected in the set and vice versa.
+	SubSet(from O, fromInclusive bool, to O, toInclusive bool) SortedSet[O]
+}
@@ -15,3 +15,34 @@ type Stack[O objects.Object] interface {
 	// Pop removes and returns the value at the top of the stack.
 	Pop() (O, error)
 }
+
+func stackEquals[O objects.Object](left, right any) bool {
+	lStack, lOk := left.(Stack[O])
+	rStack, rOk := right.(Stack[O])
+
+	if !lOk || !rOk {
+		return false
+	}
+
+	if lStack.Size() != rStack.Size() {
+		return false
+	}
+
+	lIterator := lStack.Iterator()
+	rIterator := rStack.Iterator()
+
+	for lIterator.HasNext() && rIterator.HasNext() {
+		if !lIterator.Next().Equals(rIterator.Next()) {
+			return false
+		}
+	}
+	return lIterator.HasNext() == rIterator.HasNext()
+}
+
+func stackHashCode[O objects.Object](stack Stack[O]) uint64 {
+	hashcode := uint64(13001)
+	for iterator := stack.Iterator(); iterator.HasNext(); {
+		hashcode = hashcode * iterator.Next().HashCode()
+	}
+	return hashcode * 13997
+}
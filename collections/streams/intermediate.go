@@ -0,0 +1,120 @@
+// Package streams provides lazy combinators over iter.Seq, such as the sequences returned by Collection.Elems, and
+// collectors that gather the results back into collections.
+//
+// The intermediate operations in this package don't do any work until the sequence they return is iterated, and
+// stop pulling from their source as soon as the consumer stops.
+package streams
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/pasataleo/go-objects/objects"
+
+	"github.com/pasataleo/go-collections/collections"
+)
+
+// Map returns a sequence of the results of applying fn to each element of seq.
+func Map[I, O any](seq iter.Seq[I], fn func(value I) O) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for value := range seq {
+			if !yield(fn(value)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence of the elements of seq that match the predicate.
+func Filter[O any](seq iter.Seq[O], predicate func(value O) bool) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for value := range seq {
+			if predicate(value) && !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap returns the concatenation of the sequences returned by applying fn to each element of seq.
+func FlatMap[I, O any](seq iter.Seq[I], fn func(value I) iter.Seq[O]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for value := range seq {
+			for result := range fn(value) {
+				if !yield(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take returns a sequence of at most the first n elements of seq.
+func Take[O any](seq iter.Seq[O], n int) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+		for value := range seq {
+			if !yield(value) {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence of the elements of seq after the first n.
+func Skip[O any](seq iter.Seq[O], n int) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		skipped := 0
+		for value := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Distinct returns a sequence of the elements of seq with duplicates removed, keeping the first occurrence of each.
+// Elements are compared with their Equals and HashCode methods.
+func Distinct[O objects.Object](seq iter.Seq[O]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		seen := collections.NewHashSet[O]()
+		for value := range seq {
+			if seen.Contains(value) {
+				continue
+			}
+			_ = seen.Add(value)
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns a sequence of the elements of seq in the order given by the comparator. Elements that compare as
+// equal keep their original order.
+//
+// Unlike the other intermediate operations, Sorted has to read the whole of seq before it can return the first
+// element.
+func Sorted[O any](seq iter.Seq[O], comparator objects.Comparator[O]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		values := slices.Collect(seq)
+		slices.SortStableFunc(values, comparator.Compare)
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
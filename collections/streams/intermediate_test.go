@@ -0,0 +1,79 @@
+package streams
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+
+	"github.com/pasataleo/go-collections/collections"
+)
+
+func ints(values ...int) collections.List[*objects.Int] {
+	list := collections.NewArrayList[*objects.Int]()
+	for _, value := range values {
+		_ = list.Add(objects.WrapInt(value))
+	}
+	return list
+}
+
+func unwrap(list collections.List[*objects.Int]) []int {
+	var values []int
+	for value := range list.Elems() {
+		values = append(values, value.Unwrap())
+	}
+	return values
+}
+
+func TestMap(t *testing.T) {
+	doubled := Map(ints(1, 2, 3).Elems(), func(value *objects.Int) *objects.Int {
+		return objects.WrapInt(value.Unwrap() * 2)
+	})
+	tests.Execute(unwrap(ToList(doubled))).Equal(t, []int{2, 4, 6})
+}
+
+func TestFilter(t *testing.T) {
+	even := Filter(ints(1, 2, 3, 4).Elems(), func(value *objects.Int) bool {
+		return value.Unwrap()%2 == 0
+	})
+	tests.Execute(unwrap(ToList(even))).Equal(t, []int{2, 4})
+}
+
+func TestFlatMap(t *testing.T) {
+	repeated := FlatMap(ints(1, 2, 3).Elems(), func(value *objects.Int) iter.Seq[*objects.Int] {
+		return ints(slices.Repeat([]int{value.Unwrap()}, value.Unwrap())...).Elems()
+	})
+	tests.Execute(unwrap(ToList(repeated))).Equal(t, []int{1, 2, 2, 3, 3, 3})
+}
+
+func TestTakeSkip(t *testing.T) {
+	values := ints(1, 2, 3, 4, 5)
+
+	tests.Execute(unwrap(ToList(Take(values.Elems(), 2)))).Equal(t, []int{1, 2})
+	tests.Execute(unwrap(ToList(Take(values.Elems(), 10)))).Equal(t, []int{1, 2, 3, 4, 5})
+	tests.Execute(ToList(Take(values.Elems(), 0)).Size()).Equal(t, 0)
+	tests.Execute(unwrap(ToList(Skip(values.Elems(), 3)))).Equal(t, []int{4, 5})
+	tests.Execute(unwrap(ToList(Take(Skip(values.Elems(), 1), 2)))).Equal(t, []int{2, 3})
+}
+
+func TestTake_Lazy(t *testing.T) {
+	// Take must stop pulling from its source once it has enough elements.
+	pulled := 0
+	counting := Map(ints(1, 2, 3, 4, 5).Elems(), func(value *objects.Int) *objects.Int {
+		pulled++
+		return value
+	})
+	tests.Execute(unwrap(ToList(Take(counting, 2)))).Equal(t, []int{1, 2})
+	tests.Execute(pulled).Equal(t, 2)
+}
+
+func TestDistinct(t *testing.T) {
+	tests.Execute(unwrap(ToList(Distinct(ints(3, 1, 3, 2, 1).Elems())))).Equal(t, []int{3, 1, 2})
+}
+
+func TestSorted(t *testing.T) {
+	ascending := objects.ReverseComparator[*objects.Int](objects.ComparableComparator[*objects.Int]())
+	tests.Execute(unwrap(ToList(Sorted(ints(3, 1, 2).Elems(), ascending)))).Equal(t, []int{1, 2, 3})
+}
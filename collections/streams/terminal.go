@@ -0,0 +1,95 @@
+package streams
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+
+	"github.com/pasataleo/go-collections/collections"
+)
+
+// Reduce combines the elements of seq into a single value, by applying fn to the running result and each element in
+// turn, starting from initial.
+func Reduce[O, R any](seq iter.Seq[O], initial R, fn func(result R, value O) R) R {
+	result := initial
+	for value := range seq {
+		result = fn(result, value)
+	}
+	return result
+}
+
+// GroupBy gathers the elements of seq into lists keyed by the result of applying key to each element. The groups
+// are ordered by the first element in each, and the elements within each group keep their original order.
+func GroupBy[O, K objects.Object](seq iter.Seq[O], key func(value O) K) collections.Map[K, collections.List[O]] {
+	groups := collections.NewLinkedHashMap[K, collections.List[O]]()
+	for value := range seq {
+		groupKey := key(value)
+		group, err := groups.GetSafe(groupKey)
+		if err != nil {
+			group = collections.NewArrayList[O]()
+			if err := groups.Put(groupKey, group); err != nil {
+				panic(err)
+			}
+		}
+		if err := group.Add(value); err != nil {
+			panic(err)
+		}
+	}
+	return groups
+}
+
+// Partition splits the elements of seq into those that match the predicate and those that don't, keeping their
+// original order.
+func Partition[O objects.Object](seq iter.Seq[O], predicate func(value O) bool) (collections.List[O], collections.List[O]) {
+	matching := collections.NewArrayList[O]()
+	rest := collections.NewArrayList[O]()
+	for value := range seq {
+		var err error
+		if predicate(value) {
+			err = matching.Add(value)
+		} else {
+			err = rest.Add(value)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+	return matching, rest
+}
+
+// ToList collects the elements of seq into an array list.
+func ToList[O objects.Object](seq iter.Seq[O]) collections.List[O] {
+	list := collections.NewArrayList[O]()
+	for value := range seq {
+		if err := list.Add(value); err != nil {
+			panic(err)
+		}
+	}
+	return list
+}
+
+// ToSet collects the elements of seq into a hash set, dropping any duplicates.
+func ToSet[O objects.Object](seq iter.Seq[O]) collections.Set[O] {
+	set := collections.NewHashSet[O]()
+	for value := range seq {
+		if set.Contains(value) {
+			continue
+		}
+		if err := set.Add(value); err != nil {
+			panic(err)
+		}
+	}
+	return set
+}
+
+// ToMap collects the elements of seq into a hash map, using the key and value functions to build each entry. It
+// returns an error if two elements map to the same key.
+func ToMap[O, K, V objects.Object](seq iter.Seq[O], key func(value O) K, value func(value O) V) (collections.Map[K, V], error) {
+	m := collections.NewHashMap[K, V]()
+	for elem := range seq {
+		if err := m.Put(key(elem), value(elem)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
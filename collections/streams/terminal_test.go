@@ -0,0 +1,57 @@
+package streams
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+
+	"github.com/pasataleo/go-collections/collections"
+)
+
+func TestReduce(t *testing.T) {
+	sum := Reduce(ints(1, 2, 3).Elems(), 0, func(result int, value *objects.Int) int {
+		return result + value.Unwrap()
+	})
+	tests.Execute(sum).Equal(t, 6)
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(ints(1, 2, 3, 4, 5).Elems(), func(value *objects.Int) *objects.Bool {
+		return objects.WrapBool(value.Unwrap()%2 == 0)
+	})
+
+	tests.Execute(groups.Size()).Equal(t, 2)
+	tests.Execute(unwrap(groups.Get(objects.WrapBool(false)))).Equal(t, []int{1, 3, 5})
+	tests.Execute(unwrap(groups.Get(objects.WrapBool(true)))).Equal(t, []int{2, 4})
+}
+
+func TestPartition(t *testing.T) {
+	small, large := Partition(ints(5, 1, 4, 2, 3).Elems(), func(value *objects.Int) bool {
+		return value.Unwrap() < 3
+	})
+	tests.Execute(unwrap(small)).Equal(t, []int{1, 2})
+	tests.Execute(unwrap(large)).Equal(t, []int{5, 4, 3})
+}
+
+func TestToSet(t *testing.T) {
+	set := ToSet(ints(1, 2, 2, 3).Elems())
+	tests.Execute(set.Size()).Equal(t, 3)
+	tests.Execute(set.Contains(objects.WrapInt(2))).Equal(t, true)
+}
+
+func TestToMap(t *testing.T) {
+	square := func(value *objects.Int) *objects.Int {
+		return objects.WrapInt(value.Unwrap() * value.Unwrap())
+	}
+	identity := func(value *objects.Int) *objects.Int {
+		return value
+	}
+
+	squares, err := ToMap(ints(1, 2, 3).Elems(), identity, square)
+	tests.ExecuteE(err).NoError(t)
+	tests.Execute(squares.Get(objects.WrapInt(3))).Equal(t, objects.WrapInt(9))
+
+	_, err = ToMap(ints(1, 2, 1).Elems(), identity, square)
+	tests.ExecuteE(err).ErrorCode(t, collections.ErrorCodeAlreadyExists)
+}
@@ -0,0 +1,190 @@
+package collections
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// synchronizedCollection guards every method of the wrapped collection with a
+// read-write lock. It is embedded by the synchronized wrappers for each of the
+// collection interfaces, which add the methods specific to that interface.
+//
+// The lock is never held while calling into another collection, so operations
+// between two synchronized collections can't deadlock. Instead, whichever side
+// isn't locked is read through a snapshot.
+type synchronizedCollection[O objects.Object] struct {
+	lock       sync.RWMutex
+	collection Collection[O]
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (c *synchronizedCollection[O]) Equals(other any) bool {
+	if synchronized, ok := other.(interface {
+		synchronized() *synchronizedCollection[O]
+	}); ok {
+		if synchronized.synchronized() == c {
+			return true
+		}
+	}
+	return c.snapshot().Equals(other)
+}
+
+// HashCode implements objects.Object.
+func (c *synchronizedCollection[O]) HashCode() uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.HashCode()
+}
+
+// String implements objects.Object.
+func (c *synchronizedCollection[O]) String() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (c *synchronizedCollection[O]) MarshalJSON() ([]byte, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object.
+func (c *synchronizedCollection[O]) UnmarshalJSON(bytes []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.UnmarshalJSON(bytes)
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable. The iterator works over a snapshot taken when it is created, so the lock
+// isn't held while the caller iterates.
+func (c *synchronizedCollection[O]) Iterator() objects.Iterator[O] {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return objects.NewSliceIterator(objects.SliceFrom[O](c.collection))
+}
+
+// Collection implementation
+
+// Elems implements Collection. The sequence works over a snapshot taken when iteration starts.
+func (c *synchronizedCollection[O]) Elems() iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for iterator := c.Iterator(); iterator.HasNext(); {
+			if !yield(iterator.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Add implements Collection.
+func (c *synchronizedCollection[O]) Add(value O) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.Add(value)
+}
+
+// AddAll implements Collection.
+func (c *synchronizedCollection[O]) AddAll(values Collection[O]) error {
+	snapshot := NewArrayList[O](objects.SliceFrom[O](values)...)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.AddAll(snapshot)
+}
+
+// Remove implements Collection.
+func (c *synchronizedCollection[O]) Remove(value O) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.Remove(value)
+}
+
+// RemoveAll implements Collection.
+func (c *synchronizedCollection[O]) RemoveAll(values Collection[O]) error {
+	snapshot := NewArrayList[O](objects.SliceFrom[O](values)...)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.RemoveAll(snapshot)
+}
+
+// Contains implements Collection.
+func (c *synchronizedCollection[O]) Contains(value O) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (c *synchronizedCollection[O]) ContainsAll(values Collection[O]) bool {
+	snapshot := NewArrayList[O](objects.SliceFrom[O](values)...)
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.ContainsAll(snapshot)
+}
+
+// RemoveIf implements Collection. The condition is called with the collection locked, so it must not use the
+// collection.
+func (c *synchronizedCollection[O]) RemoveIf(condition func(value O) bool) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.RemoveIf(condition)
+}
+
+// RetainAll implements Collection.
+func (c *synchronizedCollection[O]) RetainAll(values Collection[O]) int {
+	if synchronized, ok := values.(interface {
+		synchronized() *synchronizedCollection[O]
+	}); ok && synchronized.synchronized() == c {
+		return 0
+	}
+	snapshot := setSnapshot(values)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.collection.RetainAll(snapshot)
+}
+
+// Size implements Collection.
+func (c *synchronizedCollection[O]) Size() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.Size()
+}
+
+// IsEmpty implements Collection.
+func (c *synchronizedCollection[O]) IsEmpty() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.IsEmpty()
+}
+
+// Clear implements Collection.
+func (c *synchronizedCollection[O]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.collection.Clear()
+}
+
+// synchronized collection
+
+// synchronized exposes the shared state, so wrappers can recognise each other.
+func (c *synchronizedCollection[O]) synchronized() *synchronizedCollection[O] {
+	return c
+}
+
+// snapshot returns a copy of the wrapped collection, taken under the lock.
+func (c *synchronizedCollection[O]) snapshot() Collection[O] {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.collection.Copy()
+}
@@ -0,0 +1,167 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type synchronizedList[O objects.Object] struct {
+	synchronizedCollection[O]
+	list List[O]
+}
+
+// SynchronizedList returns a list that guards every method of the given list with a read-write lock, so it can be
+// shared between goroutines. The given list must not be used directly afterward.
+func SynchronizedList[O objects.Object](list List[O]) List[O] {
+	return &synchronizedList[O]{
+		synchronizedCollection: synchronizedCollection[O]{
+			collection: list,
+		},
+		list: list,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is synchronized independently of the original.
+func (l *synchronizedList[O]) Copy() Collection[O] {
+	return SynchronizedList[O](l.snapshot().(List[O]))
+}
+
+// List implementation
+
+// IndexOf implements List.
+func (l *synchronizedList[O]) IndexOf(value O) int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.list.IndexOf(value)
+}
+
+// Get implements List.
+func (l *synchronizedList[O]) Get(ix int) (O, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.list.Get(ix)
+}
+
+// Insert implements List.
+func (l *synchronizedList[O]) Insert(value O, ix int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.list.Insert(value, ix)
+}
+
+// Replace implements List.
+func (l *synchronizedList[O]) Replace(value O, ix int) (O, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.list.Replace(value, ix)
+}
+
+// RemoveAt implements List.
+func (l *synchronizedList[O]) RemoveAt(ix int) (O, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.list.RemoveAt(ix)
+}
+
+// ReplaceAll implements List. The function is called with the list locked, so it must not use the list.
+func (l *synchronizedList[O]) ReplaceAll(fn func(value O) O) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.list.ReplaceAll(fn)
+}
+
+// ListIterator implements List. Unlike Iterator, the list iterator works over the live list so it can change it. Each
+// of its methods takes the lock, but the list may still be changed by other goroutines between calls, in which case
+// the iterator panics with an ErrorCodeConcurrentModification error.
+func (l *synchronizedList[O]) ListIterator() ListIterator[O] {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return &synchronizedListIterator[O]{
+		list:     l,
+		iterator: l.list.ListIterator(),
+	}
+}
+
+// ListIteratorAt implements List. The iterator behaves as the one returned by ListIterator.
+func (l *synchronizedList[O]) ListIteratorAt(ix int) (ListIterator[O], error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	iterator, err := l.list.ListIteratorAt(ix)
+	if err != nil {
+		return nil, err
+	}
+	return &synchronizedListIterator[O]{
+		list:     l,
+		iterator: iterator,
+	}, nil
+}
+
+// synchronized list
+
+// synchronizedListIterator guards every method of the wrapped iterator with the lock of the list it came from.
+type synchronizedListIterator[O objects.Object] struct {
+	list     *synchronizedList[O]
+	iterator ListIterator[O]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *synchronizedListIterator[O]) HasNext() bool {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *synchronizedListIterator[O]) Next() O {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.Next()
+}
+
+// HasPrevious implements ListIterator.
+func (iterator *synchronizedListIterator[O]) HasPrevious() bool {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.HasPrevious()
+}
+
+// Previous implements ListIterator.
+func (iterator *synchronizedListIterator[O]) Previous() O {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.Previous()
+}
+
+// NextIndex implements ListIterator.
+func (iterator *synchronizedListIterator[O]) NextIndex() int {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.NextIndex()
+}
+
+// PreviousIndex implements ListIterator.
+func (iterator *synchronizedListIterator[O]) PreviousIndex() int {
+	iterator.list.lock.RLock()
+	defer iterator.list.lock.RUnlock()
+	return iterator.iterator.PreviousIndex()
+}
+
+// Remove implements ListIterator.
+func (iterator *synchronizedListIterator[O]) Remove() {
+	iterator.list.lock.Lock()
+	defer iterator.list.lock.Unlock()
+	iterator.iterator.Remove()
+}
+
+// Set implements ListIterator.
+func (iterator *synchronizedListIterator[O]) Set(value O) {
+	iterator.list.lock.Lock()
+	defer iterator.list.lock.Unlock()
+	iterator.iterator.Set(value)
+}
+
+// Add implements ListIterator.
+func (iterator *synchronizedListIterator[O]) Add(value O) {
+	iterator.list.lock.Lock()
+	defer iterator.list.lock.Unlock()
+	iterator.iterator.Add(value)
+}
@@ -0,0 +1,59 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSynchronizedList_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return SynchronizedList[*objects.String](NewArrayList[*objects.String]())
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestSynchronizedList_List(t *testing.T) {
+	runListTests(t, func() List[*objects.String] {
+		return SynchronizedList[*objects.String](NewArrayList[*objects.String]())
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+	})
+}
+
+func TestSynchronizedList_Parallel(t *testing.T) {
+	list := SynchronizedList[*objects.Int](NewArrayList[*objects.Int]())
+
+	const goroutines = 16
+	const values = 100
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for ix := 0; ix < values; ix++ {
+				_ = list.Add(objects.WrapInt(g*values + ix))
+				_ = list.Contains(objects.WrapInt(ix))
+				if ix%10 == 0 {
+					for range list.Elems() {
+					}
+					_ = list.Copy()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	tests.Execute(list.Size()).Equal(t, goroutines*values)
+	tests.Execute(list.Equals(list)).Equal(t, true)
+}
@@ -0,0 +1,189 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// synchronizedMap looks keys up under the read lock, unless lookups change
+// the wrapped map, as they do for access-ordered maps, LRU caches and bounded
+// maps. exclusiveReads is fixed when the map is wrapped.
+type synchronizedMap[K, V objects.Object] struct {
+	synchronizedCollection[MapEntry[K, V]]
+	m Map[K, V]
+
+	exclusiveReads bool
+}
+
+// SynchronizedMap returns a map that guards every method of the given map with a read-write lock, so it can be
+// shared between goroutines. The given map must not be used directly afterward.
+func SynchronizedMap[K, V objects.Object](m Map[K, V]) Map[K, V] {
+	return &synchronizedMap[K, V]{
+		synchronizedCollection: synchronizedCollection[MapEntry[K, V]]{
+			collection: m,
+		},
+		m:              m,
+		exclusiveReads: mapWritesOnRead(m),
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is synchronized independently of the original.
+func (m *synchronizedMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	return SynchronizedMap[K, V](m.snapshot().(Map[K, V]))
+}
+
+// Map implementation
+
+// Entries implements Map. The sequence works over a snapshot taken when iteration starts.
+func (m *synchronizedMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for iterator := m.Iterator(); iterator.HasNext(); {
+			entry := iterator.Next()
+			if !yield(entry.GetKey(), entry.GetValue()) {
+				return
+			}
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *synchronizedMap[K, V]) ContainsKey(key K) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.m.ContainsKey(key)
+}
+
+// Put implements Map.
+func (m *synchronizedMap[K, V]) Put(key K, value V) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.Put(key, value)
+}
+
+// Replace implements Map.
+func (m *synchronizedMap[K, V]) Replace(key K, value V) (V, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.Replace(key, value)
+}
+
+// PutOrReplace implements Map.
+func (m *synchronizedMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.PutOrReplace(key, value)
+}
+
+// Delete implements Map.
+func (m *synchronizedMap[K, V]) Delete(key K) (V, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.Delete(key)
+}
+
+// DeleteIfPresent implements Map.
+func (m *synchronizedMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.DeleteIfPresent(key)
+}
+
+// Get implements Map.
+func (m *synchronizedMap[K, V]) Get(key K) V {
+	m.lockLookup()
+	defer m.unlockLookup()
+	return m.m.Get(key)
+}
+
+// GetSafe implements Map.
+func (m *synchronizedMap[K, V]) GetSafe(key K) (V, error) {
+	m.lockLookup()
+	defer m.unlockLookup()
+	return m.m.GetSafe(key)
+}
+
+// GetOrDefault implements Map.
+func (m *synchronizedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	m.lockLookup()
+	defer m.unlockLookup()
+	return m.m.GetOrDefault(key, defaultValue)
+}
+
+// PutIfAbsent implements Map.
+func (m *synchronizedMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.PutIfAbsent(key, value)
+}
+
+// ComputeIfAbsent implements Map. fn is called while the lock is held.
+func (m *synchronizedMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.ComputeIfAbsent(key, fn)
+}
+
+// ComputeIfPresent implements Map. fn is called while the lock is held.
+func (m *synchronizedMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.ComputeIfPresent(key, fn)
+}
+
+// Compute implements Map. fn is called while the lock is held.
+func (m *synchronizedMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.Compute(key, fn)
+}
+
+// Merge implements Map. remapping is called while the lock is held.
+func (m *synchronizedMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.m.Merge(key, value, remapping)
+}
+
+// ReplaceAllValues implements Map. fn is called while the lock is held.
+func (m *synchronizedMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.m.ReplaceAllValues(fn)
+}
+
+// Keys implements Map. The keys are a snapshot, and don't reflect later changes to the map.
+func (m *synchronizedMap[K, V]) Keys() Collection[K] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.m.Keys().Copy()
+}
+
+// Values implements Map. The values are a snapshot, and don't reflect later changes to the map.
+func (m *synchronizedMap[K, V]) Values() Collection[V] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.m.Values().Copy()
+}
+
+// synchronized map
+
+// lockLookup takes the lock needed to look a key up in the wrapped map.
+func (m *synchronizedMap[K, V]) lockLookup() {
+	if m.exclusiveReads {
+		m.lock.Lock()
+	} else {
+		m.lock.RLock()
+	}
+}
+
+// unlockLookup releases the lock taken by lockLookup.
+func (m *synchronizedMap[K, V]) unlockLookup() {
+	if m.exclusiveReads {
+		m.lock.Unlock()
+	} else {
+		m.lock.RUnlock()
+	}
+}
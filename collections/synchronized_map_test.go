@@ -0,0 +1,108 @@
+package collections
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSynchronizedMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return SynchronizedMap[*objects.String, *objects.String](NewLinkedHashMap[*objects.String, *objects.String]())
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestSynchronizedMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return SynchronizedMap[*objects.String, *objects.String](NewLinkedHashMap[*objects.String, *objects.String]())
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestSynchronizedMap_Parallel(t *testing.T) {
+	m := SynchronizedMap[*objects.String, *objects.Int](NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int]())
+
+	const goroutines = 16
+	const keys = 100
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for ix := 0; ix < keys; ix++ {
+				key := objects.WrapString(fmt.Sprintf("key-%d-%d", g, ix))
+				_ = m.Put(key, objects.WrapInt(ix))
+				_, _ = m.GetSafe(objects.WrapString(fmt.Sprintf("key-0-%d", ix)))
+				if ix%10 == 0 {
+					for range m.Entries() {
+					}
+					_ = m.Keys()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	tests.Execute(m.Size()).Equal(t, goroutines*keys)
+	tests.Execute(m.Keys().Size()).Equal(t, goroutines*keys)
+}
+
+func TestSynchronizedMap_Reads(t *testing.T) {
+	for name, tc := range map[string]struct {
+		m         Map[*objects.String, *objects.Int]
+		exclusive bool
+	}{
+		"hash":              {m: NewHashMap[*objects.String, *objects.Int](), exclusive: false},
+		"linked":            {m: NewLinkedHashMap[*objects.String, *objects.Int](), exclusive: false},
+		"access":            {m: NewAccessOrderedLinkedHashMap[*objects.String, *objects.Int](), exclusive: true},
+		"lru":               {m: NewLRUCache[*objects.String, *objects.Int](4), exclusive: true},
+		"bounded":           {m: NewBoundedMap[*objects.String, *objects.Int](NewFIFOPolicy[*objects.String](), 4), exclusive: true},
+		"unmodifiable hash": {m: UnmodifiableMap[*objects.String, *objects.Int](NewHashMap[*objects.String, *objects.Int]()), exclusive: false},
+		"unmodifiable lru":  {m: UnmodifiableMap[*objects.String, *objects.Int](NewLRUCache[*objects.String, *objects.Int](4)), exclusive: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := SynchronizedMap[*objects.String, *objects.Int](tc.m).(*synchronizedMap[*objects.String, *objects.Int])
+			tests.Execute(m.exclusiveReads).Equal(t, tc.exclusive)
+			if tc.exclusive {
+				return
+			}
+
+			// Lookups only need the read lock, so they don't wait for other readers.
+			m.lock.RLock()
+			defer m.lock.RUnlock()
+
+			done := make(chan *objects.Int)
+			go func() {
+				done <- m.GetOrDefault(objects.WrapString("a"), objects.WrapInt(-1))
+			}()
+			select {
+			case value := <-done:
+				tests.Execute(value).Equal(t, objects.WrapInt(-1))
+			case <-time.After(time.Second):
+				t.Fatal("lookup waited for the read lock to be released")
+			}
+		})
+	}
+}
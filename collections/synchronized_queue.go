@@ -0,0 +1,49 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type synchronizedQueue[O objects.Object] struct {
+	synchronizedCollection[O]
+	queue Queue[O]
+}
+
+// SynchronizedQueue returns a queue that guards every method of the given queue with a read-write lock, so it can be
+// shared between goroutines. The given queue must not be used directly afterward.
+func SynchronizedQueue[O objects.Object](queue Queue[O]) Queue[O] {
+	return &synchronizedQueue[O]{
+		synchronizedCollection: synchronizedCollection[O]{
+			collection: queue,
+		},
+		queue: queue,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is synchronized independently of the original.
+func (s *synchronizedQueue[O]) Copy() Collection[O] {
+	return SynchronizedQueue[O](s.snapshot().(Queue[O]))
+}
+
+// Queue implementation
+
+// Offer implements Queue.
+func (s *synchronizedQueue[O]) Offer(value O) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.queue.Offer(value)
+}
+
+// Peep implements Queue.
+func (s *synchronizedQueue[O]) Peep() (O, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.queue.Peep()
+}
+
+// Pop implements Queue.
+func (s *synchronizedQueue[O]) Pop() (O, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.queue.Pop()
+}
@@ -0,0 +1,29 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSynchronizedQueue_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return SynchronizedQueue[*objects.String](NewArrayQueue[*objects.String]())
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestSynchronizedQueue_Queue(t *testing.T) {
+	queue := SynchronizedQueue[*objects.String](NewArrayQueue[*objects.String]())
+
+	tests.ExecuteE(queue.Offer(objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(queue.Offer(objects.WrapString("two"))).NoError(t)
+	tests.Execute2E(queue.Peep()).NoError(t).Equal(t, objects.WrapString("one"))
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapString("one"))
+	tests.Execute2E(queue.Pop()).NoError(t).Equal(t, objects.WrapString("two"))
+	tests.Execute2E(queue.Pop()).Error(t)
+}
@@ -0,0 +1,61 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type synchronizedSet[O objects.Object] struct {
+	synchronizedCollection[O]
+}
+
+// SynchronizedSet returns a set that guards every method of the given set with a read-write lock, so it can be
+// shared between goroutines. The given set must not be used directly afterward.
+func SynchronizedSet[O objects.Object](set Set[O]) Set[O] {
+	return &synchronizedSet[O]{
+		synchronizedCollection: synchronizedCollection[O]{
+			collection: set,
+		},
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is synchronized independently of the original.
+func (s *synchronizedSet[O]) Copy() Collection[O] {
+	return SynchronizedSet[O](s.snapshot().(Set[O]))
+}
+
+// Set implementation
+
+// Union implements Set.
+func (s *synchronizedSet[O]) Union(other Set[O]) Set[O] {
+	return Union[O](s, other)
+}
+
+// Intersection implements Set.
+func (s *synchronizedSet[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](s, other)
+}
+
+// Difference implements Set.
+func (s *synchronizedSet[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](s, other)
+}
+
+// SymmetricDifference implements Set.
+func (s *synchronizedSet[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](s, other)
+}
+
+// IsSubsetOf implements Set.
+func (s *synchronizedSet[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](s, other)
+}
+
+// IsSupersetOf implements Set.
+func (s *synchronizedSet[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](s, other)
+}
+
+// IsDisjoint implements Set.
+func (s *synchronizedSet[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](s, other)
+}
@@ -0,0 +1,17 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+func TestSynchronizedSet_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return SynchronizedSet[*objects.String](NewHashSet[*objects.String]())
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
@@ -0,0 +1,49 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type synchronizedStack[O objects.Object] struct {
+	synchronizedCollection[O]
+	stack Stack[O]
+}
+
+// SynchronizedStack returns a stack that guards every method of the given stack with a read-write lock, so it can be
+// shared between goroutines. The given stack must not be used directly afterward.
+func SynchronizedStack[O objects.Object](stack Stack[O]) Stack[O] {
+	return &synchronizedStack[O]{
+		synchronizedCollection: synchronizedCollection[O]{
+			collection: stack,
+		},
+		stack: stack,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is synchronized independently of the original.
+func (s *synchronizedStack[O]) Copy() Collection[O] {
+	return SynchronizedStack[O](s.snapshot().(Stack[O]))
+}
+
+// Stack implementation
+
+// Offer implements Stack.
+func (s *synchronizedStack[O]) Offer(value O) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stack.Offer(value)
+}
+
+// Peep implements Stack.
+func (s *synchronizedStack[O]) Peep() (O, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.stack.Peep()
+}
+
+// Pop implements Stack.
+func (s *synchronizedStack[O]) Pop() (O, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stack.Pop()
+}
@@ -0,0 +1,29 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestSynchronizedStack_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return SynchronizedStack[*objects.String](NewArrayStack[*objects.String]())
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestSynchronizedStack_Stack(t *testing.T) {
+	stack := SynchronizedStack[*objects.String](NewArrayStack[*objects.String]())
+
+	tests.ExecuteE(stack.Offer(objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(stack.Offer(objects.WrapString("two"))).NoError(t)
+	tests.Execute2E(stack.Peep()).NoError(t).Equal(t, objects.WrapString("two"))
+	tests.Execute2E(stack.Pop()).NoError(t).Equal(t, objects.WrapString("two"))
+	tests.Execute2E(stack.Pop()).NoError(t).Equal(t, objects.WrapString("one"))
+	tests.Execute2E(stack.Pop()).Error(t)
+}
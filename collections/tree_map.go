@@ -0,0 +1,554 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type treeMap[K, V objects.Object] struct {
+	tree *redBlackTree[K, V]
+
+	// bounds restricts the keys visible through this map, which is how the
+	// HeadMap, TailMap and SubMap views share the tree of their parent.
+	bounds sortedBounds[K]
+}
+
+// NewTreeMap creates a new tree map ordered by the natural ordering of the keys, with the given elements.
+func NewTreeMap[K objects.ComparableObject[K], V objects.Object](entries ...MapEntry[K, V]) SortedMap[K, V] {
+	return NewTreeMapT[K, V](objects.ComparableComparator[K](), entries...)
+}
+
+// NewTreeMapT creates a new tree map ordered by the given comparator, with the given elements.
+func NewTreeMapT[K, V objects.Object](comparator objects.Comparator[K], entries ...MapEntry[K, V]) SortedMap[K, V] {
+	m := &treeMap[K, V]{
+		tree: newRedBlackTree[K, V](comparator),
+	}
+	for _, entry := range entries {
+		_ = m.Put(entry.GetKey(), entry.GetValue())
+	}
+	return m
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (m *treeMap[K, V]) Equals(other any) bool {
+	return mapEquals[K, V](m, other)
+}
+
+// HashCode implements objects.Object.
+func (m *treeMap[K, V]) HashCode() uint64 {
+	return mapHashCode[K, V](m)
+}
+
+// String implements objects.Object.
+func (m *treeMap[K, V]) String() string {
+	return mapString[K, V](m)
+}
+
+// MarshalJSON implements objects.Object.
+func (m *treeMap[K, V]) MarshalJSON() ([]byte, error) {
+	var entries []*mapEntry[K, V]
+	for node := m.first(); node != nil; node = m.successor(node) {
+		entries = append(entries, &mapEntry[K, V]{
+			Key:   node.key,
+			Value: node.value,
+		})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements objects.Object.
+func (m *treeMap[K, V]) UnmarshalJSON(bytes []byte) error {
+	var entries []*mapEntry[K, V]
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (m *treeMap[K, V]) Iterator() objects.Iterator[MapEntry[K, V]] {
+	return &treeMapIterator[K, V]{
+		m:        m,
+		next:     m.first(),
+		modCount: m.tree.modCount,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (m *treeMap[K, V]) Elems() iter.Seq[MapEntry[K, V]] {
+	return objects.SequenceFrom[MapEntry[K, V]](m)
+}
+
+// Add implements Collection.
+func (m *treeMap[K, V]) Add(value MapEntry[K, V]) error {
+	return m.Put(value.GetKey(), value.GetValue())
+}
+
+// AddAll implements Collection.
+func (m *treeMap[K, V]) AddAll(values Collection[MapEntry[K, V]]) error {
+	return collectionAddAll[MapEntry[K, V]](m, values)
+}
+
+// Remove implements Collection.
+func (m *treeMap[K, V]) Remove(value MapEntry[K, V]) error {
+	if !m.Contains(value) {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", value.GetKey())
+	}
+
+	_, err := m.Delete(value.GetKey())
+	return err
+}
+
+// RemoveAll implements Collection.
+func (m *treeMap[K, V]) RemoveAll(values Collection[MapEntry[K, V]]) error {
+	return collectionRemoveAll[MapEntry[K, V]](m, values)
+}
+
+// Contains implements Collection.
+func (m *treeMap[K, V]) Contains(value MapEntry[K, V]) bool {
+	node := m.find(value.GetKey())
+	if node == nil {
+		return false
+	}
+	return value.GetValue().Equals(node.value)
+}
+
+// ContainsAll implements Collection.
+func (m *treeMap[K, V]) ContainsAll(values Collection[MapEntry[K, V]]) bool {
+	return collectionContainsAll[MapEntry[K, V]](m, values)
+}
+
+// RemoveIf implements Collection. Calling RemoveIf on a view only considers the entries within the view.
+func (m *treeMap[K, V]) RemoveIf(condition func(value MapEntry[K, V]) bool) int {
+	removed := 0
+	for node := m.first(); node != nil; {
+		if !condition(&mapEntry[K, V]{Key: node.key, Value: node.value}) {
+			node = m.successor(node)
+			continue
+		}
+
+		node = m.tree.deleteAndSuccessor(node)
+		if node != nil && m.bounds.tooHigh(m.tree.comparator, node.key) {
+			node = nil
+		}
+		removed = removed + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (m *treeMap[K, V]) RetainAll(values Collection[MapEntry[K, V]]) int {
+	return collectionRetainAll[MapEntry[K, V]](m, values)
+}
+
+// Copy implements Collection. Copying a view returns a new map holding only the entries within the view.
+func (m *treeMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	newMap := &treeMap[K, V]{
+		tree: newRedBlackTree[K, V](m.tree.comparator),
+	}
+	for node := m.first(); node != nil; node = m.successor(node) {
+		newMap.tree.insert(node.key, node.value)
+	}
+	return newMap
+}
+
+// Size implements Collection.
+func (m *treeMap[K, V]) Size() int {
+	if m.bounds.unbounded() {
+		return m.tree.size
+	}
+
+	size := 0
+	for node := m.first(); node != nil; node = m.successor(node) {
+		size++
+	}
+	return size
+}
+
+// IsEmpty implements Collection.
+func (m *treeMap[K, V]) IsEmpty() bool {
+	return m.first() == nil
+}
+
+// Clear implements Collection. Clearing a view only removes the entries within the view.
+func (m *treeMap[K, V]) Clear() {
+	if m.bounds.unbounded() {
+		m.tree.clear()
+		return
+	}
+
+	for node := m.first(); node != nil; {
+		node = m.tree.deleteAndSuccessor(node)
+		if node != nil && m.bounds.tooHigh(m.tree.comparator, node.key) {
+			node = nil
+		}
+	}
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *treeMap[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		modCount := m.tree.modCount
+		for node := m.first(); node != nil; node = m.successor(node) {
+			if !yield(node.key, node.value) {
+				return
+			}
+			checkForComodification(modCount, m.tree.modCount)
+		}
+	}
+}
+
+// ContainsKey implements Map.
+func (m *treeMap[K, V]) ContainsKey(key K) bool {
+	return m.find(key) != nil
+}
+
+// Put implements Map.
+func (m *treeMap[K, V]) Put(key K, value V) error {
+	if !m.bounds.contains(m.tree.comparator, key) {
+		return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "key out of range"), "key", key)
+	}
+
+	if _, inserted := m.tree.insert(key, value); !inserted {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "key", key)
+	}
+	return nil
+}
+
+// Replace implements Map.
+func (m *treeMap[K, V]) Replace(key K, value V) (V, error) {
+	node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+
+	oldValue := node.value
+	node.value = value
+	return oldValue, nil
+}
+
+// PutOrReplace implements Map. Inserting a key outside the range of a view panics with an ErrorCodeOutOfBounds error,
+// as it does for PutIfAbsent.
+func (m *treeMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	m.checkBounds(key)
+
+	node, inserted := m.tree.insert(key, value)
+	if inserted {
+		return value, false
+	}
+
+	oldValue := node.value
+	node.value = value
+	return oldValue, true
+}
+
+// Delete implements Map.
+func (m *treeMap[K, V]) Delete(key K) (V, error) {
+	value, ok := m.DeleteIfPresent(key)
+	if !ok {
+		return value, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return value, nil
+}
+
+// DeleteIfPresent implements Map.
+func (m *treeMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+
+	// Take the value first, as deleting can move node contents around.
+	value := node.value
+	m.tree.delete(node)
+	return value, true
+}
+
+// Get implements Map.
+func (m *treeMap[K, V]) Get(key K) V {
+	node := m.find(key)
+	if node == nil {
+		panic("not found")
+	}
+	return node.value
+}
+
+// GetSafe implements Map.
+func (m *treeMap[K, V]) GetSafe(key K) (V, error) {
+	node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return node.value, nil
+}
+
+// GetOrDefault implements Map.
+func (m *treeMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	node := m.find(key)
+	if node == nil {
+		return defaultValue
+	}
+	return node.value
+}
+
+// PutIfAbsent implements Map. Inserting a key outside the range of a view panics with an ErrorCodeOutOfBounds error,
+// as do PutOrReplace, ComputeIfAbsent, Compute and Merge.
+func (m *treeMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	m.checkBounds(key)
+
+	node, inserted := m.tree.insert(key, value)
+	return node.value, !inserted
+}
+
+// ComputeIfAbsent implements Map.
+func (m *treeMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	if node := m.find(key); node != nil {
+		return node.value
+	}
+	m.checkBounds(key)
+
+	modCount := m.tree.modCount
+	value := fn(key)
+	checkForComodification(modCount, m.tree.modCount)
+
+	m.tree.insert(key, value)
+	return value
+}
+
+// ComputeIfPresent implements Map.
+func (m *treeMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		var obj V
+		return obj, false
+	}
+
+	modCount := m.tree.modCount
+	value, keep := fn(key, node.value)
+	checkForComodification(modCount, m.tree.modCount)
+
+	if !keep {
+		m.tree.delete(node)
+		var obj V
+		return obj, false
+	}
+	node.value = value
+	return value, true
+}
+
+// Compute implements Map.
+func (m *treeMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	node := m.find(key)
+
+	var current V
+	if node != nil {
+		current = node.value
+	}
+
+	modCount := m.tree.modCount
+	value, keep := fn(key, current, node != nil)
+	checkForComodification(modCount, m.tree.modCount)
+
+	if !keep {
+		if node != nil {
+			m.tree.delete(node)
+		}
+		var obj V
+		return obj, false
+	}
+
+	if node != nil {
+		node.value = value
+	} else {
+		m.checkBounds(key)
+		m.tree.insert(key, value)
+	}
+	return value, true
+}
+
+// Merge implements Map.
+func (m *treeMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		m.checkBounds(key)
+		m.tree.insert(key, value)
+		return value, true
+	}
+
+	modCount := m.tree.modCount
+	merged, keep := remapping(node.value, value)
+	checkForComodification(modCount, m.tree.modCount)
+
+	if !keep {
+		m.tree.delete(node)
+		var obj V
+		return obj, false
+	}
+	node.value = merged
+	return merged, true
+}
+
+// ReplaceAllValues implements Map. Calling ReplaceAllValues on a view only replaces the values within the view.
+func (m *treeMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	modCount := m.tree.modCount
+	for node := m.first(); node != nil; node = m.successor(node) {
+		value := fn(node.key, node.value)
+		checkForComodification(modCount, m.tree.modCount)
+		node.value = value
+	}
+}
+
+// Keys implements Map. The keys are returned as a sorted set using the same comparator as the map.
+func (m *treeMap[K, V]) Keys() Collection[K] {
+	set := &treeSet[K]{
+		tree: newRedBlackTree[K, struct{}](m.tree.comparator),
+	}
+	for node := m.first(); node != nil; node = m.successor(node) {
+		set.tree.insert(node.key, struct{}{})
+	}
+	return set
+}
+
+// Values implements Map. The values are returned in the ascending order of their keys.
+func (m *treeMap[K, V]) Values() Collection[V] {
+	list := NewArrayList[V]()
+	for node := m.first(); node != nil; node = m.successor(node) {
+		if err := list.Add(node.value); err != nil {
+			panic(err)
+		}
+	}
+	return list
+}
+
+// SortedMap implementation
+
+// Comparator implements SortedMap.
+func (m *treeMap[K, V]) Comparator() objects.Comparator[K] {
+	return m.tree.comparator
+}
+
+// FirstKey implements SortedMap.
+func (m *treeMap[K, V]) FirstKey() (K, error) {
+	return nodeKey(m.first())
+}
+
+// LastKey implements SortedMap.
+func (m *treeMap[K, V]) LastKey() (K, error) {
+	return nodeKey(m.tree.boundedLast(m.bounds))
+}
+
+// Floor implements SortedMap.
+func (m *treeMap[K, V]) Floor(key K) (MapEntry[K, V], error) {
+	return nodeEntry(m.tree.boundedFloor(m.bounds, key, true), key)
+}
+
+// Ceiling implements SortedMap.
+func (m *treeMap[K, V]) Ceiling(key K) (MapEntry[K, V], error) {
+	return nodeEntry(m.tree.boundedCeiling(m.bounds, key, true), key)
+}
+
+// Lower implements SortedMap.
+func (m *treeMap[K, V]) Lower(key K) (MapEntry[K, V], error) {
+	return nodeEntry(m.tree.boundedFloor(m.bounds, key, false), key)
+}
+
+// Higher implements SortedMap.
+func (m *treeMap[K, V]) Higher(key K) (MapEntry[K, V], error) {
+	return nodeEntry(m.tree.boundedCeiling(m.bounds, key, false), key)
+}
+
+// HeadMap implements SortedMap.
+func (m *treeMap[K, V]) HeadMap(to K, inclusive bool) SortedMap[K, V] {
+	return &treeMap[K, V]{
+		tree:   m.tree,
+		bounds: m.bounds.withTo(m.tree.comparator, to, inclusive),
+	}
+}
+
+// TailMap implements SortedMap.
+func (m *treeMap[K, V]) TailMap(from K, inclusive bool) SortedMap[K, V] {
+	return &treeMap[K, V]{
+		tree:   m.tree,
+		bounds: m.bounds.withFrom(m.tree.comparator, from, inclusive),
+	}
+}
+
+// SubMap implements SortedMap.
+func (m *treeMap[K, V]) SubMap(from K, fromInclusive bool, to K, toInclusive bool) SortedMap[K, V] {
+	return &treeMap[K, V]{
+		tree: m.tree,
+		bounds: m.bounds.
+			withFrom(m.tree.comparator, from, fromInclusive).
+			withTo(m.tree.comparator, to, toInclusive),
+	}
+}
+
+// tree map
+
+// find returns the node for the given key if it is within the bounds of the map.
+func (m *treeMap[K, V]) find(key K) *redBlackNode[K, V] {
+	if !m.bounds.contains(m.tree.comparator, key) {
+		return nil
+	}
+	return m.tree.find(key)
+}
+
+// checkBounds panics if the given key is outside the bounds of the map.
+func (m *treeMap[K, V]) checkBounds(key K) {
+	if !m.bounds.contains(m.tree.comparator, key) {
+		panic(errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "key out of range"), "key", key))
+	}
+}
+
+// first returns the smallest node within the bounds of the map.
+func (m *treeMap[K, V]) first() *redBlackNode[K, V] {
+	return m.tree.boundedFirst(m.bounds)
+}
+
+// successor returns the node following the given node, if it is within the bounds of the map.
+func (m *treeMap[K, V]) successor(node *redBlackNode[K, V]) *redBlackNode[K, V] {
+	next := node.successor()
+	if next == nil || m.bounds.tooHigh(m.tree.comparator, next.key) {
+		return nil
+	}
+	return next
+}
+
+func nodeKey[K objects.Object, V any](node *redBlackNode[K, V]) (K, error) {
+	if node == nil {
+		var obj K
+		return obj, errors.New(nil, ErrorCodeNotFound, "not found")
+	}
+	return node.key, nil
+}
+
+func nodeEntry[K, V objects.Object](node *redBlackNode[K, V], key K) (MapEntry[K, V], error) {
+	if node == nil {
+		return nil, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "key", key)
+	}
+	return &mapEntry[K, V]{
+		Key:   node.key,
+		Value: node.value,
+	}, nil
+}
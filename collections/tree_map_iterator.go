@@ -0,0 +1,32 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// treeMapIterator is an iterator for treeMap, returning entries in ascending key order.
+type treeMapIterator[K, V objects.Object] struct {
+	m    *treeMap[K, V]
+	next *redBlackNode[K, V]
+
+	// modCount is the modification count of the tree when the iterator was created.
+	modCount int
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *treeMapIterator[K, V]) HasNext() bool {
+	return iterator.next != nil
+}
+
+// Next implements objects.Iterator.
+func (iterator *treeMapIterator[K, V]) Next() MapEntry[K, V] {
+	checkForComodification(iterator.modCount, iterator.m.tree.modCount)
+	if iterator.next == nil {
+		panic("out of bounds")
+	}
+
+	current := iterator.next
+	iterator.next = iterator.m.successor(current)
+	return &mapEntry[K, V]{
+		Key:   current.key,
+		Value: current.value,
+	}
+}
@@ -0,0 +1,244 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+// ascendingInts orders objects.Int numerically, as objects.Int.CompareTo sorts in descending order.
+func ascendingInts() objects.Comparator[*objects.Int] {
+	return objects.ReverseComparator[*objects.Int](objects.ComparableComparator[*objects.Int]())
+}
+
+func TestTreeMap_Collection(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runCollectionTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewTreeMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestTreeMap_FailFast(t *testing.T) {
+	makeEntry := func(key, value string) MapEntry[*objects.String, *objects.String] {
+		return &mapEntry[*objects.String, *objects.String]{
+			Key:   objects.WrapString(key),
+			Value: objects.WrapString(value),
+		}
+	}
+
+	runFailFastTests(t, func() Collection[MapEntry[*objects.String, *objects.String]] {
+		return NewTreeMap[*objects.String, *objects.String]()
+	}, map[string]MapEntry[*objects.String, *objects.String]{
+		"one":   makeEntry("one", "four"),
+		"two":   makeEntry("two", "five"),
+		"three": makeEntry("three", "six"),
+	})
+}
+
+func TestTreeMap_MapFailFast(t *testing.T) {
+	runMapFailFastTests(t, func() Map[*objects.String, *objects.String] {
+		return NewTreeMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestTreeMap_Map(t *testing.T) {
+	runMapTests(t, func() Map[*objects.String, *objects.String] {
+		return NewTreeMap[*objects.String, *objects.String]()
+	}, map[string]*objects.String{
+		"zero":  objects.WrapString("zero"),
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+		"four":  objects.WrapString("four"),
+		"five":  objects.WrapString("five"),
+	})
+}
+
+func TestTreeMap_Ordering(t *testing.T) {
+	m := NewTreeMapT[*objects.Int, *objects.String](ascendingInts())
+
+	var keys []int
+	for _, key := range rand.New(rand.NewSource(0)).Perm(500) {
+		keys = append(keys, key)
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	// Delete every third key to exercise rebalancing on removal.
+	for ix := 0; ix < len(keys); ix += 3 {
+		tests.Execute2E(m.Delete(objects.WrapInt(keys[ix]))).NoError(t)
+	}
+
+	var expected []int
+	for ix, key := range keys {
+		if ix%3 != 0 {
+			expected = append(expected, key)
+		}
+	}
+	sort.Ints(expected)
+
+	var actual []int
+	for key := range m.Entries() {
+		actual = append(actual, key.Unwrap())
+	}
+	tests.Execute(actual).Equal(t, expected)
+	tests.Execute(m.Size()).Equal(t, len(expected))
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	m := NewTreeMapT[*objects.Int, *objects.String](ascendingInts())
+	for _, key := range []int{10, 20, 30, 40} {
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	tests.Execute2E(m.FirstKey()).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(m.LastKey()).NoError(t).Equal(t, objects.WrapInt(40))
+
+	key := func(entry MapEntry[*objects.Int, *objects.String], err error) (*objects.Int, error) {
+		if err != nil {
+			return nil, err
+		}
+		return entry.GetKey(), nil
+	}
+
+	tests.Execute2E(key(m.Floor(objects.WrapInt(25)))).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(key(m.Floor(objects.WrapInt(20)))).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(key(m.Floor(objects.WrapInt(5)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(25)))).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(30)))).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(key(m.Ceiling(objects.WrapInt(45)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Lower(objects.WrapInt(20)))).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(key(m.Lower(objects.WrapInt(10)))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.Execute2E(key(m.Higher(objects.WrapInt(30)))).NoError(t).Equal(t, objects.WrapInt(40))
+	tests.Execute2E(key(m.Higher(objects.WrapInt(40)))).ErrorCode(t, ErrorCodeNotFound)
+
+	empty := NewTreeMapT[*objects.Int, *objects.String](ascendingInts())
+	tests.Execute2E(empty.FirstKey()).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute2E(empty.LastKey()).ErrorCode(t, ErrorCodeNotFound)
+}
+
+func TestTreeMap_Views(t *testing.T) {
+	m := NewTreeMapT[*objects.Int, *objects.String](ascendingInts())
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	keys := func(m SortedMap[*objects.Int, *objects.String]) []int {
+		var keys []int
+		for key := range m.Entries() {
+			keys = append(keys, key.Unwrap())
+		}
+		return keys
+	}
+
+	tests.Execute(keys(m.HeadMap(objects.WrapInt(30), false))).Equal(t, []int{10, 20})
+	tests.Execute(keys(m.HeadMap(objects.WrapInt(30), true))).Equal(t, []int{10, 20, 30})
+	tests.Execute(keys(m.TailMap(objects.WrapInt(30), false))).Equal(t, []int{40, 50})
+	tests.Execute(keys(m.TailMap(objects.WrapInt(30), true))).Equal(t, []int{30, 40, 50})
+	tests.Execute(keys(m.SubMap(objects.WrapInt(15), true, objects.WrapInt(40), true))).Equal(t, []int{20, 30, 40})
+
+	view := m.SubMap(objects.WrapInt(20), true, objects.WrapInt(40), false)
+	tests.Execute(view.Size()).Equal(t, 2)
+	tests.Execute(view.ContainsKey(objects.WrapInt(10))).Equal(t, false)
+	tests.Execute2E(view.FirstKey()).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(view.LastKey()).NoError(t).Equal(t, objects.WrapInt(30))
+
+	// Writes through the view are visible in the map and vice versa.
+	tests.ExecuteE(view.Put(objects.WrapInt(25), objects.WrapString("value"))).NoError(t)
+	tests.ExecuteE(view.Put(objects.WrapInt(45), objects.WrapString("value"))).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.Execute(m.ContainsKey(objects.WrapInt(25))).Equal(t, true)
+	tests.ExecuteE(m.Put(objects.WrapInt(35), objects.WrapString("value"))).NoError(t)
+	tests.Execute(keys(view)).Equal(t, []int{20, 25, 30, 35})
+
+	// Narrowing a view never widens it.
+	tests.Execute(keys(view.HeadMap(objects.WrapInt(50), true))).Equal(t, []int{20, 25, 30, 35})
+
+	// Inserting outside the view panics, while looking up outside it finds nothing.
+	tests.Execute(view.GetOrDefault(objects.WrapInt(10), objects.WrapString("default"))).Equal(t, objects.WrapString("default"))
+	expectErrorCode(t, ErrorCodeOutOfBounds, func() {
+		view.PutIfAbsent(objects.WrapInt(45), objects.WrapString("value"))
+	})
+	expectErrorCode(t, ErrorCodeOutOfBounds, func() {
+		view.PutOrReplace(objects.WrapInt(10), objects.WrapString("other"))
+	})
+	tests.Execute(m.Get(objects.WrapInt(10))).Equal(t, objects.WrapString("value"))
+	tests.Execute2(view.ComputeIfPresent(objects.WrapInt(10), func(key *objects.Int, current *objects.String) (*objects.String, bool) {
+		return nil, false
+	})).Equal(t, false)
+	tests.Execute(m.ContainsKey(objects.WrapInt(10))).Equal(t, true)
+
+	view.Clear()
+	tests.Execute(view.IsEmpty()).Equal(t, true)
+	tests.Execute(keys(m)).Equal(t, []int{10, 40, 50})
+}
+
+func TestTreeMap_RemoveIf(t *testing.T) {
+	m := NewTreeMapT[*objects.Int, *objects.String](ascendingInts())
+	for key := 0; key < 100; key++ {
+		tests.ExecuteE(m.Put(objects.WrapInt(key), objects.WrapString("value"))).NoError(t)
+	}
+
+	// Removing from a view only removes entries within the view, even though
+	// the condition would match entries outside it.
+	view := m.SubMap(objects.WrapInt(10), true, objects.WrapInt(20), false)
+	removed := view.RemoveIf(func(entry MapEntry[*objects.Int, *objects.String]) bool {
+		return entry.GetKey().Unwrap()%2 == 0
+	})
+	tests.Execute(removed).Equal(t, 5)
+	tests.Execute(view.Size()).Equal(t, 5)
+	tests.Execute(m.Size()).Equal(t, 95)
+
+	removed = m.RemoveIf(func(entry MapEntry[*objects.Int, *objects.String]) bool {
+		return entry.GetKey().Unwrap()%3 != 0
+	})
+	tests.Execute(removed).Equal(t, 63)
+
+	var expected, actual []int
+	for key := 0; key < 100; key += 3 {
+		if key != 12 && key != 18 {
+			expected = append(expected, key)
+		}
+	}
+	for key := range m.Entries() {
+		actual = append(actual, key.Unwrap())
+	}
+	tests.Execute(actual).Equal(t, expected)
+}
+
+func TestTreeMap_JSON(t *testing.T) {
+	m := NewTreeMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(m.Put(objects.WrapString("b"), objects.WrapInt(2))).NoError(t)
+	tests.ExecuteE(m.Put(objects.WrapString("a"), objects.WrapInt(1))).NoError(t)
+
+	data, err := json.Marshal(m)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(string(data)).Equal(t, `[{"key":"a","value":1},{"key":"b","value":2}]`)
+
+	other := NewTreeMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.Equals(m)).Equal(t, true)
+	tests.Execute(other.String()).Equal(t, "{a:1,b:2}")
+}
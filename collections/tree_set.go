@@ -0,0 +1,356 @@
+package collections
+
+import (
+	"encoding/json"
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type treeSet[O objects.Object] struct {
+	tree *redBlackTree[O, struct{}]
+
+	// bounds restricts the elements visible through this set, which is how the
+	// HeadSet, TailSet and SubSet views share the tree of their parent.
+	bounds sortedBounds[O]
+}
+
+// NewTreeSet creates a new tree set ordered by the natural ordering of the elements, with the given elements.
+func NewTreeSet[O objects.ComparableObject[O]](elems ...O) SortedSet[O] {
+	return NewTreeSetT[O](objects.ComparableComparator[O](), elems...)
+}
+
+// NewTreeSetT creates a new tree set ordered by the given comparator, with the given elements.
+func NewTreeSetT[O objects.Object](comparator objects.Comparator[O], elems ...O) SortedSet[O] {
+	set := &treeSet[O]{
+		tree: newRedBlackTree[O, struct{}](comparator),
+	}
+	for _, elem := range elems {
+		_ = set.Add(elem)
+	}
+	return set
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (set *treeSet[O]) Equals(other any) bool {
+	return setEquals[O](set, other)
+}
+
+// HashCode implements objects.Object.
+func (set *treeSet[O]) HashCode() uint64 {
+	return setHashCode[O](set)
+}
+
+// String implements objects.Object.
+func (set *treeSet[O]) String() string {
+	return setString[O](set)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (set *treeSet[O]) MarshalJSON() ([]byte, error) {
+	var values []O
+	for node := set.first(); node != nil; node = set.successor(node) {
+		values = append(values, node.key)
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (set *treeSet[O]) UnmarshalJSON(bytes []byte) error {
+	var values []O
+	if err := json.Unmarshal(bytes, &values); err != nil {
+		return err
+	}
+
+	set.Clear()
+	for _, value := range values {
+		if err := set.Add(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (set *treeSet[O]) Iterator() objects.Iterator[O] {
+	return &treeSetIterator[O]{
+		set:      set,
+		next:     set.first(),
+		modCount: set.tree.modCount,
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (set *treeSet[O]) Elems() iter.Seq[O] {
+	return objects.SequenceFrom[O](set)
+}
+
+// Contains implements Collection.
+func (set *treeSet[O]) Contains(value O) bool {
+	return set.find(value) != nil
+}
+
+// ContainsAll implements Collection.
+func (set *treeSet[O]) ContainsAll(values Collection[O]) bool {
+	return collectionContainsAll[O](set, values)
+}
+
+// RemoveIf implements Collection. Calling RemoveIf on a view only considers the elements within the view.
+func (set *treeSet[O]) RemoveIf(condition func(value O) bool) int {
+	removed := 0
+	for node := set.first(); node != nil; {
+		if !condition(node.key) {
+			node = set.successor(node)
+			continue
+		}
+
+		node = set.tree.deleteAndSuccessor(node)
+		if node != nil && set.bounds.tooHigh(set.tree.comparator, node.key) {
+			node = nil
+		}
+		removed = removed + 1
+	}
+	return removed
+}
+
+// RetainAll implements Collection.
+func (set *treeSet[O]) RetainAll(values Collection[O]) int {
+	return collectionRetainAll[O](set, values)
+}
+
+// Add implements Collection.
+func (set *treeSet[O]) Add(value O) error {
+	if !set.bounds.contains(set.tree.comparator, value) {
+		return errors.Embed(errors.New(nil, ErrorCodeOutOfBounds, "value out of range"), "value", value)
+	}
+
+	if _, inserted := set.tree.insert(value, struct{}{}); !inserted {
+		return errors.Embed(errors.New(nil, ErrorCodeAlreadyExists, "already exists"), "value", value)
+	}
+	return nil
+}
+
+// AddAll implements Collection.
+func (set *treeSet[O]) AddAll(values Collection[O]) error {
+	return collectionAddAll[O](set, values)
+}
+
+// Remove implements Collection.
+func (set *treeSet[O]) Remove(value O) error {
+	node := set.find(value)
+	if node == nil {
+		return errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+	set.tree.delete(node)
+	return nil
+}
+
+// RemoveAll implements Collection.
+func (set *treeSet[O]) RemoveAll(values Collection[O]) error {
+	return collectionRemoveAll[O](set, values)
+}
+
+// Copy implements Collection. Copying a view returns a new set holding only the elements within the view.
+func (set *treeSet[O]) Copy() Collection[O] {
+	newSet := &treeSet[O]{
+		tree: newRedBlackTree[O, struct{}](set.tree.comparator),
+	}
+	for node := set.first(); node != nil; node = set.successor(node) {
+		newSet.tree.insert(node.key, struct{}{})
+	}
+	return newSet
+}
+
+// Size implements Collection.
+func (set *treeSet[O]) Size() int {
+	if set.bounds.unbounded() {
+		return set.tree.size
+	}
+
+	size := 0
+	for node := set.first(); node != nil; node = set.successor(node) {
+		size++
+	}
+	return size
+}
+
+// IsEmpty implements Collection.
+func (set *treeSet[O]) IsEmpty() bool {
+	return set.first() == nil
+}
+
+// Clear implements Collection. Clearing a view only removes the elements within the view.
+func (set *treeSet[O]) Clear() {
+	if set.bounds.unbounded() {
+		set.tree.clear()
+		return
+	}
+
+	for node := set.first(); node != nil; {
+		node = set.tree.deleteAndSuccessor(node)
+		if node != nil && set.bounds.tooHigh(set.tree.comparator, node.key) {
+			node = nil
+		}
+	}
+}
+
+// Set implementation
+
+// Union implements Set.
+func (set *treeSet[O]) Union(other Set[O]) Set[O] {
+	return Union[O](set, other)
+}
+
+// Intersection implements Set.
+func (set *treeSet[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](set, other)
+}
+
+// Difference implements Set.
+func (set *treeSet[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](set, other)
+}
+
+// SymmetricDifference implements Set.
+func (set *treeSet[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](set, other)
+}
+
+// IsSubsetOf implements Set.
+func (set *treeSet[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](set, other)
+}
+
+// IsSupersetOf implements Set.
+func (set *treeSet[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](set, other)
+}
+
+// IsDisjoint implements Set.
+func (set *treeSet[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](set, other)
+}
+
+// SortedSet implementation
+
+// Comparator implements SortedSet.
+func (set *treeSet[O]) Comparator() objects.Comparator[O] {
+	return set.tree.comparator
+}
+
+// First implements SortedSet.
+func (set *treeSet[O]) First() (O, error) {
+	return nodeKey(set.first())
+}
+
+// Last implements SortedSet.
+func (set *treeSet[O]) Last() (O, error) {
+	return nodeKey(set.tree.boundedLast(set.bounds))
+}
+
+// Floor implements SortedSet.
+func (set *treeSet[O]) Floor(value O) (O, error) {
+	return nodeElement(set.tree.boundedFloor(set.bounds, value, true), value)
+}
+
+// Ceiling implements SortedSet.
+func (set *treeSet[O]) Ceiling(value O) (O, error) {
+	return nodeElement(set.tree.boundedCeiling(set.bounds, value, true), value)
+}
+
+// Lower implements SortedSet.
+func (set *treeSet[O]) Lower(value O) (O, error) {
+	return nodeElement(set.tree.boundedFloor(set.bounds, value, false), value)
+}
+
+// Higher implements SortedSet.
+func (set *treeSet[O]) Higher(value O) (O, error) {
+	return nodeElement(set.tree.boundedCeiling(set.bounds, value, false), value)
+}
+
+// PollFirst implements SortedSet.
+func (set *treeSet[O]) PollFirst() (O, error) {
+	node := set.first()
+	value, err := nodeKey(node)
+	if err != nil {
+		return value, err
+	}
+	set.tree.delete(node)
+	return value, nil
+}
+
+// PollLast implements SortedSet.
+func (set *treeSet[O]) PollLast() (O, error) {
+	node := set.tree.boundedLast(set.bounds)
+	value, err := nodeKey(node)
+	if err != nil {
+		return value, err
+	}
+	set.tree.delete(node)
+	return value, nil
+}
+
+// HeadSet implements SortedSet.
+func (set *treeSet[O]) HeadSet(to O, inclusive bool) SortedSet[O] {
+	return &treeSet[O]{
+		tree:   set.tree,
+		bounds: set.bounds.withTo(set.tree.comparator, to, inclusive),
+	}
+}
+
+// TailSet implements SortedSet.
+func (set *treeSet[O]) TailSet(from O, inclusive bool) SortedSet[O] {
+	return &treeSet[O]{
+		tree:   set.tree,
+		bounds: set.bounds.withFrom(set.tree.comparator, from, inclusive),
+	}
+}
+
+// SubSet implements SortedSet.
+func (set *treeSet[O]) SubSet(from O, fromInclusive bool, to O, toInclusive bool) SortedSet[O] {
+	return &treeSet[O]{
+		tree: set.tree,
+		bounds: set.bounds.
+			withFrom(set.tree.comparator, from, fromInclusive).
+			withTo(set.tree.comparator, to, toInclusive),
+	}
+}
+
+// tree set
+
+// find returns the node for the given value if it is within the bounds of the set.
+func (set *treeSet[O]) find(value O) *redBlackNode[O, struct{}] {
+	if !set.bounds.contains(set.tree.comparator, value) {
+		return nil
+	}
+	return set.tree.find(value)
+}
+
+// first returns the smallest node within the bounds of the set.
+func (set *treeSet[O]) first() *redBlackNode[O, struct{}] {
+	return set.tree.boundedFirst(set.bounds)
+}
+
+// successor returns the node following the given node, if it is within the bounds of the set.
+func (set *treeSet[O]) successor(node *redBlackNode[O, struct{}]) *redBlackNode[O, struct{}] {
+	next := node.successor()
+	if next == nil || set.bounds.tooHigh(set.tree.comparator, next.key) {
+		return nil
+	}
+	return next
+}
+
+func nodeElement[O objects.Object](node *redBlackNode[O, struct{}], value O) (O, error) {
+	if node == nil {
+		var obj O
+		return obj, errors.Embed(errors.New(nil, ErrorCodeNotFound, "not found"), "value", value)
+	}
+	return node.key, nil
+}
@@ -0,0 +1,29 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+// treeSetIterator is an iterator for treeSet, returning elements in ascending order.
+type treeSetIterator[O objects.Object] struct {
+	set  *treeSet[O]
+	next *redBlackNode[O, struct{}]
+
+	// modCount is the modification count of the tree when the iterator was created.
+	modCount int
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *treeSetIterator[O]) HasNext() bool {
+	return iterator.next != nil
+}
+
+// Next implements objects.Iterator.
+func (iterator *treeSetIterator[O]) Next() O {
+	checkForComodification(iterator.modCount, iterator.set.tree.modCount)
+	if iterator.next == nil {
+		panic("out of bounds")
+	}
+
+	current := iterator.next
+	iterator.next = iterator.set.successor(current)
+	return current.key
+}
@@ -0,0 +1,106 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestTreeSet_Collection(t *testing.T) {
+	runCollectionTests(t, func() Collection[*objects.String] {
+		return NewTreeSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestTreeSet_FailFast(t *testing.T) {
+	runFailFastTests(t, func() Collection[*objects.String] {
+		return NewTreeSet[*objects.String]()
+	}, map[string]*objects.String{
+		"one":   objects.WrapString("one"),
+		"two":   objects.WrapString("two"),
+		"three": objects.WrapString("three"),
+	})
+}
+
+func TestTreeSet_Ordering(t *testing.T) {
+	set := NewTreeSet[*objects.String](
+		objects.WrapString("c"),
+		objects.WrapString("a"),
+		objects.WrapString("d"),
+		objects.WrapString("b"))
+
+	var values []string
+	for value := range set.Elems() {
+		values = append(values, value.Unwrap())
+	}
+	tests.Execute(values).Equal(t, []string{"a", "b", "c", "d"})
+	tests.Execute(set.String()).Equal(t, "[a,b,c,d]")
+
+	data, err := json.Marshal(set)
+	tests.ExecuteE(err).NoError(t, tests.Fatal)
+	tests.Execute(string(data)).Equal(t, `["a","b","c","d"]`)
+
+	other := NewTreeSet[*objects.String]()
+	tests.ExecuteE(json.Unmarshal(data, other)).NoError(t)
+	tests.Execute(other.Equals(set)).Equal(t, true)
+}
+
+func TestTreeSet_Navigation(t *testing.T) {
+	set := NewTreeSetT[*objects.Int](ascendingInts(),
+		objects.WrapInt(10),
+		objects.WrapInt(20),
+		objects.WrapInt(30),
+		objects.WrapInt(40))
+
+	tests.Execute2E(set.First()).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(set.Last()).NoError(t).Equal(t, objects.WrapInt(40))
+
+	tests.Execute2E(set.Floor(objects.WrapInt(25))).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(set.Floor(objects.WrapInt(5))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute2E(set.Ceiling(objects.WrapInt(25))).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(set.Ceiling(objects.WrapInt(45))).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute2E(set.Lower(objects.WrapInt(20))).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(set.Higher(objects.WrapInt(30))).NoError(t).Equal(t, objects.WrapInt(40))
+
+	tests.Execute2E(set.PollFirst()).NoError(t).Equal(t, objects.WrapInt(10))
+	tests.Execute2E(set.PollLast()).NoError(t).Equal(t, objects.WrapInt(40))
+	tests.Execute(set.Size()).Equal(t, 2)
+
+	tests.Execute2E(set.PollFirst()).NoError(t).Equal(t, objects.WrapInt(20))
+	tests.Execute2E(set.PollFirst()).NoError(t).Equal(t, objects.WrapInt(30))
+	tests.Execute2E(set.PollFirst()).ErrorCode(t, ErrorCodeNotFound)
+	tests.Execute2E(set.PollLast()).ErrorCode(t, ErrorCodeNotFound)
+}
+
+func TestTreeSet_Views(t *testing.T) {
+	set := NewTreeSetT[*objects.Int](ascendingInts())
+	for _, value := range []int{10, 20, 30, 40, 50} {
+		tests.ExecuteE(set.Add(objects.WrapInt(value))).NoError(t)
+	}
+
+	values := func(set SortedSet[*objects.Int]) []int {
+		var values []int
+		for value := range set.Elems() {
+			values = append(values, value.Unwrap())
+		}
+		return values
+	}
+
+	tests.Execute(values(set.HeadSet(objects.WrapInt(30), false))).Equal(t, []int{10, 20})
+	tests.Execute(values(set.TailSet(objects.WrapInt(30), true))).Equal(t, []int{30, 40, 50})
+
+	view := set.SubSet(objects.WrapInt(20), true, objects.WrapInt(40), true)
+	tests.Execute(values(view)).Equal(t, []int{20, 30, 40})
+	tests.ExecuteE(view.Add(objects.WrapInt(60))).ErrorCode(t, ErrorCodeOutOfBounds)
+	tests.ExecuteE(view.Add(objects.WrapInt(35))).NoError(t)
+	tests.Execute(set.Contains(objects.WrapInt(35))).Equal(t, true)
+
+	tests.Execute2E(view.PollLast()).NoError(t).Equal(t, objects.WrapInt(40))
+	tests.Execute(values(set)).Equal(t, []int{10, 20, 30, 35, 50})
+}
@@ -0,0 +1,176 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-objects/objects"
+)
+
+// readOnly is implemented by collections that reject every modification.
+type readOnly interface {
+	readOnly()
+}
+
+// IsReadOnly returns true if the given collection rejects every modification, such as the collections returned by
+// the Unmodifiable wrappers.
+func IsReadOnly(collection any) bool {
+	_, ok := collection.(readOnly)
+	return ok
+}
+
+// unmodifiableCollection delegates every read to the wrapped collection, and
+// rejects every write with an ErrorCodeUnsupported error. It is embedded by
+// the unmodifiable wrappers for each of the collection interfaces, which add
+// the methods specific to that interface.
+//
+// Methods that can't return an error, such as Clear, panic instead.
+type unmodifiableCollection[O objects.Object] struct {
+	collection Collection[O]
+}
+
+// Object implementation
+
+// Equals implements objects.Object.
+func (c *unmodifiableCollection[O]) Equals(other any) bool {
+	return c.collection.Equals(other)
+}
+
+// HashCode implements objects.Object.
+func (c *unmodifiableCollection[O]) HashCode() uint64 {
+	return c.collection.HashCode()
+}
+
+// String implements objects.Object.
+func (c *unmodifiableCollection[O]) String() string {
+	return c.collection.String()
+}
+
+// MarshalJSON implements objects.Object.
+func (c *unmodifiableCollection[O]) MarshalJSON() ([]byte, error) {
+	return c.collection.MarshalJSON()
+}
+
+// UnmarshalJSON implements objects.Object. It always returns an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) UnmarshalJSON(bytes []byte) error {
+	return unsupported()
+}
+
+// Iterable implementation
+
+// Iterator implements objects.Iterable.
+func (c *unmodifiableCollection[O]) Iterator() objects.Iterator[O] {
+	return &unmodifiableIterator[O]{
+		iterator: c.collection.Iterator(),
+	}
+}
+
+// Collection implementation
+
+// Elems implements Collection.
+func (c *unmodifiableCollection[O]) Elems() iter.Seq[O] {
+	return c.collection.Elems()
+}
+
+// Add implements Collection. It always returns an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) Add(value O) error {
+	return unsupported()
+}
+
+// AddAll implements Collection. It always returns an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) AddAll(values Collection[O]) error {
+	return unsupported()
+}
+
+// Remove implements Collection. It always returns an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) Remove(value O) error {
+	return unsupported()
+}
+
+// RemoveAll implements Collection. It always returns an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) RemoveAll(values Collection[O]) error {
+	return unsupported()
+}
+
+// Contains implements Collection.
+func (c *unmodifiableCollection[O]) Contains(value O) bool {
+	return c.collection.Contains(value)
+}
+
+// ContainsAll implements Collection.
+func (c *unmodifiableCollection[O]) ContainsAll(values Collection[O]) bool {
+	return c.collection.ContainsAll(values)
+}
+
+// RemoveIf implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) RemoveIf(condition func(value O) bool) int {
+	panic(unsupported())
+}
+
+// RetainAll implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) RetainAll(values Collection[O]) int {
+	panic(unsupported())
+}
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (c *unmodifiableCollection[O]) Copy() Collection[O] {
+	return unmodifiable[O](c.collection.Copy())
+}
+
+// Size implements Collection.
+func (c *unmodifiableCollection[O]) Size() int {
+	return c.collection.Size()
+}
+
+// IsEmpty implements Collection.
+func (c *unmodifiableCollection[O]) IsEmpty() bool {
+	return c.collection.IsEmpty()
+}
+
+// Clear implements Collection. It always panics with an ErrorCodeUnsupported error.
+func (c *unmodifiableCollection[O]) Clear() {
+	panic(unsupported())
+}
+
+// unmodifiable collection
+
+func (c *unmodifiableCollection[O]) readOnly() {}
+
+// unmodifiable wraps the given collection in the most specific unmodifiable wrapper for its type.
+//
+// Stack and Queue have the same methods, so a type switch can't tell them apart. Only lists and sets are matched, and
+// other collections get the plain wrapper; callers that hold a stack or a queue wrap it with UnmodifiableStack or
+// UnmodifiableQueue instead.
+func unmodifiable[O objects.Object](collection Collection[O]) Collection[O] {
+	switch collection := collection.(type) {
+	case List[O]:
+		return UnmodifiableList[O](collection)
+	case Set[O]:
+		return UnmodifiableSet[O](collection)
+	default:
+		return &unmodifiableCollection[O]{
+			collection: collection,
+		}
+	}
+}
+
+// unsupported returns the error returned by every mutating method of the unmodifiable wrappers.
+func unsupported() error {
+	return errors.New(nil, ErrorCodeUnsupported, "unsupported")
+}
+
+// unmodifiableIterator hides the wrapped iterator, so callers can't reach any
+// mutating methods it might have through a type assertion.
+type unmodifiableIterator[O objects.Object] struct {
+	iterator objects.Iterator[O]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *unmodifiableIterator[O]) HasNext() bool {
+	return iterator.iterator.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *unmodifiableIterator[O]) Next() O {
+	return iterator.iterator.Next()
+}
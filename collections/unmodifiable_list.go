@@ -0,0 +1,136 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type unmodifiableList[O objects.Object] struct {
+	unmodifiableCollection[O]
+	list List[O]
+}
+
+// UnmodifiableList returns a read-only view of the given list. Changes made to the list directly are visible through
+// the view, but every mutating method of the view returns an ErrorCodeUnsupported error, or panics if it can't
+// return an error.
+func UnmodifiableList[O objects.Object](list List[O]) List[O] {
+	if unmodifiable, ok := list.(*unmodifiableList[O]); ok {
+		return unmodifiable
+	}
+	return &unmodifiableList[O]{
+		unmodifiableCollection: unmodifiableCollection[O]{
+			collection: list,
+		},
+		list: list,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (l *unmodifiableList[O]) Copy() Collection[O] {
+	return UnmodifiableList[O](l.list.Copy().(List[O]))
+}
+
+// List implementation
+
+// IndexOf implements List.
+func (l *unmodifiableList[O]) IndexOf(value O) int {
+	return l.list.IndexOf(value)
+}
+
+// Get implements List.
+func (l *unmodifiableList[O]) Get(ix int) (O, error) {
+	return l.list.Get(ix)
+}
+
+// Insert implements List. It always returns an ErrorCodeUnsupported error.
+func (l *unmodifiableList[O]) Insert(value O, ix int) error {
+	return unsupported()
+}
+
+// Replace implements List. It always returns an ErrorCodeUnsupported error.
+func (l *unmodifiableList[O]) Replace(value O, ix int) (O, error) {
+	var null O
+	return null, unsupported()
+}
+
+// RemoveAt implements List. It always returns an ErrorCodeUnsupported error.
+func (l *unmodifiableList[O]) RemoveAt(ix int) (O, error) {
+	var null O
+	return null, unsupported()
+}
+
+// ReplaceAll implements List. It always panics with an ErrorCodeUnsupported error.
+func (l *unmodifiableList[O]) ReplaceAll(fn func(value O) O) {
+	panic(unsupported())
+}
+
+// ListIterator implements List. The iterator panics with an ErrorCodeUnsupported error if it is asked to change the
+// list.
+func (l *unmodifiableList[O]) ListIterator() ListIterator[O] {
+	return &unmodifiableListIterator[O]{
+		iterator: l.list.ListIterator(),
+	}
+}
+
+// ListIteratorAt implements List. The iterator panics with an ErrorCodeUnsupported error if it is asked to change the
+// list.
+func (l *unmodifiableList[O]) ListIteratorAt(ix int) (ListIterator[O], error) {
+	iterator, err := l.list.ListIteratorAt(ix)
+	if err != nil {
+		return nil, err
+	}
+	return &unmodifiableListIterator[O]{
+		iterator: iterator,
+	}, nil
+}
+
+// unmodifiable list
+
+// unmodifiableListIterator passes reads through to the wrapped iterator, and rejects every write.
+type unmodifiableListIterator[O objects.Object] struct {
+	iterator ListIterator[O]
+}
+
+// HasNext implements objects.Iterator.
+func (iterator *unmodifiableListIterator[O]) HasNext() bool {
+	return iterator.iterator.HasNext()
+}
+
+// Next implements objects.Iterator.
+func (iterator *unmodifiableListIterator[O]) Next() O {
+	return iterator.iterator.Next()
+}
+
+// HasPrevious implements ListIterator.
+func (iterator *unmodifiableListIterator[O]) HasPrevious() bool {
+	return iterator.iterator.HasPrevious()
+}
+
+// Previous implements ListIterator.
+func (iterator *unmodifiableListIterator[O]) Previous() O {
+	return iterator.iterator.Previous()
+}
+
+// NextIndex implements ListIterator.
+func (iterator *unmodifiableListIterator[O]) NextIndex() int {
+	return iterator.iterator.NextIndex()
+}
+
+// PreviousIndex implements ListIterator.
+func (iterator *unmodifiableListIterator[O]) PreviousIndex() int {
+	return iterator.iterator.PreviousIndex()
+}
+
+// Remove implements ListIterator. It always panics with an ErrorCodeUnsupported error.
+func (iterator *unmodifiableListIterator[O]) Remove() {
+	panic(unsupported())
+}
+
+// Set implements ListIterator. It always panics with an ErrorCodeUnsupported error.
+func (iterator *unmodifiableListIterator[O]) Set(value O) {
+	panic(unsupported())
+}
+
+// Add implements ListIterator. It always panics with an ErrorCodeUnsupported error.
+func (iterator *unmodifiableListIterator[O]) Add(value O) {
+	panic(unsupported())
+}
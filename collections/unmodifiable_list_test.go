@@ -0,0 +1,82 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestUnmodifiableList(t *testing.T) {
+	list := NewArrayList[*objects.String](objects.WrapString("one"), objects.WrapString("two"))
+	view := UnmodifiableList[*objects.String](list)
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.Execute(IsReadOnly(list)).Equal(t, false)
+	tests.Execute(view.Equals(list)).Equal(t, true)
+	tests.Execute(view.Size()).Equal(t, 2)
+	tests.Execute(view.IndexOf(objects.WrapString("two"))).Equal(t, 1)
+	tests.Execute2E(view.Get(0)).NoError(t).Equal(t, objects.WrapString("one"))
+
+	tests.ExecuteE(view.Add(objects.WrapString("three"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.Remove(objects.WrapString("one"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.Insert(objects.WrapString("three"), 0)).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.Replace(objects.WrapString("three"), 0)).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.RemoveAt(0)).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.UnmarshalJSON([]byte(`["three"]`))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute(view.Size()).Equal(t, 2)
+
+	// The view reflects changes made to the list directly, but copies don't.
+	copied := view.Copy()
+	tests.ExecuteE(list.Add(objects.WrapString("three"))).NoError(t)
+	tests.Execute(view.Size()).Equal(t, 3)
+	tests.Execute(copied.Size()).Equal(t, 2)
+	tests.Execute(IsReadOnly(copied)).Equal(t, true)
+}
+
+func TestUnmodifiableList_Clear(t *testing.T) {
+	view := UnmodifiableList[*objects.String](NewArrayList[*objects.String](objects.WrapString("one")))
+
+	defer func() {
+		err, _ := recover().(error)
+		tests.ExecuteE(err).ErrorCode(t, ErrorCodeUnsupported)
+		tests.Execute(view.Size()).Equal(t, 1)
+	}()
+	view.Clear()
+}
+
+func TestUnmodifiableList_ListIterator(t *testing.T) {
+	view := UnmodifiableList[*objects.String](NewArrayList[*objects.String](objects.WrapString("one")))
+
+	iterator := view.ListIterator()
+	tests.Execute(iterator.Next()).Equal(t, objects.WrapString("one"))
+	tests.Execute(iterator.Previous()).Equal(t, objects.WrapString("one"))
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		iterator.Remove()
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		iterator.Set(objects.WrapString("two"))
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		iterator.Add(objects.WrapString("two"))
+	})
+	tests.Execute(view.Size()).Equal(t, 1)
+
+	_, ok := view.Iterator().(MutableIterator[*objects.String])
+	tests.Execute(ok).Equal(t, false)
+}
+
+func TestUnmodifiableList_Bulk(t *testing.T) {
+	view := UnmodifiableList[*objects.String](NewArrayList[*objects.String](objects.WrapString("one")))
+
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.RemoveIf(func(value *objects.String) bool { return true })
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.RetainAll(NewArrayList[*objects.String]())
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.ReplaceAll(func(value *objects.String) *objects.String { return value })
+	})
+	tests.Execute(view.Size()).Equal(t, 1)
+}
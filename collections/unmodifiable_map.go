@@ -0,0 +1,135 @@
+package collections
+
+import (
+	"iter"
+
+	"github.com/pasataleo/go-objects/objects"
+)
+
+type unmodifiableMap[K, V objects.Object] struct {
+	unmodifiableCollection[MapEntry[K, V]]
+	m Map[K, V]
+}
+
+// UnmodifiableMap returns a read-only view of the given map. Changes made to the map directly are visible through
+// the view, but every mutating method of the view returns an ErrorCodeUnsupported error, or panics if it can't
+// return an error.
+func UnmodifiableMap[K, V objects.Object](m Map[K, V]) Map[K, V] {
+	if unmodifiable, ok := m.(*unmodifiableMap[K, V]); ok {
+		return unmodifiable
+	}
+	return &unmodifiableMap[K, V]{
+		unmodifiableCollection: unmodifiableCollection[MapEntry[K, V]]{
+			collection: m,
+		},
+		m: m,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (m *unmodifiableMap[K, V]) Copy() Collection[MapEntry[K, V]] {
+	return UnmodifiableMap[K, V](m.m.Copy().(Map[K, V]))
+}
+
+// Map implementation
+
+// Entries implements Map.
+func (m *unmodifiableMap[K, V]) Entries() iter.Seq2[K, V] {
+	return m.m.Entries()
+}
+
+// ContainsKey implements Map.
+func (m *unmodifiableMap[K, V]) ContainsKey(key K) bool {
+	return m.m.ContainsKey(key)
+}
+
+// Put implements Map. It always returns an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) Put(key K, value V) error {
+	return unsupported()
+}
+
+// Replace implements Map. It always returns an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) Replace(key K, value V) (V, error) {
+	var null V
+	return null, unsupported()
+}
+
+// PutOrReplace implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) PutOrReplace(key K, value V) (V, bool) {
+	panic(unsupported())
+}
+
+// Delete implements Map. It always returns an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) Delete(key K) (V, error) {
+	var null V
+	return null, unsupported()
+}
+
+// DeleteIfPresent implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) DeleteIfPresent(key K) (V, bool) {
+	panic(unsupported())
+}
+
+// Get implements Map.
+func (m *unmodifiableMap[K, V]) Get(key K) V {
+	return m.m.Get(key)
+}
+
+// GetSafe implements Map.
+func (m *unmodifiableMap[K, V]) GetSafe(key K) (V, error) {
+	return m.m.GetSafe(key)
+}
+
+// GetOrDefault implements Map.
+func (m *unmodifiableMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	return m.m.GetOrDefault(key, defaultValue)
+}
+
+// PutIfAbsent implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	panic(unsupported())
+}
+
+// ComputeIfAbsent implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	panic(unsupported())
+}
+
+// ComputeIfPresent implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) ComputeIfPresent(key K, fn func(key K, current V) (V, bool)) (V, bool) {
+	panic(unsupported())
+}
+
+// Compute implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) Compute(key K, fn func(key K, current V, present bool) (V, bool)) (V, bool) {
+	panic(unsupported())
+}
+
+// Merge implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) Merge(key K, value V, remapping func(current V, value V) (V, bool)) (V, bool) {
+	panic(unsupported())
+}
+
+// ReplaceAllValues implements Map. It always panics with an ErrorCodeUnsupported error.
+func (m *unmodifiableMap[K, V]) ReplaceAllValues(fn func(key K, value V) V) {
+	panic(unsupported())
+}
+
+// Keys implements Map. The keys are also unmodifiable.
+func (m *unmodifiableMap[K, V]) Keys() Collection[K] {
+	return unmodifiable[K](m.m.Keys())
+}
+
+// Values implements Map. The values are also unmodifiable.
+func (m *unmodifiableMap[K, V]) Values() Collection[V] {
+	return unmodifiable[V](m.m.Values())
+}
+
+// unmodifiable map
+
+// writesOnRead returns true if lookups can change the underlying map.
+func (m *unmodifiableMap[K, V]) writesOnRead() bool {
+	return mapWritesOnRead(m.m)
+}
@@ -0,0 +1,62 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestUnmodifiableMap(t *testing.T) {
+	m := NewHashMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(m.Put(objects.WrapString("one"), objects.WrapInt(1))).NoError(t)
+	view := UnmodifiableMap[*objects.String, *objects.Int](m)
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.Execute(view.ContainsKey(objects.WrapString("one"))).Equal(t, true)
+	tests.Execute(view.Get(objects.WrapString("one"))).Equal(t, objects.WrapInt(1))
+	tests.Execute2E(view.GetSafe(objects.WrapString("two"))).ErrorCode(t, ErrorCodeNotFound)
+
+	tests.ExecuteE(view.Put(objects.WrapString("two"), objects.WrapInt(2))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.Replace(objects.WrapString("one"), objects.WrapInt(2))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.Delete(objects.WrapString("one"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute(view.Size()).Equal(t, 1)
+
+	// The keys and values can't be used to modify the map either.
+	tests.Execute(IsReadOnly(view.Keys())).Equal(t, true)
+	tests.Execute(IsReadOnly(view.Values())).Equal(t, true)
+	tests.ExecuteE(view.Keys().Add(objects.WrapString("two"))).ErrorCode(t, ErrorCodeUnsupported)
+
+	tests.ExecuteE(m.Put(objects.WrapString("two"), objects.WrapInt(2))).NoError(t)
+	tests.Execute(view.Size()).Equal(t, 2)
+	tests.Execute(IsReadOnly(view.Copy())).Equal(t, true)
+}
+
+func TestUnmodifiableMap_Compute(t *testing.T) {
+	m := NewHashMap[*objects.String, *objects.Int]()
+	tests.ExecuteE(m.Put(objects.WrapString("one"), objects.WrapInt(1))).NoError(t)
+	view := UnmodifiableMap[*objects.String, *objects.Int](m)
+
+	tests.Execute(view.GetOrDefault(objects.WrapString("one"), objects.WrapInt(0))).Equal(t, objects.WrapInt(1))
+	tests.Execute(view.GetOrDefault(objects.WrapString("two"), objects.WrapInt(0))).Equal(t, objects.WrapInt(0))
+
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.PutIfAbsent(objects.WrapString("two"), objects.WrapInt(2))
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.ComputeIfAbsent(objects.WrapString("two"), func(key *objects.String) *objects.Int {
+			return objects.WrapInt(2)
+		})
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.Merge(objects.WrapString("one"), objects.WrapInt(2), func(current, value *objects.Int) (*objects.Int, bool) {
+			return value, true
+		})
+	})
+	expectErrorCode(t, ErrorCodeUnsupported, func() {
+		view.ReplaceAllValues(func(key *objects.String, value *objects.Int) *objects.Int {
+			return value
+		})
+	})
+	tests.Execute(m.Get(objects.WrapString("one"))).Equal(t, objects.WrapInt(1))
+}
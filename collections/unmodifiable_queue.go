@@ -0,0 +1,48 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type unmodifiableQueue[O objects.Object] struct {
+	unmodifiableCollection[O]
+	queue Queue[O]
+}
+
+// UnmodifiableQueue returns a read-only view of the given queue. Changes made to the queue directly are visible
+// through the view, but every mutating method of the view returns an ErrorCodeUnsupported error, or panics if it
+// can't return an error.
+func UnmodifiableQueue[O objects.Object](queue Queue[O]) Queue[O] {
+	if unmodifiable, ok := queue.(*unmodifiableQueue[O]); ok {
+		return unmodifiable
+	}
+	return &unmodifiableQueue[O]{
+		unmodifiableCollection: unmodifiableCollection[O]{
+			collection: queue,
+		},
+		queue: queue,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (s *unmodifiableQueue[O]) Copy() Collection[O] {
+	return UnmodifiableQueue[O](s.queue.Copy().(Queue[O]))
+}
+
+// Queue implementation
+
+// Offer implements Queue. It always returns an ErrorCodeUnsupported error.
+func (s *unmodifiableQueue[O]) Offer(value O) error {
+	return unsupported()
+}
+
+// Peep implements Queue.
+func (s *unmodifiableQueue[O]) Peep() (O, error) {
+	return s.queue.Peep()
+}
+
+// Pop implements Queue. It always returns an ErrorCodeUnsupported error.
+func (s *unmodifiableQueue[O]) Pop() (O, error) {
+	var null O
+	return null, unsupported()
+}
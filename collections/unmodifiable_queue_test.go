@@ -0,0 +1,20 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestUnmodifiableQueue(t *testing.T) {
+	queue := NewArrayQueue[*objects.String]()
+	tests.ExecuteE(queue.Offer(objects.WrapString("one"))).NoError(t)
+	view := UnmodifiableQueue[*objects.String](queue)
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.Execute2E(view.Peep()).NoError(t).Equal(t, objects.WrapString("one"))
+	tests.ExecuteE(view.Offer(objects.WrapString("two"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.Pop()).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute(view.Size()).Equal(t, 1)
+}
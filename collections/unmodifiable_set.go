@@ -0,0 +1,65 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type unmodifiableSet[O objects.Object] struct {
+	unmodifiableCollection[O]
+}
+
+// UnmodifiableSet returns a read-only view of the given set. Changes made to the set directly are visible through
+// the view, but every mutating method of the view returns an ErrorCodeUnsupported error, or panics if it can't
+// return an error.
+func UnmodifiableSet[O objects.Object](set Set[O]) Set[O] {
+	if unmodifiable, ok := set.(*unmodifiableSet[O]); ok {
+		return unmodifiable
+	}
+	return &unmodifiableSet[O]{
+		unmodifiableCollection: unmodifiableCollection[O]{
+			collection: set,
+		},
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (s *unmodifiableSet[O]) Copy() Collection[O] {
+	return UnmodifiableSet[O](s.collection.Copy().(Set[O]))
+}
+
+// Set implementation
+
+// Union implements Set.
+func (s *unmodifiableSet[O]) Union(other Set[O]) Set[O] {
+	return Union[O](s, other)
+}
+
+// Intersection implements Set.
+func (s *unmodifiableSet[O]) Intersection(other Set[O]) Set[O] {
+	return Intersection[O](s, other)
+}
+
+// Difference implements Set.
+func (s *unmodifiableSet[O]) Difference(other Set[O]) Set[O] {
+	return Difference[O](s, other)
+}
+
+// SymmetricDifference implements Set.
+func (s *unmodifiableSet[O]) SymmetricDifference(other Set[O]) Set[O] {
+	return SymmetricDifference[O](s, other)
+}
+
+// IsSubsetOf implements Set.
+func (s *unmodifiableSet[O]) IsSubsetOf(other Set[O]) bool {
+	return IsSubset[O](s, other)
+}
+
+// IsSupersetOf implements Set.
+func (s *unmodifiableSet[O]) IsSupersetOf(other Set[O]) bool {
+	return IsSuperset[O](s, other)
+}
+
+// IsDisjoint implements Set.
+func (s *unmodifiableSet[O]) IsDisjoint(other Set[O]) bool {
+	return IsDisjoint[O](s, other)
+}
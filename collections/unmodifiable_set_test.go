@@ -0,0 +1,23 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestUnmodifiableSet(t *testing.T) {
+	set := NewLinkedHashSet[*objects.String](objects.WrapString("one"))
+	view := UnmodifiableSet[*objects.String](set)
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.Execute(view.Contains(objects.WrapString("one"))).Equal(t, true)
+	tests.Execute(view.Equals(set)).Equal(t, true)
+	tests.Execute(UnmodifiableSet[*objects.String](view)).Equal(t, view)
+
+	tests.ExecuteE(view.Add(objects.WrapString("two"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.AddAll(NewLinkedHashSet[*objects.String](objects.WrapString("two")))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.ExecuteE(view.RemoveAll(set)).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute(view.Size()).Equal(t, 1)
+}
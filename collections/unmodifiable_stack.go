@@ -0,0 +1,48 @@
+package collections
+
+import "github.com/pasataleo/go-objects/objects"
+
+type unmodifiableStack[O objects.Object] struct {
+	unmodifiableCollection[O]
+	stack Stack[O]
+}
+
+// UnmodifiableStack returns a read-only view of the given stack. Changes made to the stack directly are visible
+// through the view, but every mutating method of the view returns an ErrorCodeUnsupported error, or panics if it
+// can't return an error.
+func UnmodifiableStack[O objects.Object](stack Stack[O]) Stack[O] {
+	if unmodifiable, ok := stack.(*unmodifiableStack[O]); ok {
+		return unmodifiable
+	}
+	return &unmodifiableStack[O]{
+		unmodifiableCollection: unmodifiableCollection[O]{
+			collection: stack,
+		},
+		stack: stack,
+	}
+}
+
+// Collection implementation
+
+// Copy implements Collection. The copy is also unmodifiable, and doesn't reflect later changes to the original.
+func (s *unmodifiableStack[O]) Copy() Collection[O] {
+	return UnmodifiableStack[O](s.stack.Copy().(Stack[O]))
+}
+
+// Stack implementation
+
+// Offer implements Stack. It always returns an ErrorCodeUnsupported error.
+func (s *unmodifiableStack[O]) Offer(value O) error {
+	return unsupported()
+}
+
+// Peep implements Stack.
+func (s *unmodifiableStack[O]) Peep() (O, error) {
+	return s.stack.Peep()
+}
+
+// Pop implements Stack. It always returns an ErrorCodeUnsupported error.
+func (s *unmodifiableStack[O]) Pop() (O, error) {
+	var null O
+	return null, unsupported()
+}
@@ -0,0 +1,53 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-objects/objects"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestUnmodifiableStack(t *testing.T) {
+	stack := NewArrayStack[*objects.String]()
+	tests.ExecuteE(stack.Offer(objects.WrapString("one"))).NoError(t)
+	tests.ExecuteE(stack.Offer(objects.WrapString("two"))).NoError(t)
+	view := UnmodifiableStack[*objects.String](stack)
+
+	tests.Execute(IsReadOnly(view)).Equal(t, true)
+	tests.Execute2E(view.Peep()).NoError(t).Equal(t, objects.WrapString("two"))
+	tests.ExecuteE(view.Offer(objects.WrapString("three"))).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute2E(view.Pop()).ErrorCode(t, ErrorCodeUnsupported)
+	tests.Execute(view.Size()).Equal(t, 2)
+}
+
+func TestUnmodifiableStack_Wrap(t *testing.T) {
+	for name, stack := range map[string]Stack[*objects.String]{
+		"array":        NewArrayStack[*objects.String](),
+		"linked":       NewStack[*objects.String](),
+		"synchronized": SynchronizedStack[*objects.String](NewStack[*objects.String]()),
+		"custom":       &customStack[*objects.String]{Stack: NewStack[*objects.String]()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tests.ExecuteE(stack.Offer(objects.WrapString("one"))).NoError(t)
+			tests.ExecuteE(stack.Offer(objects.WrapString("two"))).NoError(t)
+
+			view := UnmodifiableStack[*objects.String](stack)
+			tests.Execute2E(view.Peep()).NoError(t).Equal(t, objects.WrapString("two"))
+			tests.ExecuteE(view.Offer(objects.WrapString("three"))).ErrorCode(t, ErrorCodeUnsupported)
+			tests.Execute(view.Size()).Equal(t, 2)
+
+			_, ok := view.Copy().(*unmodifiableStack[*objects.String])
+			tests.Execute(ok).Equal(t, true)
+
+			// Stacks have the same methods as queues, so wrapping one as a
+			// plain collection must not guess that it is a queue.
+			_, ok = unmodifiable[*objects.String](stack).(*unmodifiableQueue[*objects.String])
+			tests.Execute(ok).Equal(t, false)
+		})
+	}
+}
+
+// customStack is a stack from outside this package.
+type customStack[O objects.Object] struct {
+	Stack[O]
+}